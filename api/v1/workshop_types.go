@@ -30,15 +30,269 @@ type WorkshopSpec struct {
 	Source         SourceSpec         `json:"source"`
 	Infrastructure InfrastructureSpec `json:"infrastructure"`
 	UserDetails    UserDetailsSpec    `json:"userDetails"`
+	// Naming scopes the names of the workshop objects, so several workshops can run on one cluster.
+	// A workshop sharing its scope with an older workshop is not reconciled.
+	// +optional
+	Naming NamingSpec `json:"naming,omitempty"`
+	// Plan reports the changes the operator would make in a ConfigMap instead of applying them.
+	// It can also be enabled with the workshop.stakater.com/plan: "true" annotation.
+	// +optional
+	Plan bool `json:"plan,omitempty"`
+	// Cluster describes the cluster the workshop runs on
+	// +optional
+	Cluster ClusterSpec `json:"cluster,omitempty"`
+	// Portal configures the portal attendees claim their users in
+	// +optional
+	Portal PortalSpec `json:"portal,omitempty"`
+}
+
+// PortalSpec ...
+type PortalSpec struct {
+	// Image of the portal, the image the operator is configured with unless set
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Title shown on the portal pages, OpenShift Workshops unless set
+	// +optional
+	Title string `json:"title,omitempty"`
+	// Duration of the event, attendees stay logged in to the portal for as long. Defaults to 168h.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	// +optional
+	Duration string `json:"duration,omitempty"`
+	// AccessTokenSecretName is the Secret in the workshop namespace holding the code attendees enter to claim a user
+	// under the accessToken key. A code is generated in the <workshop>-portal-access-token Secret unless set.
+	// +optional
+	AccessTokenSecretName string `json:"accessTokenSecretName,omitempty"`
+	// AdminPasswordSecretName is the Secret in the workshop namespace holding the password of the admin page
+	// under the password key. A password is generated in the <workshop>-portal-admin-credentials Secret
+	// unless set.
+	// +optional
+	AdminPasswordSecretName string `json:"adminPasswordSecretName,omitempty"`
+	// Route exposes the portal over TLS
+	// +optional
+	Route PortalRouteSpec `json:"route,omitempty"`
+}
+
+// PortalRouteSpec exposes the portal with TLS terminated at the router
+type PortalRouteSpec struct {
+	// Host of the portal, <workshop>-portal-<namespace>.<apps domain> unless set
+	// +optional
+	Host string `json:"host,omitempty"`
+	// CertificateSecretName is a kubernetes.io/tls Secret in the workshop namespace with the certificate of the host.
+	// The default certificate of the router is used unless set.
+	// +optional
+	CertificateSecretName string `json:"certificateSecretName,omitempty"`
+	// InsecureEdgeTerminationPolicy is what happens to plain HTTP requests on OpenShift, Redirect unless set
+	// +kubebuilder:validation:Enum=Redirect;Allow;None
+	// +optional
+	InsecureEdgeTerminationPolicy string `json:"insecureEdgeTerminationPolicy,omitempty"`
+}
+
+// ClusterSpec ...
+type ClusterSpec struct {
+	// AppsDomain is the domain of the application routes, e.g. apps.cluster.example.com.
+	// When empty it is read from the cluster ingress config, then from the console route.
+	// +optional
+	AppsDomain string `json:"appsDomain,omitempty"`
+}
+
+// NamingSpec ...
+type NamingSpec struct {
+	// Prefix is prepended to the namespaces, user names and shared secrets of the workshop
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Suffix is appended to the namespaces and shared secrets of the workshop, user names end with their number
+	// and only carry the prefix
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
 }
 
 // UserDetailsSpec ...
 type UserDetailsSpec struct {
-	UserNamePrefix  string `json:"userNamePrefix"`
-	NumberOfUsers   int    `json:"numberOfUsers"`
-	DefaultPassword string `json:"defaultPassword"`
+	// UserNamePrefix and NumberOfUsers generate the attendees user1 to userN when no roster is set.
+	// The prefix defaults to user, every component names users with it.
+	// +kubebuilder:validation:Pattern=`^[a-z][-a-z0-9]*$`
+	// +optional
+	UserNamePrefix string `json:"userNamePrefix,omitempty"`
+	// +optional
+	NumberOfUsers int `json:"numberOfUsers,omitempty"`
+	// UserNumberDigits pads the numbers of generated users with zeros, e.g. 2 for user01 to user20
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=9
+	// +optional
+	UserNumberDigits int    `json:"userNumberDigits,omitempty"`
+	DefaultPassword  string `json:"defaultPassword"`
+	// Roster lists the attendees by name, it replaces UserNamePrefix and NumberOfUsers
+	// +optional
+	Roster *RosterSpec `json:"roster,omitempty"`
+	// PasswordMode is shared to give every attendee DefaultPassword, or perUser to generate a password
+	// per attendee, stored in a Secret labelled with the user name
+	// +kubebuilder:validation:Enum=shared;perUser
+	// +optional
+	PasswordMode string `json:"passwordMode,omitempty"`
+	// IdentityProvider is how attendees log in, by default with a password through an htpasswd identity provider
+	// +optional
+	IdentityProvider *IdentityProviderSpec `json:"identityProvider,omitempty"`
+	// Instructors are the roles of attendees with the instructor role, on top of those of the other attendees
+	// +optional
+	Instructors *InstructorsSpec `json:"instructors,omitempty"`
+}
+
+// InstructorsSpec are the elevated roles of the instructors group
+type InstructorsSpec struct {
+	// ProjectRole is the cluster role instructors are granted in every attendee project, view unless set
+	// +optional
+	ProjectRole string `json:"projectRole,omitempty"`
+	// ToolsAdmin makes instructors administrators of Gitea, Argo CD and CodeReady Workspaces, true unless set
+	// +optional
+	ToolsAdmin *bool `json:"toolsAdmin,omitempty"`
+}
+
+// IdentityProviderSpec is the identity provider the operator registers in the cluster OAuth config.
+// With OpenID, LDAP or GitHub attendees log in with existing accounts, no users are created for them
+// and their resources are provisioned once their user appears, i.e. on their first login.
+type IdentityProviderSpec struct {
+	// Type of the identity provider, HTPasswd unless set
+	// +kubebuilder:validation:Enum=HTPasswd;OpenID;LDAP;GitHub
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Name of the identity provider shown on the login page, defaults to the type followed by -workshop-users.
+	// It must not be the name of an identity provider the workshop did not add.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	OpenID *OpenIDProviderSpec `json:"openID,omitempty"`
+	// +optional
+	LDAP *LDAPProviderSpec `json:"ldap,omitempty"`
+	// +optional
+	GitHub *GitHubProviderSpec `json:"github,omitempty"`
+}
+
+const (
+	// IdentityProviderHTPasswd creates a user with a password per attendee
+	IdentityProviderHTPasswd = "HTPasswd"
+	// IdentityProviderOpenID lets attendees log in with an OpenID Connect provider, e.g. Dex or Keycloak
+	IdentityProviderOpenID = "OpenID"
+	// IdentityProviderLDAP lets attendees log in with LDAP accounts
+	IdentityProviderLDAP = "LDAP"
+	// IdentityProviderGitHub lets attendees log in with GitHub accounts
+	IdentityProviderGitHub = "GitHub"
+)
+
+// OpenIDProviderSpec configures an OpenID Connect identity provider.
+// Secrets and ConfigMaps are read from the openshift-config namespace.
+type OpenIDProviderSpec struct {
+	ClientID string `json:"clientID"`
+	// ClientSecretName is the Secret holding the client secret under the clientSecret key
+	ClientSecretName string `json:"clientSecretName"`
+	Issuer           string `json:"issuer"`
+	// CAName is the ConfigMap holding the CA bundle of the issuer under the ca.crt key
+	// +optional
+	CAName string `json:"caName,omitempty"`
+	// +optional
+	ExtraScopes []string `json:"extraScopes,omitempty"`
+	// UsernameClaim is the claim holding the user name, which must match the attendee name. Defaults to preferred_username.
+	// +optional
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+}
+
+// LDAPProviderSpec configures an LDAP identity provider.
+// Secrets and ConfigMaps are read from the openshift-config namespace.
+type LDAPProviderSpec struct {
+	// URL is an RFC 2255 URL of the search, e.g. ldap://ldap.example.com/ou=users,dc=example,dc=com?uid
+	URL string `json:"url"`
+	// +optional
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPasswordSecretName is the Secret holding the bind password under the bindPassword key
+	// +optional
+	BindPasswordSecretName string `json:"bindPasswordSecretName,omitempty"`
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// CAName is the ConfigMap holding the CA bundle of the server under the ca.crt key
+	// +optional
+	CAName string `json:"caName,omitempty"`
+	// UsernameAttribute is the attribute holding the user name, which must match the attendee name. Defaults to uid.
+	// +optional
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+}
+
+// GitHubProviderSpec configures a GitHub identity provider.
+// Secrets and ConfigMaps are read from the openshift-config namespace.
+type GitHubProviderSpec struct {
+	ClientID string `json:"clientID"`
+	// ClientSecretName is the Secret holding the client secret under the clientSecret key
+	ClientSecretName string `json:"clientSecretName"`
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+	// Hostname of a GitHub Enterprise instance
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+	// CAName is the ConfigMap holding the CA bundle of GitHub Enterprise under the ca.crt key
+	// +optional
+	CAName string `json:"caName,omitempty"`
+}
+
+const (
+	// PasswordModeShared gives every attendee DefaultPassword
+	PasswordModeShared = "shared"
+	// PasswordModePerUser generates a password per attendee
+	PasswordModePerUser = "perUser"
+)
+
+// RosterSpec lists the attendees of the workshop, inline or from a ConfigMap or Secret in the workshop namespace.
+// Attendees are merged by name, inline attendees win.
+type RosterSpec struct {
+	// +optional
+	Attendees []Attendee `json:"attendees,omitempty"`
+	// +optional
+	ConfigMapRef *RosterSourceSpec `json:"configMapRef,omitempty"`
+	// +optional
+	SecretRef *RosterSourceSpec `json:"secretRef,omitempty"`
+}
+
+// RosterSourceSpec references the key of a ConfigMap or Secret holding a roster
+type RosterSourceSpec struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	// Format of the roster, csv with the columns name, email, displayName and role, or yaml with a list of attendees.
+	// Defaults to csv for keys ending in .csv and to yaml otherwise.
+	// +kubebuilder:validation:Enum=csv;yaml
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+const (
+	// RosterFormatCSV is a roster with one attendee per line
+	RosterFormatCSV = "csv"
+	// RosterFormatYAML is a roster with a list of attendees
+	RosterFormatYAML = "yaml"
+)
+
+// Attendee is a named workshop attendee
+type Attendee struct {
+	// Name is the user name of the attendee, it is used as is in the names of their resources
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+	// +optional
+	Email string `json:"email,omitempty"`
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// Role of the attendee in the workshop, attendee unless set
+	// +optional
+	Role string `json:"role,omitempty"`
 }
 
+const (
+	// RoleAttendee is the role of workshop participants
+	RoleAttendee = "attendee"
+	// RoleInstructor is the role of people running the workshop
+	RoleInstructor = "instructor"
+)
+
 // SourceSpec ...
 type SourceSpec struct {
 	GitURL    string `json:"gitURL"`
@@ -104,8 +358,125 @@ type PipelineSpec struct {
 
 // ProjectSpec ...
 type ProjectSpec struct {
-	Enabled     bool   `json:"enabled"`
-	StagingName string `json:"stagingName"`
+	Enabled bool `json:"enabled"`
+	// StagingName is the prefix of the single project of every user, followed by their number or name.
+	// Deprecated: use Templates, StagingName is ignored when Templates are set.
+	// +optional
+	StagingName string `json:"stagingName,omitempty"`
+	// Templates are the projects of every user
+	// +optional
+	Templates []ProjectTemplateSpec `json:"templates,omitempty"`
+	// Policy limits the resources of every user project and isolates it from the other projects
+	// +optional
+	Policy *ProjectPolicySpec `json:"policy,omitempty"`
+	// Seed applies manifests to the projects of every user
+	// +optional
+	Seed *SeedSpec `json:"seed,omitempty"`
+}
+
+// SeedSpec ...
+type SeedSpec struct {
+	// Path of the directory of manifests in the repository of spec.source, e.g. seed. Manifests are plain YAML
+	// rendered as Go templates, kustomize is not supported. A seed.yaml listing the manifests under the manifests key
+	// orders them, otherwise every YAML file of the directory is applied sorted by path.
+	// The branch is checked for new commits every 5 minutes.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// ConfigMapName is a ConfigMap in the workshop namespace holding a manifest per key, used instead of Path
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// Projects are the name patterns of the project templates to seed, every project of the user unless set
+	// +optional
+	Projects []string `json:"projects,omitempty"`
+}
+
+// ProjectTemplateSpec ...
+type ProjectTemplateSpec struct {
+	// Name is the name pattern of the project, a Go template of .UserName and .UserID, e.g. {{.UserName}}-dev.
+	// .UserID is the number of a generated user or the name of a roster attendee.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// DisplayName is the display name pattern of the project, a Go template like Name
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// RoleBindings grant the user cluster roles in the project, edit unless set
+	// +optional
+	RoleBindings []ProjectRoleBindingSpec `json:"roleBindings,omitempty"`
+	// Labels are added to the project
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ProjectRoleBindingSpec ...
+type ProjectRoleBindingSpec struct {
+	// ClusterRole granted to the user in the project
+	// +kubebuilder:validation:MinLength=1
+	ClusterRole string `json:"clusterRole"`
+	// Name of the Role Binding, the user name followed by the cluster role unless set
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// ProjectPolicySpec ...
+type ProjectPolicySpec struct {
+	// Quota of every user project, no ResourceQuota is created if unset
+	// +optional
+	Quota *ProjectQuotaSpec `json:"quota,omitempty"`
+	// LimitRange sets the default resources of containers that set none, no LimitRange is created if unset
+	// +optional
+	LimitRange *ProjectLimitRangeSpec `json:"limitRange,omitempty"`
+	// NetworkIsolation denies ingress traffic from other namespaces, except from the ingress controller on OpenShift
+	// +optional
+	NetworkIsolation bool `json:"networkIsolation,omitempty"`
+}
+
+// ProjectQuotaSpec ...
+type ProjectQuotaSpec struct {
+	// CPU is the sum of the CPU requests of all pods, e.g. 2 or 1500m
+	// +optional
+	CPU string `json:"cpu,omitempty"`
+	// Memory is the sum of the memory requests of all pods, e.g. 4Gi
+	// +optional
+	Memory string `json:"memory,omitempty"`
+	// LimitsCPU is the sum of the CPU limits of all pods
+	// +optional
+	LimitsCPU string `json:"limitsCPU,omitempty"`
+	// LimitsMemory is the sum of the memory limits of all pods
+	// +optional
+	LimitsMemory string `json:"limitsMemory,omitempty"`
+	// Pods is the number of pods that are not terminated
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Pods int `json:"pods,omitempty"`
+	// PersistentVolumeClaims is the number of persistent volume claims
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PersistentVolumeClaims int `json:"persistentVolumeClaims,omitempty"`
+	// Storage is the sum of the storage requests of all persistent volume claims, e.g. 10Gi
+	// +optional
+	Storage string `json:"storage,omitempty"`
+}
+
+// ProjectLimitRangeSpec ...
+type ProjectLimitRangeSpec struct {
+	// DefaultRequestCPU is the CPU request of containers that set none
+	// +optional
+	DefaultRequestCPU string `json:"defaultRequestCPU,omitempty"`
+	// DefaultRequestMemory is the memory request of containers that set none
+	// +optional
+	DefaultRequestMemory string `json:"defaultRequestMemory,omitempty"`
+	// DefaultCPU is the CPU limit of containers that set none
+	// +optional
+	DefaultCPU string `json:"defaultCPU,omitempty"`
+	// DefaultMemory is the memory limit of containers that set none
+	// +optional
+	DefaultMemory string `json:"defaultMemory,omitempty"`
+	// MaxCPU is the highest CPU limit of a container
+	// +optional
+	MaxCPU string `json:"maxCPU,omitempty"`
+	// MaxMemory is the highest memory limit of a container
+	// +optional
+	MaxMemory string `json:"maxMemory,omitempty"`
 }
 
 // ScholarsSpec ...
@@ -173,10 +544,72 @@ type WorkshopStatus struct {
 	Serverless           string `json:"serverless"`
 	UsernameDistribution string `json:"usernameDistribution"`
 	Vault                string `json:"vault"`
+
+	// ObservedGeneration is the most recent generation observed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastError is the last error returned while reconciling the workshop
+	LastError string `json:"lastError,omitempty"`
+	// PortalURL is the URL of the portal to share with the attendees
+	// +optional
+	PortalURL string `json:"portalURL,omitempty"`
+	// LegacyPortalRemoved records that the Redis and username-distribution portal of earlier releases was removed
+	// +optional
+	LegacyPortalRemoved bool `json:"legacyPortalRemoved,omitempty"`
+	// Attendees are the user names provisioned by the operator, attendees missing from the roster are deprovisioned
+	// +optional
+	Attendees []string `json:"attendees,omitempty"`
+	// IdentityProviders are the names of the identity providers the workshop added to the cluster OAuth config
+	// +optional
+	IdentityProviders []string `json:"identityProviders,omitempty"`
+	// Conditions contains one condition per component and an overall Ready condition
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// ConditionReady is the overall readiness condition of the workshop
+const ConditionReady = "Ready"
+
+// ConditionAppsDomainResolved reports whether the apps domain of the cluster could be resolved
+const ConditionAppsDomainResolved = "AppsDomainResolved"
+
+// ConditionUsersCanLogIn reports whether attendees can log in, their users exist and the identity provider is rolled out
+const ConditionUsersCanLogIn = "UsersCanLogIn"
+
+// ConditionNamingScopeUnique reports whether the workshop is the only one using its naming scope
+const ConditionNamingScopeUnique = "NamingScopeUnique"
+
+// ConditionPlanned reports the outcome of the last plan while plan mode is enabled
+const ConditionPlanned = "Planned"
+
+// PlanAnnotation enables plan mode when set to "true"
+const PlanAnnotation = "workshop.stakater.com/plan"
+
+// Condition mirrors metav1.Condition, which is not available in the vendored apimachinery
+type Condition struct {
+	// Type of condition in CamelCase
+	Type string `json:"type"`
+	// Status of the condition, one of True, False, Unknown
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status metav1.ConditionStatus `json:"status"`
+	// ObservedGeneration is the generation the condition was set based upon
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Reason contains a programmatic identifier indicating the reason for the last transition
+	Reason string `json:"reason"`
+	// Message is a human readable message indicating details about the transition
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].message"
+// +kubebuilder:printcolumn:name="Portal",type="string",JSONPath=".status.portalURL"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Workshop is the Schema for the workshops API
 type Workshop struct {
@@ -0,0 +1,79 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkshopAttendeeSpec defines the desired state of WorkshopAttendee
+type WorkshopAttendeeSpec struct {
+	// Workshop is the name of the Workshop in the same namespace that provisions the user
+	Workshop string `json:"workshop"`
+	// UserName is the provisioned user, e.g. user7
+	UserName string `json:"userName"`
+	// Email of the attendee the user is assigned to, set by the portal when an attendee claims the user.
+	// Clear it to release the user, or set another email to reassign it.
+	// +optional
+	Email string `json:"email,omitempty"`
+}
+
+// WorkshopAttendeeStatus defines the observed state of WorkshopAttendee
+type WorkshopAttendeeStatus struct {
+	// Email of the attendee holding the user
+	// +optional
+	Email string `json:"email,omitempty"`
+	// ClaimedAt is when the user was assigned to Email
+	// +optional
+	ClaimedAt *metav1.Time `json:"claimedAt,omitempty"`
+	// LastLogin is the last time the attendee opened the portal
+	// +optional
+	LastLogin *metav1.Time `json:"lastLogin,omitempty"`
+	// Conditions contains the readiness condition of every enabled workshop component the user relies on
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Workshop",type="string",JSONPath=".spec.workshop"
+// +kubebuilder:printcolumn:name="User",type="string",JSONPath=".spec.userName"
+// +kubebuilder:printcolumn:name="Email",type="string",JSONPath=".spec.email"
+// +kubebuilder:printcolumn:name="Claimed",type="date",JSONPath=".status.claimedAt"
+// +kubebuilder:printcolumn:name="Last Login",type="date",JSONPath=".status.lastLogin"
+
+// WorkshopAttendee records which attendee holds a user of a workshop
+type WorkshopAttendee struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkshopAttendeeSpec   `json:"spec,omitempty"`
+	Status WorkshopAttendeeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkshopAttendeeList contains a list of WorkshopAttendee
+type WorkshopAttendeeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkshopAttendee `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkshopAttendee{}, &WorkshopAttendeeList{})
+}
@@ -25,6 +25,21 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Attendee) DeepCopyInto(out *Attendee) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Attendee.
+func (in *Attendee) DeepCopy() *Attendee {
+	if in == nil {
+		return nil
+	}
+	out := new(Attendee)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BookbagSpec) DeepCopyInto(out *BookbagSpec) {
 	*out = *in
@@ -57,6 +72,21 @@ func (in *CertManagerSpec) DeepCopy() *CertManagerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CodeReadyWorkspaceSpec) DeepCopyInto(out *CodeReadyWorkspaceSpec) {
 	*out = *in
@@ -74,6 +104,47 @@ func (in *CodeReadyWorkspaceSpec) DeepCopy() *CodeReadyWorkspaceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubProviderSpec) DeepCopyInto(out *GitHubProviderSpec) {
+	*out = *in
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubProviderSpec.
+func (in *GitHubProviderSpec) DeepCopy() *GitHubProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitOpsSpec) DeepCopyInto(out *GitOpsSpec) {
 	*out = *in
@@ -123,6 +194,36 @@ func (in *GuideSpec) DeepCopy() *GuideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityProviderSpec) DeepCopyInto(out *IdentityProviderSpec) {
+	*out = *in
+	if in.OpenID != nil {
+		in, out := &in.OpenID, &out.OpenID
+		*out = new(OpenIDProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAPProviderSpec)
+		**out = **in
+	}
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(GitHubProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderSpec.
+func (in *IdentityProviderSpec) DeepCopy() *IdentityProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 	*out = *in
@@ -148,7 +249,7 @@ func (in *InfrastructureSpec) DeepCopyInto(out *InfrastructureSpec) {
 	in.Guide.DeepCopyInto(&out.Guide)
 	out.Nexus = in.Nexus
 	out.Pipeline = in.Pipeline
-	out.Project = in.Project
+	in.Project.DeepCopyInto(&out.Project)
 	out.ServiceMesh = in.ServiceMesh
 	out.Serverless = in.Serverless
 	out.Vault = in.Vault
@@ -164,6 +265,56 @@ func (in *InfrastructureSpec) DeepCopy() *InfrastructureSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstructorsSpec) DeepCopyInto(out *InstructorsSpec) {
+	*out = *in
+	if in.ToolsAdmin != nil {
+		in, out := &in.ToolsAdmin, &out.ToolsAdmin
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstructorsSpec.
+func (in *InstructorsSpec) DeepCopy() *InstructorsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstructorsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPProviderSpec) DeepCopyInto(out *LDAPProviderSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPProviderSpec.
+func (in *LDAPProviderSpec) DeepCopy() *LDAPProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamingSpec) DeepCopyInto(out *NamingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamingSpec.
+func (in *NamingSpec) DeepCopy() *NamingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NexusSpec) DeepCopyInto(out *NexusSpec) {
 	*out = *in
@@ -180,6 +331,26 @@ func (in *NexusSpec) DeepCopy() *NexusSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenIDProviderSpec) DeepCopyInto(out *OpenIDProviderSpec) {
+	*out = *in
+	if in.ExtraScopes != nil {
+		in, out := &in.ExtraScopes, &out.ExtraScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenIDProviderSpec.
+func (in *OpenIDProviderSpec) DeepCopy() *OpenIDProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenIDProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorHubSpec) DeepCopyInto(out *OperatorHubSpec) {
 	*out = *in
@@ -211,9 +382,127 @@ func (in *PipelineSpec) DeepCopy() *PipelineSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalRouteSpec) DeepCopyInto(out *PortalRouteSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalRouteSpec.
+func (in *PortalRouteSpec) DeepCopy() *PortalRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortalSpec) DeepCopyInto(out *PortalSpec) {
+	*out = *in
+	out.Route = in.Route
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortalSpec.
+func (in *PortalSpec) DeepCopy() *PortalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PortalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectLimitRangeSpec) DeepCopyInto(out *ProjectLimitRangeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectLimitRangeSpec.
+func (in *ProjectLimitRangeSpec) DeepCopy() *ProjectLimitRangeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectLimitRangeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectPolicySpec) DeepCopyInto(out *ProjectPolicySpec) {
+	*out = *in
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(ProjectQuotaSpec)
+		**out = **in
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(ProjectLimitRangeSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectPolicySpec.
+func (in *ProjectPolicySpec) DeepCopy() *ProjectPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectQuotaSpec) DeepCopyInto(out *ProjectQuotaSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectQuotaSpec.
+func (in *ProjectQuotaSpec) DeepCopy() *ProjectQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleBindingSpec) DeepCopyInto(out *ProjectRoleBindingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleBindingSpec.
+func (in *ProjectRoleBindingSpec) DeepCopy() *ProjectRoleBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
 	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]ProjectTemplateSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(ProjectPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(SeedSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
@@ -226,6 +515,78 @@ func (in *ProjectSpec) DeepCopy() *ProjectSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectTemplateSpec) DeepCopyInto(out *ProjectTemplateSpec) {
+	*out = *in
+	if in.RoleBindings != nil {
+		in, out := &in.RoleBindings, &out.RoleBindings
+		*out = make([]ProjectRoleBindingSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectTemplateSpec.
+func (in *ProjectTemplateSpec) DeepCopy() *ProjectTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RosterSourceSpec) DeepCopyInto(out *RosterSourceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RosterSourceSpec.
+func (in *RosterSourceSpec) DeepCopy() *RosterSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RosterSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RosterSpec) DeepCopyInto(out *RosterSpec) {
+	*out = *in
+	if in.Attendees != nil {
+		in, out := &in.Attendees, &out.Attendees
+		*out = make([]Attendee, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(RosterSourceSpec)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(RosterSourceSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RosterSpec.
+func (in *RosterSpec) DeepCopy() *RosterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RosterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScholarsSpec) DeepCopyInto(out *ScholarsSpec) {
 	*out = *in
@@ -248,6 +609,26 @@ func (in *ScholarsSpec) DeepCopy() *ScholarsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
+	*out = *in
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedSpec.
+func (in *SeedSpec) DeepCopy() *SeedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerlessSpec) DeepCopyInto(out *ServerlessSpec) {
 	*out = *in
@@ -301,6 +682,21 @@ func (in *SourceSpec) DeepCopy() *SourceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserDetailsSpec) DeepCopyInto(out *UserDetailsSpec) {
 	*out = *in
+	if in.Roster != nil {
+		in, out := &in.Roster, &out.Roster
+		*out = new(RosterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdentityProvider != nil {
+		in, out := &in.IdentityProvider, &out.IdentityProvider
+		*out = new(IdentityProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Instructors != nil {
+		in, out := &in.Instructors, &out.Instructors
+		*out = new(InstructorsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDetailsSpec.
@@ -336,7 +732,7 @@ func (in *Workshop) DeepCopyInto(out *Workshop) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workshop.
@@ -357,6 +753,110 @@ func (in *Workshop) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkshopAttendee) DeepCopyInto(out *WorkshopAttendee) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopAttendee.
+func (in *WorkshopAttendee) DeepCopy() *WorkshopAttendee {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkshopAttendee)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkshopAttendee) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkshopAttendeeList) DeepCopyInto(out *WorkshopAttendeeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkshopAttendee, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopAttendeeList.
+func (in *WorkshopAttendeeList) DeepCopy() *WorkshopAttendeeList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkshopAttendeeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkshopAttendeeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkshopAttendeeSpec) DeepCopyInto(out *WorkshopAttendeeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopAttendeeSpec.
+func (in *WorkshopAttendeeSpec) DeepCopy() *WorkshopAttendeeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkshopAttendeeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkshopAttendeeStatus) DeepCopyInto(out *WorkshopAttendeeStatus) {
+	*out = *in
+	if in.ClaimedAt != nil {
+		in, out := &in.ClaimedAt, &out.ClaimedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastLogin != nil {
+		in, out := &in.LastLogin, &out.LastLogin
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopAttendeeStatus.
+func (in *WorkshopAttendeeStatus) DeepCopy() *WorkshopAttendeeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkshopAttendeeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkshopList) DeepCopyInto(out *WorkshopList) {
 	*out = *in
@@ -394,7 +894,10 @@ func (in *WorkshopSpec) DeepCopyInto(out *WorkshopSpec) {
 	*out = *in
 	out.Source = in.Source
 	in.Infrastructure.DeepCopyInto(&out.Infrastructure)
-	out.UserDetails = in.UserDetails
+	in.UserDetails.DeepCopyInto(&out.UserDetails)
+	out.Naming = in.Naming
+	out.Cluster = in.Cluster
+	out.Portal = in.Portal
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopSpec.
@@ -410,6 +913,23 @@ func (in *WorkshopSpec) DeepCopy() *WorkshopSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkshopStatus) DeepCopyInto(out *WorkshopStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Attendees != nil {
+		in, out := &in.Attendees, &out.Attendees
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IdentityProviders != nil {
+		in, out := &in.IdentityProviders, &out.IdentityProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkshopStatus.
@@ -0,0 +1,89 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The portal hands out the users of a workshop to attendees. It is deployed by the operator next to the Workshop.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/portal"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func main() {
+	var addr string
+	flag.StringVar(&addr, "addr", fmt.Sprintf(":%d", portal.PORT), "The address the portal binds to.")
+	flag.Parse()
+
+	duration, err := time.ParseDuration(getenv(portal.ENV_DURATION, "168h"))
+	if err != nil {
+		log.Fatalf("Invalid %s: %s", portal.ENV_DURATION, err)
+	}
+	namespace := os.Getenv(portal.ENV_NAMESPACE)
+	workshop := os.Getenv(portal.ENV_WORKSHOP)
+	if namespace == "" || workshop == "" {
+		log.Fatalf("%s and %s must be set", portal.ENV_NAMESPACE, portal.ENV_WORKSHOP)
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(workshopv1.AddToScheme(scheme))
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		log.Fatalf("Failed to create a client: %s", err)
+	}
+
+	server := &portal.Server{
+		Title:         getenv(portal.ENV_TITLE, "OpenShift Workshops"),
+		Duration:      duration,
+		AccessToken:   os.Getenv(portal.ENV_ACCESS_TOKEN),
+		AdminPassword: os.Getenv(portal.ENV_ADMIN_PASSWORD),
+		UsersFile:     os.Getenv(portal.ENV_USERS_FILE),
+		Assignments: &portal.Assignments{
+			Client:    c,
+			Workshop:  workshop,
+			Namespace: namespace,
+		},
+	}
+	handler, err := server.Handler()
+	if err != nil {
+		log.Fatalf("Failed to start the portal: %s", err)
+	}
+
+	log.Infof("Serving the portal on %s", addr)
+	if err := http.ListenAndServe(addr, handler); err != nil {
+		log.Fatalf("Portal stopped: %s", err)
+	}
+}
+
+// getenv returns an environment variable, or fallback if it is not set
+func getenv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -4,6 +4,7 @@ import (
 	argocdoperator "github.com/argoproj-labs/argocd-operator/pkg/apis/argoproj/v1alpha1"
 	argocd "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -19,7 +20,7 @@ func NewArgoCDCustomResource(workshop *workshopv1.Workshop, scheme *runtime.Sche
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    kubernetes.WorkshopLabels(workshop, labels),
 		},
 		Spec: argocdoperator.ArgoCDSpec{
 			ApplicationInstanceLabelKey: "argocd.argoproj.io/instance",
@@ -42,23 +43,26 @@ func NewArgoCDCustomResource(workshop *workshopv1.Workshop, scheme *runtime.Sche
 	return cr
 }
 
-// NewAppProjectCustomResource create a AppProject Custom Resource
+// NewAppProjectCustomResource create a AppProject Custom Resource deploying to the destination namespaces
 func NewAppProjectCustomResource(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
-	name string, namespace string, labels map[string]string, argocdPolicy string) *argocd.AppProject {
+	name string, namespace string, labels map[string]string, destinationNamespaces []string) *argocd.AppProject {
+
+	destinations := []argocd.ApplicationDestination{}
+	for _, destinationNamespace := range destinationNamespaces {
+		destinations = append(destinations, argocd.ApplicationDestination{
+			Namespace: destinationNamespace,
+			Server:    "https://kubernetes.default.svc",
+		})
+	}
 
 	cr := &argocd.AppProject{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    kubernetes.WorkshopLabels(workshop, labels),
 		},
 		Spec: argocd.AppProjectSpec{
-			Destinations: []argocd.ApplicationDestination{
-				{
-					Namespace: name,
-					Server:    "https://kubernetes.default.svc",
-				},
-			},
+			Destinations: destinations,
 			SourceRepos: []string{
 				"*",
 			},
@@ -1,35 +1,38 @@
 package bookbag
 
 import (
-	"fmt"
-
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
+	"github.com/stakater/workshop-operator/common/util"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-// NewDeployment create a deployment
+// NewDeployment create a deployment, the password of the user is read from the credentialsSecretName Secret
 func NewDeployment(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 	name string, namespace string, labels map[string]string,
-	userID string, appsHostnameSuffix string, openshiftConsoleURL string) *appsv1.Deployment {
+	user string, credentialsSecretName string, appsHostnameSuffix string, openshiftConsoleURL string) *appsv1.Deployment {
 
-	user := fmt.Sprintf("user%s", userID)
+	userID := util.UserID(workshop, user)
 	image := workshop.Spec.Infrastructure.Guide.Bookbag.Image.Name + ":" + workshop.Spec.Infrastructure.Guide.Bookbag.Image.Tag
 	consoleImage := "quay.io/openshift/origin-console:4.2"
 
+	// The kubelet expands $(AUTH_PASSWORD), WORKSHOP_VARS must follow AUTH_PASSWORD in the environment
 	vars := `{
 	"OPENSHIFT_CONSOLE_URL": "` + openshiftConsoleURL + `",
 	"APPS_HOSTNAME_SUFFIX": "` + appsHostnameSuffix + `",
 	"USER_ID": "` + userID + `",
-	"OPENSHIFT_PASSWORD": "` + workshop.Spec.UserDetails.DefaultPassword + `",
+	"USER_NAME": "` + user + `",
+	"OPENSHIFT_PASSWORD": "$(AUTH_PASSWORD)",
 	"CHE_URL": "http://codeready-workspaces.` + appsHostnameSuffix + `",
-	"GIT_URL": "https://gitea-server-gitea.` + appsHostnameSuffix + `",
-	"JAEGER_URL": "https://jaeger-istio-system.` + appsHostnameSuffix + `",
-	"KIALI_URL": "https://kiali-istio-system.` + appsHostnameSuffix + `",
+	"GIT_URL": "https://gitea-server-` + util.ScopedName(workshop, "gitea") + `.` + appsHostnameSuffix + `",
+	"JAEGER_URL": "https://jaeger-` + util.ScopedName(workshop, "istio-system") + `.` + appsHostnameSuffix + `",
+	"KIALI_URL": "https://kiali-` + util.ScopedName(workshop, "istio-system") + `.` + appsHostnameSuffix + `",
 	"KIBANA_URL": "https://kibana-openshift-logging.` + appsHostnameSuffix + `",
-	"GITOPS_URL": "https://argocd-server-argocd.` + appsHostnameSuffix + `",
+	"GITOPS_URL": "https://argocd-server-` + util.ScopedName(workshop, "argocd") + `.` + appsHostnameSuffix + `",
 	"WORKSHOP_GIT_REPO": "` + workshop.Spec.Source.GitURL + `",
 	"WORKSHOP_GIT_REF": "` + workshop.Spec.Source.GitBranch + `"
 }`
@@ -42,7 +45,7 @@ func NewDeployment(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    kubernetes.WorkshopLabels(workshop, labels),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Selector: &metav1.LabelSelector{MatchLabels: labels},
@@ -141,8 +144,13 @@ func NewDeployment(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 									Value: user,
 								},
 								{
-									Name:  "AUTH_PASSWORD",
-									Value: workshop.Spec.UserDetails.DefaultPassword,
+									Name: "AUTH_PASSWORD",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
+											Key:                  openshiftuser.CREDENTIALS_PASSWORD,
+										},
+									},
 								},
 								{
 									Name:  "OAUTH_SERVICE_ACCOUNT",
@@ -3,6 +3,7 @@ package codeready
 import (
 	che "github.com/eclipse/che-operator/pkg/apis/org/v1"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -43,6 +44,7 @@ func NewCustomResource(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
+			Labels:    kubernetes.WorkshopLabels(workshop, nil),
 		},
 		Spec: che.CheClusterSpec{
 			Server: che.CheClusterSpecServer{
@@ -87,11 +89,11 @@ func NewCustomResource(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 }
 
 // NewUser creates a user
-func NewUser(username string, password string) *codeReadyUser {
+func NewUser(username string, email string, password string) *codeReadyUser {
 	return &codeReadyUser{
 		Username: username,
 		Enabled:  true,
-		Email:    username + "@none.com",
+		Email:    email,
 		Credentials: []credential{
 			{
 				Type:  "password",
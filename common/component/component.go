@@ -0,0 +1,158 @@
+package component
+
+import (
+	"fmt"
+	"sync"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/platform"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Environment holds what a component needs to reconcile a workshop
+type Environment struct {
+	Client              client.Client
+	Scheme              *runtime.Scheme
+	Workshop            *workshopv1.Workshop
+	Users               int
+	AppsHostnameSuffix  string
+	OpenshiftConsoleURL string
+	Platform            platform.Platform
+
+	// Attendees are the attendees to provision sorted by name, Users is their number
+	Attendees []workshopv1.Attendee
+	// RemovedAttendees are the user names of attendees that left the workshop and must be deprovisioned
+	RemovedAttendees []string
+}
+
+// Component is a piece of workshop infrastructure managed by the operator
+type Component interface {
+	// Name is the unique name of the component, used in status conditions
+	Name() string
+	// Enabled returns true if the component is requested by the workshop spec
+	Enabled(spec *workshopv1.WorkshopSpec) bool
+	// Supports returns true if the component can run on the platform
+	Supports(p platform.Platform) bool
+	// Dependencies returns the names of the components that must be ready first
+	Dependencies() []string
+	// Reconcile creates or updates the component
+	Reconcile(env *Environment) (reconcile.Result, error)
+	// Delete removes the component
+	Delete(env *Environment) (reconcile.Result, error)
+	// Status returns the status field of the component, or nil if it has none
+	Status(status *workshopv1.WorkshopStatus) *string
+}
+
+// Func adapts plain functions to the Component interface
+type Func struct {
+	ComponentName string
+	DependsOn     []string
+	EnabledFunc   func(spec *workshopv1.WorkshopSpec) bool
+	Platforms     []platform.Platform
+	ReconcileFunc func(env *Environment) (reconcile.Result, error)
+	DeleteFunc    func(env *Environment) (reconcile.Result, error)
+	StatusFunc    func(status *workshopv1.WorkshopStatus) *string
+}
+
+// Name returns the component name
+func (f *Func) Name() string {
+	return f.ComponentName
+}
+
+// Enabled calls EnabledFunc, a component without one is always enabled
+func (f *Func) Enabled(spec *workshopv1.WorkshopSpec) bool {
+	if f.EnabledFunc == nil {
+		return true
+	}
+	return f.EnabledFunc(spec)
+}
+
+// Supports returns true if the platform is one of Platforms, a component without Platforms runs everywhere
+func (f *Func) Supports(p platform.Platform) bool {
+	if len(f.Platforms) == 0 {
+		return true
+	}
+	for _, supported := range f.Platforms {
+		if supported == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Dependencies returns DependsOn
+func (f *Func) Dependencies() []string {
+	return f.DependsOn
+}
+
+// Reconcile calls ReconcileFunc
+func (f *Func) Reconcile(env *Environment) (reconcile.Result, error) {
+	if f.ReconcileFunc == nil {
+		return reconcile.Result{}, nil
+	}
+	return f.ReconcileFunc(env)
+}
+
+// Delete calls DeleteFunc
+func (f *Func) Delete(env *Environment) (reconcile.Result, error) {
+	if f.DeleteFunc == nil {
+		return reconcile.Result{}, nil
+	}
+	return f.DeleteFunc(env)
+}
+
+// Status calls StatusFunc
+func (f *Func) Status(status *workshopv1.WorkshopStatus) *string {
+	if f.StatusFunc == nil {
+		return nil
+	}
+	return f.StatusFunc(status)
+}
+
+// Registry is an ordered set of components
+type Registry struct {
+	mu         sync.RWMutex
+	components []Component
+}
+
+// NewRegistry creates a Registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds components to the registry, it panics if a name is registered twice
+func (r *Registry) Register(components ...Component) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, component := range components {
+		for _, registered := range r.components {
+			if registered.Name() == component.Name() {
+				panic(fmt.Sprintf("component %s is already registered", component.Name()))
+			}
+		}
+		r.components = append(r.components, component)
+	}
+}
+
+// Components returns the registered components in registration order
+func (r *Registry) Components() []Component {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	components := make([]Component, len(r.components))
+	copy(components, r.components)
+	return components
+}
+
+var defaultRegistry = NewRegistry()
+
+// Register adds components to the default registry, typically from a package init function
+func Register(components ...Component) {
+	defaultRegistry.Register(components...)
+}
+
+// Registered returns the components of the default registry
+func Registered() []Component {
+	return defaultRegistry.Components()
+}
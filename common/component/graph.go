@@ -0,0 +1,81 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Graph orders components so that every component comes after its dependencies
+type Graph struct {
+	sorted       []Component
+	dependencies map[string][]Component
+}
+
+// NewGraph creates a Graph, it returns an error on unknown dependencies or cycles
+func NewGraph(components []Component) (*Graph, error) {
+	byName := make(map[string]Component, len(components))
+	for _, c := range components {
+		byName[c.Name()] = c
+	}
+
+	graph := &Graph{
+		dependencies: make(map[string][]Component, len(components)),
+	}
+	for _, c := range components {
+		for _, name := range c.Dependencies() {
+			dependency, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("component %s depends on unknown component %s", c.Name(), name)
+			}
+			graph.dependencies[c.Name()] = append(graph.dependencies[c.Name()], dependency)
+		}
+	}
+
+	// Depth first walk in registration order keeps the sort stable
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(components))
+	var path []string
+	var visit func(c Component) error
+	visit = func(c Component) error {
+		switch state[c.Name()] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("component dependency cycle: %s -> %s", strings.Join(path, " -> "), c.Name())
+		}
+		state[c.Name()] = visiting
+		path = append(path, c.Name())
+		for _, dependency := range graph.dependencies[c.Name()] {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[c.Name()] = visited
+		graph.sorted = append(graph.sorted, c)
+		return nil
+	}
+	for _, c := range components {
+		if err := visit(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return graph, nil
+}
+
+// Sorted returns the components with dependencies before dependents
+func (g *Graph) Sorted() []Component {
+	sorted := make([]Component, len(g.sorted))
+	copy(sorted, g.sorted)
+	return sorted
+}
+
+// Dependencies returns the direct dependencies of a component
+func (g *Graph) Dependencies(name string) []Component {
+	return g.dependencies[name]
+}
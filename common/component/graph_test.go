@@ -0,0 +1,136 @@
+package component
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newFunc returns a component with a name and dependencies only
+func newFunc(name string, dependsOn ...string) Component {
+	return &Func{ComponentName: name, DependsOn: dependsOn}
+}
+
+func names(components []Component) []string {
+	result := []string{}
+	for _, c := range components {
+		result = append(result, c.Name())
+	}
+	return result
+}
+
+func TestNewGraph(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []Component
+		sorted     []string
+		err        string
+	}{
+		{
+			name:       "empty",
+			components: nil,
+			sorted:     []string{},
+		},
+		{
+			name:       "independent components keep the registration order",
+			components: []Component{newFunc("Project"), newFunc("Gitea"), newFunc("Nexus")},
+			sorted:     []string{"Project", "Gitea", "Nexus"},
+		},
+		{
+			name: "dependencies come before dependents",
+			components: []Component{
+				newFunc("Bookbag", "Project"),
+				newFunc("Users"),
+				newFunc("Project", "Users"),
+			},
+			sorted: []string{"Users", "Project", "Bookbag"},
+		},
+		{
+			name: "shared dependencies are sorted once",
+			components: []Component{
+				newFunc("Users"),
+				newFunc("Gitea", "Users"),
+				newFunc("GitOps", "Users", "Gitea"),
+				newFunc("Portal", "Users"),
+			},
+			sorted: []string{"Users", "Gitea", "GitOps", "Portal"},
+		},
+		{
+			name:       "unknown dependency",
+			components: []Component{newFunc("Gitea", "Users")},
+			err:        "component Gitea depends on unknown component Users",
+		},
+		{
+			name:       "self dependency",
+			components: []Component{newFunc("Users", "Users")},
+			err:        "component dependency cycle: Users -> Users",
+		},
+		{
+			name: "cycle",
+			components: []Component{
+				newFunc("Users", "Portal"),
+				newFunc("Project", "Users"),
+				newFunc("Portal", "Project"),
+			},
+			err: "component dependency cycle: Users -> Portal -> Project -> Users",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, err := NewGraph(tt.components)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("NewGraph() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewGraph() error = %v", err)
+			}
+			if sorted := names(graph.Sorted()); !reflect.DeepEqual(sorted, tt.sorted) {
+				t.Errorf("Sorted() = %v, want %v", sorted, tt.sorted)
+			}
+		})
+	}
+}
+
+func TestGraphDependencies(t *testing.T) {
+	graph, err := NewGraph([]Component{
+		newFunc("Users"),
+		newFunc("Gitea", "Users"),
+		newFunc("GitOps", "Users", "Gitea"),
+	})
+	if err != nil {
+		t.Fatalf("NewGraph() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		dependencies []string
+	}{
+		{name: "Users", dependencies: []string{}},
+		{name: "Gitea", dependencies: []string{"Users"}},
+		{name: "GitOps", dependencies: []string{"Users", "Gitea"}},
+		{name: "Unknown", dependencies: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if dependencies := names(graph.Dependencies(tt.name)); !reflect.DeepEqual(dependencies, tt.dependencies) {
+				t.Errorf("Dependencies(%s) = %v, want %v", tt.name, dependencies, tt.dependencies)
+			}
+		})
+	}
+}
+
+func TestSortedReturnsACopy(t *testing.T) {
+	graph, err := NewGraph([]Component{newFunc("Users"), newFunc("Project", "Users")})
+	if err != nil {
+		t.Fatalf("NewGraph() error = %v", err)
+	}
+	sorted := graph.Sorted()
+	sorted[0] = newFunc("Changed")
+	if name := graph.Sorted()[0].Name(); name != "Users" {
+		t.Errorf("Sorted()[0] = %s after changing a copy, want Users", name)
+	}
+}
@@ -2,23 +2,27 @@ package gitea
 
 import (
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-// NewCustomResource return a new  CustomResource
+// NewCustomResource return a new  CustomResource, with the administrator the operator signs in as
 func NewCustomResource(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
-	name string, namespace string, labels map[string]string) *Gitea {
+	name string, namespace string, labels map[string]string, adminUser string, adminPassword string, adminEmail string) *Gitea {
 	cr := &Gitea{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    kubernetes.WorkshopLabels(workshop, labels),
 		},
 		Spec: GiteaSpec{
 			GiteaVolumeSize:      "4Gi",
 			GiteaSsl:             true,
 			PostgresqlVolumeSize: "4Gi",
+			GiteaAdminUser:       adminUser,
+			GiteaAdminPassword:   adminPassword,
+			GiteaAdminEmail:      adminEmail,
 		},
 	}
 	return cr
@@ -16,6 +16,10 @@ type GiteaSpec struct {
 	GiteaSsl             bool   `json:"giteaSsl"`
 	GiteaServiceName     string `json:"giteaServiceName,omitempty"`
 	PostgresqlVolumeSize string `json:"postgresqlVolumeSize"`
+	// The Gitea operator creates the administrator with the gitea CLI, whatever users signed up before
+	GiteaAdminUser     string `json:"giteaAdminUser,omitempty"`
+	GiteaAdminPassword string `json:"giteaAdminPassword,omitempty"`
+	GiteaAdminEmail    string `json:"giteaAdminEmail,omitempty"`
 }
 
 type GiteaList struct {
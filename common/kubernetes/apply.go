@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// FIELD_MANAGER owns every field the operator applies
+const FIELD_MANAGER = "workshop-operator"
+
+// Apply creates or updates obj with server-side apply, so spec changes and out-of-band edits converge to the desired state.
+// obj must be a freshly built object, it is updated in place with the state returned by the API server.
+func Apply(c client.Client, scheme *runtime.Scheme, obj runtime.Object) (controllerutil.OperationResult, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	// Read the current revision to tell creates, updates and no-ops apart
+	existing, err := newObject(scheme, obj, gvk)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	key := types.NamespacedName{Name: accessor.GetName(), Namespace: accessor.GetNamespace()}
+	previousVersion := ""
+	if err := c.Get(context.TODO(), key, existing); err == nil {
+		if existingAccessor, err := meta.Accessor(existing); err == nil {
+			previousVersion = existingAccessor.GetResourceVersion()
+		}
+	} else if !isGone(err) {
+		return controllerutil.OperationResultNone, err
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	accessor.SetResourceVersion("")
+	accessor.SetManagedFields(nil)
+	if err := c.Patch(context.TODO(), obj, client.Apply, client.FieldOwner(FIELD_MANAGER), client.ForceOwnership); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	switch {
+	case previousVersion == "":
+		return controllerutil.OperationResultCreated, nil
+	case previousVersion != accessor.GetResourceVersion():
+		return controllerutil.OperationResultUpdated, nil
+	}
+	return controllerutil.OperationResultNone, nil
+}
+
+// newObject returns an empty object of the kind of obj, unstructured objects may be of kinds the scheme does not know
+func newObject(scheme *runtime.Scheme, obj runtime.Object, gvk schema.GroupVersionKind) (runtime.Object, error) {
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(gvk)
+		return existing, nil
+	}
+	return scheme.New(gvk)
+}
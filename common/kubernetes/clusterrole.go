@@ -15,7 +15,7 @@ func NewClusterRole(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    WorkshopLabels(workshop, labels),
 		},
 		Rules: rules,
 	}
@@ -15,7 +15,7 @@ func NewClusterRoleBindingSA(workshop *workshopv1.Workshop, scheme *runtime.Sche
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    WorkshopLabels(workshop, labels),
 		},
 		Subjects: []rbac.Subject{
 			{
@@ -41,7 +41,7 @@ func NewClusterRoleBinding(workshop *workshopv1.Workshop, scheme *runtime.Scheme
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    WorkshopLabels(workshop, labels),
 		},
 		Subjects: []rbac.Subject{
 			{
@@ -13,7 +13,8 @@ func NewCustomResourceDefinition(workshop *workshopv1.Workshop, scheme *runtime.
 
 	crd := &apiextensionsv1beta1.CustomResourceDefinition{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
+			Name:   name,
+			Labels: WorkshopLabels(workshop, nil),
 		},
 		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
 			Group:   group,
@@ -0,0 +1,57 @@
+package kubernetes
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// isGone returns true if an error means the object does not exist,
+// either because it was deleted or because its kind is no longer served
+func isGone(err error) bool {
+	return errors.IsNotFound(err) || meta.IsNoMatchError(err)
+}
+
+// DeleteIfExists deletes obj, an object that does not exist counts as deleted so teardown can be retried
+func DeleteIfExists(c client.Client, obj runtime.Object) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	// Names resolved from status, e.g. the installed CSV, are empty once their owner is gone
+	if accessor.GetName() == "" {
+		return nil
+	}
+	if err := c.Delete(context.TODO(), obj); err != nil && !isGone(err) {
+		return err
+	}
+	return nil
+}
+
+// RemoveFinalizers clears the finalizers of an object whose operator is already gone, so its namespace can terminate
+func RemoveFinalizers(c client.Client, name string, namespace string, obj runtime.Object) error {
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, obj); err != nil {
+		if isGone(err) {
+			return nil
+		}
+		return err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	if len(accessor.GetFinalizers()) == 0 {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject())
+	accessor.SetFinalizers(nil)
+	if err := c.Patch(context.TODO(), obj, patch); err != nil && !isGone(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// NewIngress creates an Ingress, the Kubernetes counterpart of an OpenShift Route
+func NewIngress(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
+	name string, namespace string, labels map[string]string, serviceName string, port int32, host string) *networkingv1beta1.Ingress {
+
+	ingress := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    WorkshopLabels(workshop, labels),
+		},
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: networkingv1beta1.IngressBackend{
+										ServiceName: serviceName,
+										ServicePort: intstr.FromInt(int(port)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return ingress
+}
+
+// NewSecuredIngress creates an Ingress terminating TLS with the default certificate of the ingress controller
+func NewSecuredIngress(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
+	name string, namespace string, labels map[string]string, serviceName string, port int32, host string) *networkingv1beta1.Ingress {
+
+	ingress := NewIngress(workshop, scheme, name, namespace, labels, serviceName, port, host)
+	ingress.Spec.TLS = []networkingv1beta1.IngressTLS{
+		{
+			Hosts: []string{host},
+		},
+	}
+
+	// Set Workshop instance as the owner and controller
+	err := ctrl.SetControllerReference(workshop, ingress, scheme)
+	if err != nil {
+		log.Error(err, " - Failed to set SetControllerReference for Secured Ingress - %s", name)
+	}
+	return ingress
+}
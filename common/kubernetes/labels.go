@@ -0,0 +1,51 @@
+package kubernetes
+
+import (
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	WORKSHOP_NAME_LABEL      = "workshop.stakater.com/name"
+	WORKSHOP_NAMESPACE_LABEL = "workshop.stakater.com/namespace"
+	WORKSHOP_USER_LABEL      = "workshop.stakater.com/user"
+	// WORKSHOP_SHARED_LABEL holds the component of an object shared by every workshop using it, e.g. an OLM subscription
+	WORKSHOP_SHARED_LABEL = "workshop.stakater.com/shared"
+)
+
+// WorkshopLabels returns a copy of labels with the labels identifying the owning workshop.
+// Cluster-scoped and cross-namespace objects cannot carry an owner reference, so watches map objects back by these labels.
+func WorkshopLabels(workshop *workshopv1.Workshop, labels map[string]string) map[string]string {
+	workshopLabels := make(map[string]string, len(labels)+2)
+	for key, value := range labels {
+		workshopLabels[key] = value
+	}
+	if workshop != nil {
+		workshopLabels[WORKSHOP_NAME_LABEL] = workshop.Name
+		workshopLabels[WORKSHOP_NAMESPACE_LABEL] = workshop.Namespace
+	}
+	return workshopLabels
+}
+
+// WorkshopFromLabels returns the workshop owning an object, if the object carries the workshop labels
+func WorkshopFromLabels(labels map[string]string) (types.NamespacedName, bool) {
+	name, ok := labels[WORKSHOP_NAME_LABEL]
+	if !ok || name == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Name: name, Namespace: labels[WORKSHOP_NAMESPACE_LABEL]}, true
+}
+
+// SharedLabels returns a copy of labels marking an object as shared by the workshops using component, without the
+// labels of an owning workshop. Every workshop applies shared objects, owner labels would make them overwrite each other.
+func SharedLabels(component string, labels map[string]string) map[string]string {
+	sharedLabels := make(map[string]string, len(labels)+1)
+	for key, value := range labels {
+		if key == WORKSHOP_NAME_LABEL || key == WORKSHOP_NAMESPACE_LABEL {
+			continue
+		}
+		sharedLabels[key] = value
+	}
+	sharedLabels[WORKSHOP_SHARED_LABEL] = component
+	return sharedLabels
+}
@@ -0,0 +1,25 @@
+package kubernetes
+
+import (
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewLimitRange creates a Limit Range
+func NewLimitRange(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
+	name string, namespace string, labels map[string]string, limits []corev1.LimitRangeItem) *corev1.LimitRange {
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    WorkshopLabels(workshop, labels),
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: limits,
+		},
+	}
+	return limitRange
+}
@@ -14,7 +14,7 @@ func NewMutatingWebhookConfiguration(workshop *workshopv1.Workshop, scheme *runt
 	mwc := &admissionregistration.MutatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   name,
-			Labels: labels,
+			Labels: WorkshopLabels(workshop, labels),
 		},
 		Webhooks: webhooks,
 	}
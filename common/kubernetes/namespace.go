@@ -7,13 +7,28 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// WORKSHOP_CREATED_ANNOTATION holds the workshop that created a namespace, namespaces that existed before are never
+// taken over or deleted
+const WORKSHOP_CREATED_ANNOTATION = "workshop.stakater.com/created-by"
+
 // NewNamespace returns a new namespace/project
 func NewNamespace(workshop *workshopv1.Workshop, scheme *runtime.Scheme, name string) *corev1.Namespace {
 
 	namespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
+			Name:   name,
+			Labels: WorkshopLabels(workshop, nil),
 		},
 	}
 	return namespace
 }
+
+// CreatedBy returns the value of the WORKSHOP_CREATED_ANNOTATION of the namespaces created for the workshop
+func CreatedBy(workshop *workshopv1.Workshop) string {
+	return workshop.Namespace + "/" + workshop.Name
+}
+
+// IsCreatedBy returns true if the namespace was created for the workshop
+func IsCreatedBy(workshop *workshopv1.Workshop, namespace *corev1.Namespace) bool {
+	return namespace.Annotations[WORKSHOP_CREATED_ANNOTATION] == CreatedBy(workshop)
+}
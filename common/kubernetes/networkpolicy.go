@@ -0,0 +1,27 @@
+package kubernetes
+
+import (
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewNetworkPolicy creates a Network Policy selecting every pod of the namespace
+func NewNetworkPolicy(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
+	name string, namespace string, labels map[string]string, ingress []networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    WorkshopLabels(workshop, labels),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			Ingress:     ingress,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	return networkPolicy
+}
@@ -15,6 +15,7 @@ func NewOperatorGroup(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
+			Labels:    WorkshopLabels(workshop, nil),
 		},
 		Spec: olmv1.OperatorGroupSpec{
 			TargetNamespaces: []string{
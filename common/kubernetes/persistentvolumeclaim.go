@@ -30,7 +30,7 @@ func NewPersistentVolumeClaim(workshop *workshopv1.Workshop, scheme *runtime.Sch
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    WorkshopLabels(workshop, labels),
 		},
 		Spec: pvcSpec,
 	}
@@ -0,0 +1,292 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/stakater/workshop-operator/common/util"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	PLAN_ACTION_CREATE = "Create"
+	PLAN_ACTION_UPDATE = "Update"
+	PLAN_ACTION_DELETE = "Delete"
+)
+
+// PlannedChange is a write the operator would make to the cluster
+type PlannedChange struct {
+	Action    string   `json:"action"`
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Fields    []string `json:"fields,omitempty"`
+	Note      string   `json:"note,omitempty"`
+}
+
+// Planner is implemented by clients that only plan changes
+type Planner interface {
+	Planning() bool
+}
+
+// IsPlanning returns true if writes through the client are only planned, not applied
+func IsPlanning(c client.Client) bool {
+	planner, ok := c.(Planner)
+	return ok && planner.Planning()
+}
+
+// PlanClient reads from the cluster and records writes instead of applying them.
+// Writes are sent as server-side dry runs, so the plan reflects defaulting and admission.
+type PlanClient struct {
+	client.Client
+	scheme  *runtime.Scheme
+	mu      sync.Mutex
+	changes map[string]*PlannedChange
+}
+
+// NewPlanClient creates a PlanClient reading through c
+func NewPlanClient(c client.Client, scheme *runtime.Scheme) *PlanClient {
+	return &PlanClient{
+		Client:  c,
+		scheme:  scheme,
+		changes: make(map[string]*PlannedChange),
+	}
+}
+
+// Planning returns true
+func (p *PlanClient) Planning() bool {
+	return true
+}
+
+// Changes returns the planned changes sorted by kind, namespace and name
+func (p *PlanClient) Changes() []PlannedChange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	changes := make([]PlannedChange, 0, len(p.changes))
+	for _, change := range p.changes {
+		changes = append(changes, *change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		if changes[i].Namespace != changes[j].Namespace {
+			return changes[i].Namespace < changes[j].Namespace
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Action < changes[j].Action
+	})
+	return changes
+}
+
+// Create plans the creation of obj
+func (p *PlanClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	if err := p.Client.Create(ctx, obj.DeepCopyObject(), append(opts, client.DryRunAll)...); err != nil {
+		if !isGone(err) {
+			return err
+		}
+		return p.record(obj, PLAN_ACTION_CREATE, nil, fmt.Sprintf("not validated: %s", err))
+	}
+	return p.record(obj, PLAN_ACTION_CREATE, nil, "")
+}
+
+// Update plans the update of obj
+func (p *PlanClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	existing, err := p.existing(obj)
+	if err != nil {
+		return err
+	}
+	desired := obj.DeepCopyObject()
+	if err := p.Client.Update(ctx, desired, append(opts, client.DryRunAll)...); err != nil {
+		return err
+	}
+	return p.recordDiff(obj, existing, desired, "")
+}
+
+// Patch plans a patch of obj, e.g. a server-side apply
+func (p *PlanClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	existing, err := p.existing(obj)
+	if err != nil {
+		return err
+	}
+	desired := obj.DeepCopyObject()
+	if err := p.Client.Patch(ctx, desired, patch, append(opts, client.DryRunAll)...); err != nil {
+		// Objects in namespaces or of kinds that are only planned cannot be validated
+		if !isGone(err) {
+			return err
+		}
+		return p.recordDiff(obj, existing, obj, fmt.Sprintf("not validated: %s", err))
+	}
+	return p.recordDiff(obj, existing, desired, "")
+}
+
+// Delete plans the deletion of obj if it exists
+func (p *PlanClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	existing, err := p.existing(obj)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+	return p.record(obj, PLAN_ACTION_DELETE, nil, "")
+}
+
+// DeleteAllOf plans the deletion of all objects of a kind
+func (p *PlanClient) DeleteAllOf(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
+	return p.record(obj, PLAN_ACTION_DELETE, nil, "all matching objects")
+}
+
+// Status returns a writer that plans status updates
+func (p *PlanClient) Status() client.StatusWriter {
+	return &planStatusWriter{client: p}
+}
+
+// existing returns the current state of obj, or nil if it does not exist
+func (p *PlanClient) existing(obj runtime.Object) (runtime.Object, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if accessor.GetName() == "" {
+		return nil, nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, p.scheme)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := newObject(p.scheme, obj, gvk)
+	if err != nil {
+		return nil, err
+	}
+	key := types.NamespacedName{Name: accessor.GetName(), Namespace: accessor.GetNamespace()}
+	if err := p.Client.Get(context.TODO(), key, existing); err != nil {
+		if isGone(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return existing, nil
+}
+
+// recordDiff records a create if the object does not exist yet, or an update of the fields that would change
+func (p *PlanClient) recordDiff(obj runtime.Object, existing runtime.Object, desired runtime.Object, note string) error {
+	if existing == nil {
+		return p.record(obj, PLAN_ACTION_CREATE, nil, note)
+	}
+
+	existingFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existing)
+	if err != nil {
+		return err
+	}
+	desiredFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return err
+	}
+	fields := diffFields("", comparable(existingFields), comparable(desiredFields))
+	if len(fields) == 0 {
+		return nil
+	}
+	return p.record(obj, PLAN_ACTION_UPDATE, fields, note)
+}
+
+// record adds a change, changes to the same object are merged
+func (p *PlanClient) record(obj runtime.Object, action string, fields []string, note string) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	gvk, err := apiutil.GVKForObject(obj, p.scheme)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s/%s/%s", action, gvk.Kind, accessor.GetNamespace(), accessor.GetName())
+	change, ok := p.changes[key]
+	if !ok {
+		change = &PlannedChange{
+			Action:    action,
+			Kind:      gvk.Kind,
+			Namespace: accessor.GetNamespace(),
+			Name:      accessor.GetName(),
+		}
+		p.changes[key] = change
+	}
+	for _, field := range fields {
+		if !util.Contains(change.Fields, field) {
+			change.Fields = append(change.Fields, field)
+		}
+	}
+	sort.Strings(change.Fields)
+	if note != "" {
+		change.Note = note
+	}
+	return nil
+}
+
+// comparable drops the fields maintained by the API server
+func comparable(fields map[string]interface{}) map[string]interface{} {
+	delete(fields, "status")
+	if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"resourceVersion", "generation", "managedFields", "creationTimestamp", "uid", "selfLink"} {
+			delete(metadata, field)
+		}
+	}
+	return fields
+}
+
+// diffFields returns the paths of the fields that differ, lists are compared as a whole
+func diffFields(path string, existing map[string]interface{}, desired map[string]interface{}) []string {
+	fields := []string{}
+	keys := map[string]bool{}
+	for key := range existing {
+		keys[key] = true
+	}
+	for key := range desired {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		existingMap, existingIsMap := existing[key].(map[string]interface{})
+		desiredMap, desiredIsMap := desired[key].(map[string]interface{})
+		if existingIsMap && desiredIsMap {
+			fields = append(fields, diffFields(fieldPath, existingMap, desiredMap)...)
+		} else if !reflect.DeepEqual(existing[key], desired[key]) {
+			fields = append(fields, fieldPath)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// planStatusWriter records status writes of a PlanClient
+type planStatusWriter struct {
+	client *PlanClient
+}
+
+// Update plans a status update
+func (w *planStatusWriter) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	return w.client.record(obj, PLAN_ACTION_UPDATE, []string{"status"}, "")
+}
+
+// Patch plans a status patch
+func (w *planStatusWriter) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return w.client.record(obj, PLAN_ACTION_UPDATE, []string{"status"}, "")
+}
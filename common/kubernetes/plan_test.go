@@ -0,0 +1,94 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing map[string]interface{}
+		desired  map[string]interface{}
+		fields   []string
+	}{
+		{
+			name:     "equal",
+			existing: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			fields:   []string{},
+		},
+		{
+			name:     "changed nested field",
+			existing: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1), "paused": false}},
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(2), "paused": false}},
+			fields:   []string{"spec.replicas"},
+		},
+		{
+			name:     "added and removed fields",
+			existing: map[string]interface{}{"data": map[string]interface{}{"old": "value"}},
+			desired:  map[string]interface{}{"data": map[string]interface{}{"new": "value"}},
+			fields:   []string{"data.new", "data.old"},
+		},
+		{
+			name:     "lists are compared as a whole",
+			existing: map[string]interface{}{"rules": []interface{}{"get", "list"}},
+			desired:  map[string]interface{}{"rules": []interface{}{"get", "list", "watch"}},
+			fields:   []string{"rules"},
+		},
+		{
+			name:     "a map replacing a value",
+			existing: map[string]interface{}{"spec": "none"},
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			fields:   []string{"spec"},
+		},
+		{
+			name: "fields are sorted",
+			existing: map[string]interface{}{
+				"spec":     map[string]interface{}{"b": "1", "a": "1"},
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "old"}},
+			},
+			desired: map[string]interface{}{
+				"spec":     map[string]interface{}{"b": "2", "a": "2"},
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "new"}},
+			},
+			fields: []string{"metadata.labels.app", "spec.a", "spec.b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if fields := diffFields("", tt.existing, tt.desired); !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("diffFields() = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestComparable(t *testing.T) {
+	fields := comparable(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":              "app",
+			"labels":            map[string]interface{}{"app": "app"},
+			"resourceVersion":   "1",
+			"generation":        int64(2),
+			"managedFields":     []interface{}{},
+			"creationTimestamp": "2020-01-01T00:00:00Z",
+			"uid":               "uid",
+			"selfLink":          "/api/v1/namespaces/default/configmaps/app",
+		},
+		"data":   map[string]interface{}{"key": "value"},
+		"status": map[string]interface{}{"ready": true},
+	})
+
+	want := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "app",
+			"labels": map[string]interface{}{"app": "app"},
+		},
+		"data": map[string]interface{}{"key": "value"},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("comparable() = %v, want %v", fields, want)
+	}
+}
@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/prometheus/common/log"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// desiredReplicas returns the replica count requested by a spec, which defaults to 1
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// IsDeploymentReady returns true once the deployment has rolled out all of its desired replicas.
+// It reads through the given client, so with the manager's client it is served from the informer cache.
+func IsDeploymentReady(c client.Client, name string, namespace string) (bool, error) {
+	// Nothing rolls out while planning, so everything counts as ready
+	if IsPlanning(c) {
+		return true, nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := GetObject(c, name, namespace, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			log.Infof("Waiting for %s Deployment in %s to be created", name, namespace)
+			return false, nil
+		}
+		return false, err
+	}
+
+	desired := desiredReplicas(deployment.Spec.Replicas)
+	if deployment.Status.ObservedGeneration < deployment.Generation ||
+		deployment.Status.UpdatedReplicas < desired ||
+		deployment.Status.ReadyReplicas < desired ||
+		deployment.Status.AvailableReplicas < desired {
+		log.Infof("Waiting for %s Deployment in %s: %d/%d replicas ready", name, namespace, deployment.Status.ReadyReplicas, desired)
+		return false, nil
+	}
+	return true, nil
+}
+
+// IsStatefulSetReady returns true once the statefulset has all of its desired replicas ready
+func IsStatefulSetReady(c client.Client, name string, namespace string) (bool, error) {
+	// Nothing rolls out while planning, so everything counts as ready
+	if IsPlanning(c) {
+		return true, nil
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := GetObject(c, name, namespace, statefulSet); err != nil {
+		if errors.IsNotFound(err) {
+			log.Infof("Waiting for %s StatefulSet in %s to be created", name, namespace)
+			return false, nil
+		}
+		return false, err
+	}
+
+	desired := desiredReplicas(statefulSet.Spec.Replicas)
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation ||
+		statefulSet.Status.ReadyReplicas < desired {
+		log.Infof("Waiting for %s StatefulSet in %s: %d/%d replicas ready", name, namespace, statefulSet.Status.ReadyReplicas, desired)
+		return false, nil
+	}
+	return true, nil
+}
+
+// IsClusterServiceVersionReady returns true once the ClusterServiceVersion has succeeded
+func IsClusterServiceVersionReady(c client.Client, name string, namespace string) (bool, error) {
+	// Nothing rolls out while planning, so everything counts as ready
+	if IsPlanning(c) {
+		return true, nil
+	}
+
+	csv := &olmv1alpha1.ClusterServiceVersion{}
+	if err := GetObject(c, name, namespace, csv); err != nil {
+		if errors.IsNotFound(err) {
+			log.Infof("Waiting for %s ClusterServiceVersion in %s to be created", name, namespace)
+			return false, nil
+		}
+		return false, err
+	}
+
+	if csv.Status.Phase != olmv1alpha1.CSVPhaseSucceeded {
+		log.Infof("Waiting for %s ClusterServiceVersion in %s: phase %s", name, namespace, csv.Status.Phase)
+		return false, nil
+	}
+	return true, nil
+}
+
+// IsSubscriptionReady returns true once the CSV installed by the subscription has succeeded
+func IsSubscriptionReady(c client.Client, name string, namespace string) (bool, error) {
+	// Nothing rolls out while planning, so everything counts as ready
+	if IsPlanning(c) {
+		return true, nil
+	}
+
+	subscription := &olmv1alpha1.Subscription{}
+	if err := GetObject(c, name, namespace, subscription); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if subscription.Status.InstalledCSV == "" {
+		log.Infof("Waiting for %s Subscription in %s to install a ClusterServiceVersion", name, namespace)
+		return false, nil
+	}
+	return IsClusterServiceVersionReady(c, subscription.Status.InstalledCSV, namespace)
+}
+
+// IsClusterOperatorReady returns true once the cluster operator is available and done progressing
+func IsClusterOperatorReady(c client.Client, name string) (bool, error) {
+	// Nothing rolls out while planning, so everything counts as ready
+	if IsPlanning(c) {
+		return true, nil
+	}
+
+	clusterOperator := &configv1.ClusterOperator{}
+	if err := GetObject(c, name, "", clusterOperator); err != nil {
+		if errors.IsNotFound(err) {
+			log.Infof("Waiting for %s ClusterOperator to be created", name)
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, condition := range clusterOperator.Status.Conditions {
+		if (condition.Type == configv1.OperatorAvailable && condition.Status != configv1.ConditionTrue) ||
+			(condition.Type == configv1.OperatorProgressing && condition.Status != configv1.ConditionFalse) {
+			log.Infof("Waiting for %s ClusterOperator: %s is %s", name, condition.Type, condition.Status)
+			return false, nil
+		}
+	}
+	return true, nil
+}
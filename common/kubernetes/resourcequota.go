@@ -0,0 +1,25 @@
+package kubernetes
+
+import (
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewResourceQuota creates a Resource Quota
+func NewResourceQuota(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
+	name string, namespace string, labels map[string]string, hard corev1.ResourceList) *corev1.ResourceQuota {
+
+	resourceQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    WorkshopLabels(workshop, labels),
+		},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: hard,
+		},
+	}
+	return resourceQuota
+}
@@ -4,7 +4,7 @@ import (
 	rbac "k8s.io/api/rbac/v1"
 )
 
-//GiteaRules gets Rules
+// GiteaRules gets Rules
 func GiteaRules() []rbac.PolicyRule {
 	return []rbac.PolicyRule{
 		{
@@ -123,7 +123,7 @@ func GiteaRules() []rbac.PolicyRule {
 	}
 }
 
-//JaegerUserRules gets Rules
+// JaegerUserRules gets Rules
 func JaegerUserRules() []rbac.PolicyRule {
 	return []rbac.PolicyRule{
 		{
@@ -140,7 +140,7 @@ func JaegerUserRules() []rbac.PolicyRule {
 	}
 }
 
-//IstioWorkspaceRules gets Rules
+// IstioWorkspaceRules gets Rules
 func IstioWorkspaceRules() []rbac.PolicyRule {
 	return []rbac.PolicyRule{
 		{
@@ -242,7 +242,7 @@ func IstioWorkspaceRules() []rbac.PolicyRule {
 	}
 }
 
-//IstioWorkspaceUserRules gets Rules
+// IstioWorkspaceUserRules gets Rules
 func IstioWorkspaceUserRules() []rbac.PolicyRule {
 	return []rbac.PolicyRule{
 		{
@@ -265,7 +265,7 @@ func IstioWorkspaceUserRules() []rbac.PolicyRule {
 	}
 }
 
-//VaultAgentInjectorRules gets Rules
+// VaultAgentInjectorRules gets Rules
 func VaultAgentInjectorRules() []rbac.PolicyRule {
 	return []rbac.PolicyRule{
 		{
@@ -285,7 +285,7 @@ func VaultAgentInjectorRules() []rbac.PolicyRule {
 	}
 }
 
-//CheRules gets Rules
+// CheRules gets Rules
 func CheRules() []rbac.PolicyRule {
 	return []rbac.PolicyRule{
 		{
@@ -302,7 +302,7 @@ func CheRules() []rbac.PolicyRule {
 	}
 }
 
-//ArgoCDRules gets Rules
+// ArgoCDRules gets Rules
 func ArgoCDRules() []rbac.PolicyRule {
 	return []rbac.PolicyRule{
 		{
@@ -17,13 +17,29 @@ func NewStringDataSecret(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    WorkshopLabels(workshop, labels),
 		},
 		StringData: stringData,
 	}
 	return secret
 }
 
+// NewDataSecret creates a Secret of byte values. Server-side apply prunes the data keys missing from a later apply,
+// unlike string data which is never stored as such.
+func NewDataSecret(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
+	name string, namespace string, labels map[string]string, data map[string][]byte) *corev1.Secret {
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    WorkshopLabels(workshop, labels),
+		},
+		Data: data,
+	}
+	return secret
+}
+
 // NewCrtSecret create a CRT Secret
 func NewCrtSecret(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 	name string, namespace string, labels map[string]string, crt []byte) *corev1.Secret {
@@ -32,7 +48,7 @@ func NewCrtSecret(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    WorkshopLabels(workshop, labels),
 		},
 		Data: map[string][]byte{
 			"ca.crt": crt,
@@ -45,3 +61,21 @@ func NewCrtSecret(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 	}
 	return secret
 }
+
+// NewServiceAccountTokenSecret creates a Secret the token controller fills with a token of the Service Account
+func NewServiceAccountTokenSecret(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
+	name string, namespace string, labels map[string]string, serviceAccountName string) *corev1.Secret {
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    WorkshopLabels(workshop, labels),
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey: serviceAccountName,
+			},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+	return secret
+}
@@ -27,7 +27,7 @@ func NewService(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    WorkshopLabels(workshop, labels),
 		},
 		Spec: corev1.ServiceSpec{
 			Ports:    ports,
@@ -55,7 +55,7 @@ func NewCustomService(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    WorkshopLabels(workshop, labels),
 		},
 		Spec: corev1.ServiceSpec{
 			Ports: ports,
@@ -90,7 +90,7 @@ func NewServiceWithTarget(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels:    labels,
+			Labels:    WorkshopLabels(workshop, labels),
 		},
 		Spec: corev1.ServiceSpec{
 			Ports:    ports,
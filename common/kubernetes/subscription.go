@@ -18,10 +18,10 @@ func NewCertifiedSubscription(workshop *workshopv1.Workshop, scheme *runtime.Sch
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels: map[string]string{
+			Labels: WorkshopLabels(workshop, map[string]string{
 				"csc-owner-name":      "certified-operators",
 				"csc-owner-namespace": "openshift-marketplace",
-			},
+			}),
 		},
 		Spec: &olmv1alpha1.SubscriptionSpec{
 			Channel:                channel,
@@ -44,10 +44,10 @@ func NewCommunitySubscription(workshop *workshopv1.Workshop, scheme *runtime.Sch
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels: map[string]string{
+			Labels: WorkshopLabels(workshop, map[string]string{
 				"csc-owner-name":      "community-operators",
 				"csc-owner-namespace": "openshift-marketplace",
-			},
+			}),
 		},
 		Spec: &olmv1alpha1.SubscriptionSpec{
 			Channel:                channel,
@@ -75,10 +75,10 @@ func NewRedHatSubscription(workshop *workshopv1.Workshop, scheme *runtime.Scheme
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels: map[string]string{
+			Labels: WorkshopLabels(workshop, map[string]string{
 				"csc-owner-name":      "redhat-operators",
 				"csc-owner-namespace": "openshift-marketplace",
-			},
+			}),
 		},
 		Spec: &olmv1alpha1.SubscriptionSpec{
 			Channel:                channel,
@@ -101,10 +101,10 @@ func NewCustomSubscription(workshop *workshopv1.Workshop, scheme *runtime.Scheme
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
-			Labels: map[string]string{
+			Labels: WorkshopLabels(workshop, map[string]string{
 				"csc-owner-name":      "custom-operators",
 				"csc-owner-namespace": "openshift-marketplace",
-			},
+			}),
 		},
 		Spec: &olmv1alpha1.SubscriptionSpec{
 			Channel:                channel,
@@ -130,6 +130,7 @@ func NewRedHatClusterServiceVersion(workshop *workshopv1.Workshop, scheme *runti
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      startingCSV,
 			Namespace: namespace,
+			Labels:    WorkshopLabels(workshop, nil),
 		},
 	}
 	return csv
@@ -4,6 +4,7 @@ import (
 	maistrav1 "github.com/maistra/istio-operator/pkg/apis/maistra/v1"
 	maistrav2 "github.com/maistra/istio-operator/pkg/apis/maistra/v2"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -18,6 +19,7 @@ func NewServiceMeshControlPlaneCR(workshop *workshopv1.Workshop, scheme *runtime
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
+			Labels:    kubernetes.WorkshopLabels(workshop, nil),
 		},
 		Spec: maistrav2.ControlPlaneSpec{
 			Version: "v2.0",
@@ -54,6 +56,7 @@ func NewServiceMeshMemberRollCR(workshop *workshopv1.Workshop, scheme *runtime.S
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
+			Labels:    kubernetes.WorkshopLabels(workshop, nil),
 		},
 		Spec: maistrav1.ServiceMeshMemberRollSpec{
 			Members: members,
@@ -0,0 +1,50 @@
+package platform
+
+import (
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// Platform is the flavour of Kubernetes the operator runs on
+type Platform string
+
+const (
+	OpenShift  Platform = "OpenShift"
+	Kubernetes Platform = "Kubernetes"
+)
+
+// openShiftGroups are the OpenShift APIs the operator relies on, a cluster serving all of them is OpenShift
+var openShiftGroups = []string{
+	"route.openshift.io",
+	"user.openshift.io",
+	"security.openshift.io",
+	"config.openshift.io",
+}
+
+// Detect discovers the API groups served by the cluster to tell OpenShift and plain Kubernetes apart
+func Detect(config *rest.Config) (Platform, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return "", err
+	}
+	groups, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return "", err
+	}
+
+	served := make(map[string]bool)
+	for _, group := range groups.Groups {
+		served[group.Name] = true
+	}
+	for _, group := range openShiftGroups {
+		if !served[group] {
+			return Kubernetes, nil
+		}
+	}
+	return OpenShift, nil
+}
+
+// IsOpenShift returns true on OpenShift, an unknown platform is assumed to be OpenShift
+func (p Platform) IsOpenShift() bool {
+	return p != Kubernetes
+}
@@ -0,0 +1,235 @@
+package portal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// UPDATE_RETRIES bounds the attempts to update an attendee when portal replicas or instructors race
+	UPDATE_RETRIES = 5
+	// LAST_LOGIN_INTERVAL throttles the updates of the last login of an attendee
+	LAST_LOGIN_INTERVAL = time.Minute
+)
+
+// ErrNoUserAvailable is returned when every user is assigned
+var ErrNoUserAvailable = errors.New("all users are assigned")
+
+// Assignment records the attendee a user is handed out to
+type Assignment struct {
+	Email     string
+	ClaimedAt metav1.Time
+}
+
+// Assignments stores which attendee got which user in the WorkshopAttendees the operator creates for the users of
+// a workshop. Updates use the resource version, so two attendees never get the same user.
+type Assignments struct {
+	Client    client.Client
+	Workshop  string
+	Namespace string
+}
+
+// List returns the assignments by user name
+func (a *Assignments) List(ctx context.Context) (map[string]Assignment, error) {
+	attendees, err := a.attendees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignments := map[string]Assignment{}
+	for _, attendee := range attendees {
+		if attendee.Spec.Email == "" {
+			continue
+		}
+		assignment := Assignment{Email: attendee.Spec.Email}
+		if attendee.Status.Email == attendee.Spec.Email && attendee.Status.ClaimedAt != nil {
+			assignment.ClaimedAt = *attendee.Status.ClaimedAt
+		}
+		assignments[attendee.Spec.UserName] = assignment
+	}
+	return assignments, nil
+}
+
+// Find returns the user assigned to an email, if any
+func (a *Assignments) Find(ctx context.Context, email string) (string, bool, error) {
+	attendees, err := a.attendees(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	if attendee := findEmail(attendees, NormalizeEmail(email)); attendee != nil {
+		return attendee.Spec.UserName, true, nil
+	}
+	return "", false, nil
+}
+
+// Claim returns the user assigned to an email, or assigns the first free user of users to it
+func (a *Assignments) Claim(ctx context.Context, email string, users []User) (string, error) {
+	email = NormalizeEmail(email)
+	for i := 0; i < UPDATE_RETRIES; i++ {
+		attendees, err := a.attendees(ctx)
+		if err != nil {
+			return "", err
+		}
+		if attendee := findEmail(attendees, email); attendee != nil {
+			return attendee.Spec.UserName, nil
+		}
+
+		byUser := make(map[string]*workshopv1.WorkshopAttendee, len(attendees))
+		for i := range attendees {
+			byUser[attendees[i].Spec.UserName] = &attendees[i]
+		}
+		var free *workshopv1.WorkshopAttendee
+		for _, user := range users {
+			// Users the operator has not recorded yet can't be claimed
+			if attendee, ok := byUser[user.Name]; ok && attendee.Spec.Email == "" {
+				free = attendee
+				break
+			}
+		}
+		if free == nil {
+			return "", ErrNoUserAvailable
+		}
+
+		free.Spec.Email = email
+		if err := a.Client.Update(ctx, free); apierrors.IsConflict(err) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		a.recordClaim(ctx, free)
+		return free.Spec.UserName, nil
+	}
+	return "", errors.New("failed to claim a user, the attendees keep changing")
+}
+
+// Assign hands a user to an email, releasing any other user the email holds
+func (a *Assignments) Assign(ctx context.Context, username string, email string) error {
+	email = NormalizeEmail(email)
+	attendees, err := a.attendees(ctx)
+	if err != nil {
+		return err
+	}
+	if holder := findEmail(attendees, email); holder != nil && holder.Spec.UserName != username {
+		if err := a.Release(ctx, holder.Spec.UserName); err != nil {
+			return err
+		}
+	}
+	return a.update(ctx, username, func(attendee *workshopv1.WorkshopAttendee) bool {
+		if attendee.Spec.Email == email {
+			return false
+		}
+		attendee.Spec.Email = email
+		return true
+	})
+}
+
+// Release frees a user, so it can be assigned to another attendee
+func (a *Assignments) Release(ctx context.Context, username string) error {
+	return a.update(ctx, username, func(attendee *workshopv1.WorkshopAttendee) bool {
+		if attendee.Spec.Email == "" {
+			return false
+		}
+		attendee.Spec.Email = ""
+		return true
+	})
+}
+
+// Login records that the attendee holding a user opened the portal
+func (a *Assignments) Login(ctx context.Context, username string) error {
+	attendee, err := a.get(ctx, username)
+	if err != nil {
+		return err
+	}
+	if attendee.Status.LastLogin != nil && time.Since(attendee.Status.LastLogin.Time) < LAST_LOGIN_INTERVAL {
+		return nil
+	}
+	now := metav1.Now()
+	attendee.Status.LastLogin = &now
+	return a.Client.Status().Update(ctx, attendee)
+}
+
+// update changes the WorkshopAttendee of a user, mutate returns false when there is nothing to change
+func (a *Assignments) update(ctx context.Context, username string,
+	mutate func(attendee *workshopv1.WorkshopAttendee) bool) error {
+
+	for i := 0; i < UPDATE_RETRIES; i++ {
+		attendee, err := a.get(ctx, username)
+		if err != nil {
+			return err
+		}
+		if !mutate(attendee) {
+			return nil
+		}
+		if err := a.Client.Update(ctx, attendee); apierrors.IsConflict(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if attendee.Spec.Email != "" {
+			a.recordClaim(ctx, attendee)
+		}
+		return nil
+	}
+	return errors.New("failed to update " + username + ", the attendee keeps changing")
+}
+
+// recordClaim sets the claim time right away, the operator records it otherwise on its next reconcile
+func (a *Assignments) recordClaim(ctx context.Context, attendee *workshopv1.WorkshopAttendee) {
+	now := metav1.Now()
+	attendee.Status.Email = attendee.Spec.Email
+	attendee.Status.ClaimedAt = &now
+	attendee.Status.LastLogin = nil
+	if err := a.Client.Status().Update(ctx, attendee); err != nil {
+		log.Warnf("Failed to record the claim of %s: %s", attendee.Spec.UserName, err)
+	}
+}
+
+// get returns the WorkshopAttendee of a user
+func (a *Assignments) get(ctx context.Context, username string) (*workshopv1.WorkshopAttendee, error) {
+	attendee := &workshopv1.WorkshopAttendee{}
+	name := AttendeeName(a.Workshop, username)
+	if err := a.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: a.Namespace}, attendee); err != nil {
+		return nil, err
+	}
+	return attendee, nil
+}
+
+// attendees returns the WorkshopAttendees of the workshop
+func (a *Assignments) attendees(ctx context.Context) ([]workshopv1.WorkshopAttendee, error) {
+	list := &workshopv1.WorkshopAttendeeList{}
+	if err := a.Client.List(ctx, list, client.InNamespace(a.Namespace)); err != nil {
+		return nil, err
+	}
+	attendees := []workshopv1.WorkshopAttendee{}
+	for _, attendee := range list.Items {
+		if attendee.Spec.Workshop == a.Workshop {
+			attendees = append(attendees, attendee)
+		}
+	}
+	return attendees, nil
+}
+
+// AttendeeName returns the name of the WorkshopAttendee of a user
+func AttendeeName(workshop string, username string) string {
+	return workshop + "-" + username
+}
+
+// findEmail returns the attendee holding a normalized email
+func findEmail(attendees []workshopv1.WorkshopAttendee, email string) *workshopv1.WorkshopAttendee {
+	if email == "" {
+		return nil
+	}
+	for i := range attendees {
+		if NormalizeEmail(attendees[i].Spec.Email) == email {
+			return &attendees[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package portal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testWorkshop  = "workshop"
+	testNamespace = "workshops"
+)
+
+var attendeeResource = schema.GroupResource{Group: workshopv1.GroupVersion.Group, Resource: "workshopattendees"}
+
+// attendeeClient stores WorkshopAttendees in memory and rejects writes of stale resource versions like the API server
+type attendeeClient struct {
+	attendees map[string]*workshopv1.WorkshopAttendee
+	version   int
+	// beforeUpdate runs before an update is checked, to let a concurrent writer change the attendee first
+	beforeUpdate func(c *attendeeClient, attendee *workshopv1.WorkshopAttendee)
+}
+
+func newAttendeeClient(attendees ...*workshopv1.WorkshopAttendee) *attendeeClient {
+	c := &attendeeClient{attendees: map[string]*workshopv1.WorkshopAttendee{}}
+	for _, attendee := range attendees {
+		c.store(attendee.DeepCopy())
+	}
+	return c
+}
+
+// store saves an attendee under a new resource version
+func (c *attendeeClient) store(attendee *workshopv1.WorkshopAttendee) {
+	c.version++
+	attendee.ResourceVersion = strconv.Itoa(c.version)
+	c.attendees[attendee.Name] = attendee.DeepCopy()
+}
+
+// write saves an attendee if it was read at the current resource version
+func (c *attendeeClient) write(obj runtime.Object, status bool) error {
+	attendee, ok := obj.(*workshopv1.WorkshopAttendee)
+	if !ok {
+		return fmt.Errorf("unexpected %T", obj)
+	}
+	if c.beforeUpdate != nil && !status {
+		c.beforeUpdate(c, attendee)
+	}
+	stored, ok := c.attendees[attendee.Name]
+	if !ok {
+		return apierrors.NewNotFound(attendeeResource, attendee.Name)
+	}
+	if stored.ResourceVersion != attendee.ResourceVersion {
+		return apierrors.NewConflict(attendeeResource, attendee.Name, fmt.Errorf("the object has been modified"))
+	}
+	updated := stored.DeepCopy()
+	if status {
+		updated.Status = attendee.Status
+	} else {
+		updated.Spec = attendee.Spec
+	}
+	c.store(updated)
+	attendee.ResourceVersion = updated.ResourceVersion
+	return nil
+}
+
+func (c *attendeeClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	stored, ok := c.attendees[key.Name]
+	if !ok || stored.Namespace != key.Namespace {
+		return apierrors.NewNotFound(attendeeResource, key.Name)
+	}
+	stored.DeepCopyInto(obj.(*workshopv1.WorkshopAttendee))
+	return nil
+}
+
+func (c *attendeeClient) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	attendees := list.(*workshopv1.WorkshopAttendeeList)
+	attendees.Items = nil
+	for _, stored := range c.attendees {
+		if listOpts.Namespace == "" || stored.Namespace == listOpts.Namespace {
+			attendees.Items = append(attendees.Items, *stored.DeepCopy())
+		}
+	}
+	return nil
+}
+
+func (c *attendeeClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	return c.write(obj, false)
+}
+
+func (c *attendeeClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	return fmt.Errorf("create is not supported")
+}
+
+func (c *attendeeClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	return fmt.Errorf("delete is not supported")
+}
+
+func (c *attendeeClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return fmt.Errorf("patch is not supported")
+}
+
+func (c *attendeeClient) DeleteAllOf(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
+	return fmt.Errorf("delete all of is not supported")
+}
+
+func (c *attendeeClient) Status() client.StatusWriter {
+	return &attendeeStatusWriter{client: c}
+}
+
+type attendeeStatusWriter struct {
+	client *attendeeClient
+}
+
+func (w *attendeeStatusWriter) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	return w.client.write(obj, true)
+}
+
+func (w *attendeeStatusWriter) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return fmt.Errorf("patch is not supported")
+}
+
+// newAttendee returns the WorkshopAttendee of a user, held by email unless it is empty
+func newAttendee(workshop string, username string, email string) *workshopv1.WorkshopAttendee {
+	return &workshopv1.WorkshopAttendee{
+		ObjectMeta: metav1.ObjectMeta{Name: AttendeeName(workshop, username), Namespace: testNamespace},
+		Spec:       workshopv1.WorkshopAttendeeSpec{Workshop: workshop, UserName: username, Email: email},
+	}
+}
+
+// claimBy returns a beforeUpdate hook with which another writer holds a user for an email, once
+func claimBy(username string, email string) func(c *attendeeClient, attendee *workshopv1.WorkshopAttendee) {
+	claimed := false
+	return func(c *attendeeClient, attendee *workshopv1.WorkshopAttendee) {
+		if claimed {
+			return
+		}
+		claimed = true
+		racer := c.attendees[AttendeeName(testWorkshop, username)].DeepCopy()
+		racer.Spec.Email = email
+		c.store(racer)
+		// The attendee being claimed changed as well, e.g. it was the one the other writer took
+		if racer.Name != attendee.Name {
+			c.store(c.attendees[attendee.Name].DeepCopy())
+		}
+	}
+}
+
+func TestAssignmentsClaim(t *testing.T) {
+	users := []User{{Name: "user1"}, {Name: "user2"}, {Name: "user3"}}
+
+	tests := []struct {
+		name         string
+		attendees    []*workshopv1.WorkshopAttendee
+		users        []User
+		beforeUpdate func(c *attendeeClient, attendee *workshopv1.WorkshopAttendee)
+		email        string
+		user         string
+		err          error
+		anyErr       bool
+		// claimed is true if this claim assigned the user
+		claimed bool
+		// holders are the expected emails by user name after the claim
+		holders map[string]string
+	}{
+		{
+			name: "first free user",
+			attendees: []*workshopv1.WorkshopAttendee{
+				newAttendee(testWorkshop, "user1", "alice@example.com"),
+				newAttendee(testWorkshop, "user2", ""),
+				newAttendee(testWorkshop, "user3", ""),
+			},
+			users:   users,
+			email:   "bob@example.com",
+			user:    "user2",
+			claimed: true,
+			holders: map[string]string{"user1": "alice@example.com", "user2": "bob@example.com", "user3": ""},
+		},
+		{
+			name: "an email gets the user it holds again",
+			attendees: []*workshopv1.WorkshopAttendee{
+				newAttendee(testWorkshop, "user1", ""),
+				newAttendee(testWorkshop, "user2", "bob@example.com"),
+			},
+			users:   users,
+			email:   " Bob@Example.com ",
+			user:    "user2",
+			holders: map[string]string{"user1": "", "user2": "bob@example.com"},
+		},
+		{
+			name: "users without an attendee are skipped",
+			attendees: []*workshopv1.WorkshopAttendee{
+				newAttendee(testWorkshop, "user3", ""),
+			},
+			users:   users,
+			email:   "bob@example.com",
+			user:    "user3",
+			claimed: true,
+			holders: map[string]string{"user3": "bob@example.com"},
+		},
+		{
+			name: "attendees of other workshops are ignored",
+			attendees: []*workshopv1.WorkshopAttendee{
+				newAttendee(testWorkshop, "user1", "alice@example.com"),
+				newAttendee("other", "user2", ""),
+			},
+			users: users,
+			email: "bob@example.com",
+			err:   ErrNoUserAvailable,
+		},
+		{
+			name: "no free user",
+			attendees: []*workshopv1.WorkshopAttendee{
+				newAttendee(testWorkshop, "user1", "alice@example.com"),
+			},
+			users: users,
+			email: "bob@example.com",
+			err:   ErrNoUserAvailable,
+		},
+		{
+			name: "a user claimed concurrently is skipped for the next free user",
+			attendees: []*workshopv1.WorkshopAttendee{
+				newAttendee(testWorkshop, "user1", ""),
+				newAttendee(testWorkshop, "user2", ""),
+			},
+			users:        users,
+			beforeUpdate: claimBy("user1", "carol@example.com"),
+			email:        "bob@example.com",
+			user:         "user2",
+			claimed:      true,
+			holders:      map[string]string{"user1": "carol@example.com", "user2": "bob@example.com"},
+		},
+		{
+			name: "a concurrent claim of the same email wins",
+			attendees: []*workshopv1.WorkshopAttendee{
+				newAttendee(testWorkshop, "user1", ""),
+				newAttendee(testWorkshop, "user2", ""),
+			},
+			users:        users,
+			beforeUpdate: claimBy("user2", "bob@example.com"),
+			email:        "bob@example.com",
+			user:         "user2",
+			holders:      map[string]string{"user1": "", "user2": "bob@example.com"},
+		},
+		{
+			name: "the last free user claimed concurrently",
+			attendees: []*workshopv1.WorkshopAttendee{
+				newAttendee(testWorkshop, "user1", "alice@example.com"),
+				newAttendee(testWorkshop, "user2", ""),
+			},
+			users:        users,
+			beforeUpdate: claimBy("user2", "carol@example.com"),
+			email:        "bob@example.com",
+			err:          ErrNoUserAvailable,
+			holders:      map[string]string{"user1": "alice@example.com", "user2": "carol@example.com"},
+		},
+		{
+			name: "attendees that keep changing",
+			attendees: []*workshopv1.WorkshopAttendee{
+				newAttendee(testWorkshop, "user1", ""),
+			},
+			users: users,
+			beforeUpdate: func(c *attendeeClient, attendee *workshopv1.WorkshopAttendee) {
+				c.store(c.attendees[attendee.Name].DeepCopy())
+			},
+			email:   "bob@example.com",
+			anyErr:  true,
+			holders: map[string]string{"user1": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newAttendeeClient(tt.attendees...)
+			c.beforeUpdate = tt.beforeUpdate
+			assignments := &Assignments{Client: c, Workshop: testWorkshop, Namespace: testNamespace}
+
+			user, err := assignments.Claim(context.TODO(), tt.email, tt.users)
+			switch {
+			case tt.anyErr:
+				if err == nil || err == ErrNoUserAvailable {
+					t.Fatalf("Claim() = %s, %v, want an error other than %v", user, err, ErrNoUserAvailable)
+				}
+			case err != tt.err:
+				t.Fatalf("Claim() error = %v, want %v", err, tt.err)
+			case user != tt.user:
+				t.Errorf("Claim() = %s, want %s", user, tt.user)
+			}
+
+			for username, email := range tt.holders {
+				attendee := c.attendees[AttendeeName(testWorkshop, username)]
+				if attendee.Spec.Email != email {
+					t.Errorf("%s is held by %q, want %q", username, attendee.Spec.Email, email)
+				}
+			}
+			// The claim time of the new holder is recorded right away
+			if tt.claimed {
+				attendee := c.attendees[AttendeeName(testWorkshop, tt.user)]
+				if attendee.Status.Email != attendee.Spec.Email || attendee.Status.ClaimedAt == nil {
+					t.Errorf("status of %s = %+v, want the claim of %s", tt.user, attendee.Status, attendee.Spec.Email)
+				}
+			}
+		})
+	}
+}
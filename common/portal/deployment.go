@@ -0,0 +1,159 @@
+package portal
+
+import (
+	"path"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Environment variables configuring the portal server
+const (
+	ENV_TITLE          = "PORTAL_TITLE"
+	ENV_DURATION       = "PORTAL_DURATION"
+	ENV_ACCESS_TOKEN   = "PORTAL_ACCESS_TOKEN"
+	ENV_ADMIN_PASSWORD = "PORTAL_ADMIN_PASSWORD"
+	ENV_USERS_FILE     = "PORTAL_USERS_FILE"
+	ENV_WORKSHOP       = "PORTAL_WORKSHOP"
+	ENV_NAMESPACE      = "PORTAL_NAMESPACE"
+	USERS_MOUNT_PATH   = "/var/run/portal"
+	PORT               = 8080
+)
+
+// Settings configure a portal Deployment
+type Settings struct {
+	Image              string
+	Title              string
+	Duration           string
+	ServiceAccountName string
+	// UsersSecretName holds the users, TokenSecretName the access token and AdminSecretName the admin credentials
+	UsersSecretName string
+	TokenSecretName string
+	AdminSecretName string
+}
+
+// NewDeployment creates the Deployment of the portal server
+func NewDeployment(workshop *workshopv1.Workshop, scheme *runtime.Scheme,
+	name string, namespace string, labels map[string]string, settings Settings) *appsv1.Deployment {
+
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    kubernetes.WorkshopLabels(workshop, labels),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: settings.ServiceAccountName,
+					Volumes: []corev1.Volume{
+						{
+							Name: "users",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: settings.UsersSecretName,
+									Items: []corev1.KeyToPath{
+										{Key: USERS_KEY, Path: USERS_KEY},
+									},
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:    name,
+							Image:   settings.Image,
+							Command: []string{"/portal"},
+							Env: []corev1.EnvVar{
+								{
+									Name:  ENV_TITLE,
+									Value: settings.Title,
+								},
+								{
+									Name:  ENV_DURATION,
+									Value: settings.Duration,
+								},
+								{
+									Name: ENV_ACCESS_TOKEN,
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: settings.TokenSecretName},
+											Key:                  ACCESS_TOKEN_KEY,
+										},
+									},
+								},
+								{
+									Name: ENV_ADMIN_PASSWORD,
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: settings.AdminSecretName},
+											Key:                  openshiftuser.CREDENTIALS_PASSWORD,
+										},
+									},
+								},
+								{
+									Name:  ENV_USERS_FILE,
+									Value: path.Join(USERS_MOUNT_PATH, USERS_KEY),
+								},
+								{
+									Name:  ENV_WORKSHOP,
+									Value: workshop.Name,
+								},
+								{
+									Name: ENV_NAMESPACE,
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+									},
+								},
+							},
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Ports: []corev1.ContainerPort{
+								{
+									ContainerPort: PORT,
+									Protocol:      "TCP",
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/healthz",
+										Port: intstr.FromInt(PORT),
+									},
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "users",
+									MountPath: USERS_MOUNT_PATH,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Set Workshop instance as the owner and controller
+	err := ctrl.SetControllerReference(workshop, dep, scheme)
+	if err != nil {
+		log.Error(err, "Failed to set SetControllerReference")
+	}
+	return dep
+}
@@ -0,0 +1,359 @@
+package portal
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/log"
+)
+
+const (
+	SESSION_COOKIE_NAME = "workshop-portal"
+	ADMIN_USERNAME      = "admin"
+	CSRF_TOKEN_FIELD    = "csrfToken"
+)
+
+// Server hands out workshop users to attendees, who identify themselves with their email
+type Server struct {
+	Title string
+	// Duration is how long an attendee stays logged in to the portal
+	Duration time.Duration
+	// AccessToken is the code attendees enter to claim a user, so only attendees of the event get one
+	AccessToken string
+	// AdminPassword protects the page listing, releasing and reassigning the users
+	AdminPassword string
+	// UsersFile is the mounted users Secret, read on every request so user changes need no restart
+	UsersFile   string
+	Assignments *Assignments
+
+	sessionKey []byte
+}
+
+// Handler returns the HTTP handler of the portal
+func (s *Server) Handler() (http.Handler, error) {
+	if s.AccessToken == "" {
+		return nil, fmt.Errorf("an access token is required")
+	}
+
+	// Sessions are signed with a key of this process, attendees enter their email again after a restart
+	s.sessionKey = make([]byte, 32)
+	if _, err := rand.Read(s.sessionKey); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/claim", s.handleClaim)
+	mux.HandleFunc("/logout", s.handleLogout)
+	mux.HandleFunc("/admin", s.handleAdmin)
+	mux.HandleFunc("/admin/release", s.handleRelease)
+	mux.HandleFunc("/admin/assign", s.handleAssign)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux, nil
+}
+
+// pageData is what the pages of the portal render
+type pageData struct {
+	Title     string
+	Error     string
+	Email     string
+	User      *User
+	Rows      []adminRow
+	CSRFToken string
+}
+
+// adminRow is a user on the admin page
+type adminRow struct {
+	User      string
+	Email     string
+	ClaimedAt string
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	data := pageData{Title: s.Title}
+
+	email, ok := s.sessionEmail(r)
+	if !ok {
+		s.render(w, http.StatusOK, data)
+		return
+	}
+	username, ok, err := s.Assignments.Find(r.Context(), email)
+	if err != nil {
+		s.fail(w, data, err)
+		return
+	}
+	if !ok {
+		// The user was released, the attendee has to claim a new one
+		s.clearSession(w, r)
+		s.render(w, http.StatusOK, data)
+		return
+	}
+	user, err := s.user(username)
+	if err != nil {
+		s.fail(w, data, err)
+		return
+	}
+	if err := s.Assignments.Login(r.Context(), username); err != nil {
+		log.Warnf("Failed to record the login of %s: %s", email, err)
+	}
+	data.Email = email
+	data.User = user
+	s.render(w, http.StatusOK, data)
+}
+
+func (s *Server) handleClaim(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	data := pageData{Title: s.Title}
+
+	email := NormalizeEmail(r.PostFormValue("email"))
+	if email == "" || !strings.Contains(email, "@") {
+		data.Error = "Enter a valid email address."
+		s.render(w, http.StatusBadRequest, data)
+		return
+	}
+	if !equal(r.PostFormValue("accessToken"), s.AccessToken) {
+		data.Error = "The access token is not valid."
+		s.render(w, http.StatusForbidden, data)
+		return
+	}
+
+	users, err := ReadUsers(s.UsersFile)
+	if err != nil {
+		s.fail(w, data, err)
+		return
+	}
+	username, err := s.Assignments.Claim(r.Context(), email, users)
+	if err == ErrNoUserAvailable {
+		data.Error = "All users are taken, ask an instructor for help."
+		s.render(w, http.StatusServiceUnavailable, data)
+		return
+	} else if err != nil {
+		s.fail(w, data, err)
+		return
+	}
+	log.Infof("Assigned %s to %s", username, email)
+
+	s.setSession(w, r, email)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	s.clearSession(w, r)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+func (s *Server) handleAdmin(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+	data := pageData{Title: s.Title}
+
+	users, err := ReadUsers(s.UsersFile)
+	if err != nil {
+		s.fail(w, data, err)
+		return
+	}
+	assignments, err := s.Assignments.List(r.Context())
+	if err != nil {
+		s.fail(w, data, err)
+		return
+	}
+
+	data.Rows = []adminRow{}
+	for _, user := range users {
+		row := adminRow{User: user.Name}
+		if assignment, ok := assignments[user.Name]; ok {
+			row.Email = assignment.Email
+			row.ClaimedAt = assignment.ClaimedAt.Format(time.RFC3339)
+		}
+		data.Rows = append(data.Rows, row)
+	}
+	sort.SliceStable(data.Rows, func(i, j int) bool {
+		return data.Rows[i].Email != "" && data.Rows[j].Email == ""
+	})
+	data.CSRFToken = s.csrfToken()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := adminTemplate.Execute(w, data); err != nil {
+		log.Errorf("Failed to render the admin page: %s", err)
+	}
+}
+
+func (s *Server) handleRelease(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Redirect(w, r, "/admin", http.StatusSeeOther)
+		return
+	}
+	if !s.checkCSRFToken(w, r) {
+		return
+	}
+	username := r.PostFormValue("user")
+	if err := s.Assignments.Release(r.Context(), username); err != nil {
+		s.fail(w, pageData{Title: s.Title}, err)
+		return
+	}
+	log.Infof("Released %s", username)
+	http.Redirect(w, r, "/admin", http.StatusSeeOther)
+}
+
+func (s *Server) handleAssign(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Redirect(w, r, "/admin", http.StatusSeeOther)
+		return
+	}
+	if !s.checkCSRFToken(w, r) {
+		return
+	}
+	username := r.PostFormValue("user")
+	email := NormalizeEmail(r.PostFormValue("email"))
+	if email == "" || !strings.Contains(email, "@") {
+		http.Error(w, "Enter a valid email address.", http.StatusBadRequest)
+		return
+	}
+	if err := s.Assignments.Assign(r.Context(), username, email); err != nil {
+		s.fail(w, pageData{Title: s.Title}, err)
+		return
+	}
+	log.Infof("Assigned %s to %s", username, email)
+	http.Redirect(w, r, "/admin", http.StatusSeeOther)
+}
+
+// user returns a user of the users Secret
+func (s *Server) user(username string) (*User, error) {
+	users, err := ReadUsers(s.UsersFile)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if users[i].Name == username {
+			return &users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s is no longer a user of the workshop", username)
+}
+
+// authorizeAdmin asks for the admin credentials unless the request has them
+func (s *Server) authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	username, password, ok := r.BasicAuth()
+	if ok && s.AdminPassword != "" && equal(username, ADMIN_USERNAME) && equal(password, s.AdminPassword) {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", `Basic realm="`+s.Title+`"`)
+	http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	return false
+}
+
+// checkCSRFToken rejects admin forms not posted from the admin page, as browsers send the admin credentials
+// along with forms of any other site
+func (s *Server) checkCSRFToken(w http.ResponseWriter, r *http.Request) bool {
+	if equal(r.PostFormValue(CSRF_TOKEN_FIELD), s.csrfToken()) {
+		return true
+	}
+	http.Error(w, "Forbidden", http.StatusForbidden)
+	return false
+}
+
+// csrfToken is the token of the admin forms, other sites cannot read it from the admin page
+func (s *Server) csrfToken() string {
+	return s.sign(CSRF_TOKEN_FIELD)
+}
+
+// setSession remembers the email of the attendee for the duration of the workshop
+func (s *Server) setSession(w http.ResponseWriter, r *http.Request, email string) {
+	expires := time.Now().Add(s.Duration)
+	value := base64.RawURLEncoding.EncodeToString([]byte(email)) + "." + strconv.FormatInt(expires.Unix(), 10)
+	http.SetCookie(w, &http.Cookie{
+		Name:     SESSION_COOKIE_NAME,
+		Value:    value + "." + s.sign(value),
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   isSecure(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (s *Server) clearSession(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SESSION_COOKIE_NAME,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecure(r),
+	})
+}
+
+// isSecure returns true if the attendee reached the portal over HTTPS, directly or through the route or ingress
+func isSecure(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// sessionEmail returns the email of the attendee if the request has a valid session
+func (s *Server) sessionEmail(r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(SESSION_COOKIE_NAME)
+	if err != nil {
+		return "", false
+	}
+	parts := strings.Split(cookie.Value, ".")
+	if len(parts) != 3 || !hmac.Equal([]byte(parts[2]), []byte(s.sign(parts[0]+"."+parts[1]))) {
+		return "", false
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return "", false
+	}
+	email, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", false
+	}
+	return string(email), true
+}
+
+func (s *Server) sign(value string) string {
+	mac := hmac.New(sha256.New, s.sessionKey)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Server) render(w http.ResponseWriter, status int, data pageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := indexTemplate.Execute(w, data); err != nil {
+		log.Errorf("Failed to render the portal page: %s", err)
+	}
+}
+
+func (s *Server) fail(w http.ResponseWriter, data pageData, err error) {
+	log.Errorf("Portal request failed: %s", err)
+	data.Error = "Something went wrong, try again or ask an instructor for help."
+	data.User = nil
+	s.render(w, http.StatusInternalServerError, data)
+}
+
+// equal compares secrets in constant time
+func equal(a string, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
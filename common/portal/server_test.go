@@ -0,0 +1,117 @@
+package portal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHandlerRequiresAccessToken(t *testing.T) {
+	server := &Server{Title: "Workshop"}
+	if _, err := server.Handler(); err == nil {
+		t.Fatal("Handler() without an access token succeeded, want an error")
+	}
+}
+
+func TestClaimAccessToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{name: "missing token", token: "", status: http.StatusForbidden},
+		{name: "wrong token", token: "guess", status: http.StatusForbidden},
+	}
+
+	server := &Server{Title: "Workshop", AccessToken: "s3cret"}
+	handler, err := server.Handler()
+	if err != nil {
+		t.Fatalf("Handler() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{"email": {"bob@example.com"}, "accessToken": {tt.token}}
+			request := httptest.NewRequest(http.MethodPost, "/claim", strings.NewReader(form.Encode()))
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			if recorder.Code != tt.status {
+				t.Errorf("POST /claim status = %d, want %d", recorder.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestReleaseCSRFToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  func(s *Server) string
+		status int
+		email  string
+	}{
+		{name: "token of the admin page", token: func(s *Server) string { return s.csrfToken() }, status: http.StatusSeeOther, email: ""},
+		{name: "missing token", token: func(s *Server) string { return "" }, status: http.StatusForbidden, email: "bob@example.com"},
+		{name: "wrong token", token: func(s *Server) string { return "guess" }, status: http.StatusForbidden, email: "bob@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newAttendeeClient(newAttendee(testWorkshop, "user1", "bob@example.com"))
+			server := &Server{Title: "Workshop", AccessToken: "s3cret", AdminPassword: "admin",
+				Assignments: &Assignments{Client: c, Workshop: testWorkshop, Namespace: testNamespace}}
+			handler, err := server.Handler()
+			if err != nil {
+				t.Fatalf("Handler() error = %v", err)
+			}
+
+			form := url.Values{"user": {"user1"}, CSRF_TOKEN_FIELD: {tt.token(server)}}
+			request := httptest.NewRequest(http.MethodPost, "/admin/release", strings.NewReader(form.Encode()))
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			request.SetBasicAuth(ADMIN_USERNAME, "admin")
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			if recorder.Code != tt.status {
+				t.Errorf("POST /admin/release status = %d, want %d", recorder.Code, tt.status)
+			}
+			if email := c.attendees[AttendeeName(testWorkshop, "user1")].Spec.Email; email != tt.email {
+				t.Errorf("user1 email = %q, want %q", email, tt.email)
+			}
+		})
+	}
+}
+
+func TestSessionCookieSecure(t *testing.T) {
+	tests := []struct {
+		name   string
+		proto  string
+		secure bool
+	}{
+		{name: "plain HTTP", proto: "", secure: false},
+		{name: "HTTPS terminated by the route", proto: "https", secure: true},
+		{name: "HTTP forwarded by the ingress", proto: "http", secure: false},
+	}
+
+	server := &Server{Title: "Workshop", AccessToken: "s3cret"}
+	if _, err := server.Handler(); err != nil {
+		t.Fatalf("Handler() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/logout", nil)
+			if tt.proto != "" {
+				request.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+			recorder := httptest.NewRecorder()
+			server.setSession(recorder, request, "bob@example.com")
+			cookies := recorder.Result().Cookies()
+			if len(cookies) != 1 {
+				t.Fatalf("setSession() set %d cookies, want 1", len(cookies))
+			}
+			if cookies[0].Secure != tt.secure {
+				t.Errorf("session cookie Secure = %t, want %t", cookies[0].Secure, tt.secure)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package portal
+
+import "html/template"
+
+const style = `
+<style>
+  body { font-family: sans-serif; max-width: 48em; margin: 2em auto; padding: 0 1em; color: #151515; }
+  h1 { font-weight: normal; }
+  .error { color: #c9190b; }
+  label { display: block; margin: 1em 0 .25em; }
+  input[type=text], input[type=email], input[type=password] { width: 100%; padding: .5em; box-sizing: border-box; }
+  button { margin-top: 1em; padding: .5em 1.5em; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { text-align: left; padding: .4em; border-bottom: 1px solid #d2d2d2; }
+  code { background: #f0f0f0; padding: .1em .3em; }
+</style>`
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>` + style + `
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+{{if .User}}
+<p>Welcome {{.Email}}, here is your workshop user.</p>
+<table>
+  <tr><th>User name</th><td><code>{{.User.Name}}</code></td></tr>
+  <tr><th>Password</th><td><code>{{.User.Password}}</code></td></tr>
+  {{if .User.Projects}}<tr><th>Projects</th><td>{{range .User.Projects}}<code>{{.}}</code> {{end}}</td></tr>{{end}}
+</table>
+{{if .User.Links}}
+<h2>Links</h2>
+<ul>
+  {{range .User.Links}}<li><a href="{{.URL}}" target="_blank" rel="noopener">{{.Name}}</a></li>{{end}}
+</ul>
+{{end}}
+<p><a href="/logout">Log out</a></p>
+{{else}}
+<form method="post" action="/claim">
+  <label for="email">Email</label>
+  <input type="email" id="email" name="email" required>
+  <label for="accessToken">Access token</label>
+  <input type="password" id="accessToken" name="accessToken" required>
+  <button type="submit">Get a user</button>
+</form>
+{{end}}
+</body>
+</html>
+`))
+
+var adminTemplate = template.Must(template.New("admin").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} - Assignments</title>` + style + `
+</head>
+<body>
+<h1>{{.Title}} - Assignments</h1>
+<table>
+  <tr><th>User</th><th>Email</th><th>Claimed</th><th></th><th></th></tr>
+  {{range .Rows}}
+  <tr>
+    <td><code>{{.User}}</code></td>
+    <td>{{.Email}}</td>
+    <td>{{.ClaimedAt}}</td>
+    <td>{{if .Email}}<form method="post" action="/admin/release"><input type="hidden" name="user" value="{{.User}}"><input type="hidden" name="csrfToken" value="{{$.CSRFToken}}"><button type="submit">Release</button></form>{{end}}</td>
+    <td><form method="post" action="/admin/assign"><input type="hidden" name="user" value="{{.User}}"><input type="hidden" name="csrfToken" value="{{$.CSRFToken}}"><input type="email" name="email" placeholder="Email" required><button type="submit">{{if .Email}}Reassign{{else}}Assign{{end}}</button></form></td>
+  </tr>
+  {{end}}
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,55 @@
+package portal
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	// USERS_KEY holds the users handed out by the portal in the users Secret
+	USERS_KEY = "users.json"
+	// ACCESS_TOKEN_KEY holds the code attendees enter to get a user in the access token Secret
+	ACCESS_TOKEN_KEY = "accessToken"
+)
+
+// Link is a URL shown to the attendee a user is assigned to
+type Link struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// User is a workshop user with everything an attendee needs to use it
+type User struct {
+	Name     string   `json:"name"`
+	Password string   `json:"password"`
+	Projects []string `json:"projects,omitempty"`
+	Links    []Link   `json:"links,omitempty"`
+}
+
+// FormatUsers returns the users Secret content of users
+func FormatUsers(users []User) (string, error) {
+	data, err := json.MarshalIndent(users, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ReadUsers reads the users from a mounted users Secret, in the order they are handed out
+func ReadUsers(path string) ([]User, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	users := []User{}
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// NormalizeEmail returns the email an attendee is known by, so the same address always gets the same user
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,74 @@
+package seed
+
+import (
+	"encoding/json"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// INVENTORY_KEY is the key of the applied objects in the inventory ConfigMap
+const INVENTORY_KEY = "inventory"
+
+// Entry identifies an applied object, so it can be pruned once no manifest renders it
+type Entry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// EntryOf returns the inventory entry of an object
+func EntryOf(obj *unstructured.Unstructured) Entry {
+	return Entry{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+// Object returns an object with the identity of the entry, e.g. to delete it
+func (e Entry) Object() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(e.APIVersion)
+	obj.SetKind(e.Kind)
+	obj.SetNamespace(e.Namespace)
+	obj.SetName(e.Name)
+	return obj
+}
+
+// ParseInventory returns the entries of an inventory, an empty inventory has none
+func ParseInventory(data string) ([]Entry, error) {
+	entries := []Entry{}
+	if data == "" {
+		return entries, nil
+	}
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FormatInventory returns an inventory of the entries, sorted so it only changes with the applied objects
+func FormatInventory(entries []Entry) (string, error) {
+	sorted := append([]Entry{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.APIVersion != b.APIVersion {
+			return a.APIVersion < b.APIVersion
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	data, err := json.MarshalIndent(sorted, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,106 @@
+package seed
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestInventory(t *testing.T) {
+	deployment := Entry{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "user1-dev", Name: "app"}
+	service := Entry{APIVersion: "v1", Kind: "Service", Namespace: "user1-dev", Name: "app"}
+	configMap := Entry{APIVersion: "v1", Kind: "ConfigMap", Namespace: "user1-dev", Name: "app"}
+	clusterRole := Entry{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "app"}
+	other := Entry{APIVersion: "v1", Kind: "Service", Namespace: "user1-dev", Name: "other"}
+
+	tests := []struct {
+		name    string
+		entries []Entry
+		sorted  []Entry
+	}{
+		{
+			name:    "empty",
+			entries: []Entry{},
+			sorted:  []Entry{},
+		},
+		{
+			name:    "sorted by namespace, apiVersion, kind and name",
+			entries: []Entry{other, service, deployment, configMap, clusterRole},
+			sorted:  []Entry{clusterRole, deployment, configMap, service, other},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := FormatInventory(tt.entries)
+			if err != nil {
+				t.Fatalf("FormatInventory() error = %v", err)
+			}
+			entries, err := ParseInventory(data)
+			if err != nil {
+				t.Fatalf("ParseInventory() error = %v", err)
+			}
+			if !reflect.DeepEqual(entries, tt.sorted) {
+				t.Errorf("ParseInventory(FormatInventory()) = %v, want %v", entries, tt.sorted)
+			}
+
+			// The order of the applied objects does not change the inventory
+			reversed := []Entry{}
+			for i := len(tt.entries) - 1; i >= 0; i-- {
+				reversed = append(reversed, tt.entries[i])
+			}
+			if reversedData, err := FormatInventory(reversed); err != nil || reversedData != data {
+				t.Errorf("FormatInventory() of reversed entries = %s, %v, want %s", reversedData, err, data)
+			}
+		})
+	}
+}
+
+func TestParseInventory(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		entries []Entry
+		err     bool
+	}{
+		{name: "no inventory", data: "", entries: []Entry{}},
+		{name: "empty list", data: "[]", entries: []Entry{}},
+		{
+			name:    "cluster scoped entry",
+			data:    `[{"apiVersion": "v1", "kind": "Namespace", "name": "user1-dev"}]`,
+			entries: []Entry{{APIVersion: "v1", Kind: "Namespace", Name: "user1-dev"}},
+		},
+		{name: "invalid", data: "{", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ParseInventory(tt.data)
+			if (err != nil) != tt.err {
+				t.Fatalf("ParseInventory() error = %v, want error %v", err, tt.err)
+			}
+			if !tt.err && !reflect.DeepEqual(entries, tt.entries) {
+				t.Errorf("ParseInventory() = %v, want %v", entries, tt.entries)
+			}
+		})
+	}
+}
+
+func TestEntryOf(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetNamespace("user1-dev")
+	obj.SetName("app")
+	obj.SetLabels(map[string]string{"app": "app"})
+
+	entry := EntryOf(obj)
+	want := Entry{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "user1-dev", Name: "app"}
+	if entry != want {
+		t.Fatalf("EntryOf() = %+v, want %+v", entry, want)
+	}
+	if back := EntryOf(entry.Object()); back != want {
+		t.Errorf("EntryOf(Object()) = %+v, want %+v", back, want)
+	}
+}
@@ -0,0 +1,144 @@
+package seed
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/stakater/workshop-operator/common/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// SEED_FILE lists the manifests of a directory in the order they are applied.
+// Manifests are plain YAML templates, the seed file only orders them and nothing like kustomize is applied.
+const SEED_FILE = "seed.yaml"
+
+// seedFileFields are the fields of a seed file
+var seedFileFields = []string{"manifests"}
+
+// Data is what manifests can refer to
+type Data struct {
+	UserName   string
+	UserID     string
+	Project    string
+	AppsDomain string
+}
+
+// ManifestNames returns the manifests to apply in order, the manifests listed by the seed.yaml
+// at the root or every YAML file sorted by path
+func ManifestNames(files map[string]string) ([]string, error) {
+	if contents, ok := files[SEED_FILE]; ok {
+		return listedManifests(files, "", contents)
+	}
+	return yamlFiles(files, ""), nil
+}
+
+// listedManifests returns the manifests listed by a seed.yaml, directories are read recursively
+func listedManifests(files map[string]string, dir string, contents string) ([]string, error) {
+	fields := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(contents), &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path.Join(dir, SEED_FILE), err)
+	}
+	for field := range fields {
+		if !util.Contains(seedFileFields, field) {
+			return nil, fmt.Errorf("%s: %s is not supported, only manifests are", path.Join(dir, SEED_FILE), field)
+		}
+	}
+	manifests, _ := fields["manifests"].([]interface{})
+
+	names := []string{}
+	for _, manifest := range manifests {
+		name, ok := manifest.(string)
+		if !ok || strings.Contains(name, "://") || strings.HasPrefix(path.Clean(path.Join(dir, name)), "..") {
+			return nil, fmt.Errorf("%s: manifest %v is not a file of the directory", path.Join(dir, SEED_FILE), manifest)
+		}
+		name = path.Clean(path.Join(dir, name))
+		if _, ok := files[name]; ok {
+			names = append(names, name)
+			continue
+		}
+		if nested, ok := files[path.Join(name, SEED_FILE)]; ok {
+			nestedNames, err := listedManifests(files, name, nested)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, nestedNames...)
+			continue
+		}
+		dirNames := yamlFiles(files, name)
+		if len(dirNames) == 0 {
+			return nil, fmt.Errorf("%s: manifest %s not found", path.Join(dir, SEED_FILE), name)
+		}
+		names = append(names, dirNames...)
+	}
+	return names, nil
+}
+
+// yamlFiles returns the YAML files under dir sorted by path, except seed files
+func yamlFiles(files map[string]string, dir string) []string {
+	names := []string{}
+	for name := range files {
+		if dir != "" && !strings.HasPrefix(name, dir+"/") {
+			continue
+		}
+		extension := strings.ToLower(path.Ext(name))
+		if (extension != ".yaml" && extension != ".yml") || path.Base(name) == SEED_FILE {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render executes a manifest template and returns its objects, List objects are expanded to their items
+func Render(name string, manifest string, data Data) ([]*unstructured.Unstructured, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(manifest)
+	if err != nil {
+		return nil, err
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, err
+	}
+
+	objects := []*unstructured.Unstructured{}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(&rendered, 4096)
+	for {
+		fields := map[string]interface{}{}
+		if err := decoder.Decode(&fields); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %s", name, err)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: fields}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %s", name, err)
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	for _, obj := range objects {
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("%s: every object needs an apiVersion, a kind and a name", name)
+		}
+	}
+	return objects, nil
+}
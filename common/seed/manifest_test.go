@@ -0,0 +1,194 @@
+package seed
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestManifestNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		names []string
+		err   string
+	}{
+		{
+			name:  "no files",
+			files: map[string]string{},
+			names: []string{},
+		},
+		{
+			name: "YAML files sorted by path",
+			files: map[string]string{
+				"b.yaml":         "",
+				"a/deploy.yml":   "",
+				"README.md":      "",
+				"a/service.YAML": "",
+			},
+			names: []string{"a/deploy.yml", "a/service.YAML", "b.yaml"},
+		},
+		{
+			name: "nested seed files are skipped without a root seed file",
+			files: map[string]string{
+				"a/seed.yaml":   "manifests: [deploy.yaml]",
+				"a/deploy.yaml": "",
+			},
+			names: []string{"a/deploy.yaml"},
+		},
+		{
+			name: "listed manifests in order",
+			files: map[string]string{
+				"seed.yaml":    "manifests:\n- service.yaml\n- deploy.yaml\n",
+				"deploy.yaml":  "",
+				"service.yaml": "",
+				"unused.yaml":  "",
+			},
+			names: []string{"service.yaml", "deploy.yaml"},
+		},
+		{
+			name: "listed directories and nested seed files",
+			files: map[string]string{
+				"seed.yaml":            "manifests:\n- base\n- overlay\n",
+				"base/b.yaml":          "",
+				"base/a.yaml":          "",
+				"overlay/seed.yaml":    "manifests:\n- ./z.yaml\n",
+				"overlay/z.yaml":       "",
+				"overlay/ignored.yaml": "",
+			},
+			names: []string{"base/a.yaml", "base/b.yaml", "overlay/z.yaml"},
+		},
+		{
+			name: "unsupported seed file field",
+			files: map[string]string{
+				"seed.yaml":   "manifests: [deploy.yaml]\nnamePrefix: dev-\n",
+				"deploy.yaml": "",
+			},
+			err: "namePrefix is not supported",
+		},
+		{
+			name: "remote manifest",
+			files: map[string]string{
+				"seed.yaml": "manifests: [https://example.com/deploy.yaml]\n",
+			},
+			err: "is not a file of the directory",
+		},
+		{
+			name: "manifest outside of the directory",
+			files: map[string]string{
+				"seed.yaml": "manifests: [../deploy.yaml]\n",
+			},
+			err: "is not a file of the directory",
+		},
+		{
+			name: "missing manifest",
+			files: map[string]string{
+				"seed.yaml": "manifests: [deploy.yaml]\n",
+			},
+			err: "manifest deploy.yaml not found",
+		},
+		{
+			name: "invalid seed file",
+			files: map[string]string{
+				"seed.yaml": "manifests: [",
+			},
+			err: "failed to parse seed.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := ManifestNames(tt.files)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ManifestNames() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ManifestNames() error = %v", err)
+			}
+			if !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("ManifestNames() = %v, want %v", names, tt.names)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	data := Data{UserName: "user1", UserID: "1", Project: "user1-dev", AppsDomain: "apps.example.com"}
+
+	tests := []struct {
+		name     string
+		manifest string
+		// objects are the rendered objects as kind/namespace/name
+		objects []string
+		err     string
+	}{
+		{
+			name:     "empty manifest",
+			manifest: "",
+			objects:  []string{},
+		},
+		{
+			name:     "templated object",
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-{{.UserID}}\n  namespace: {{.Project}}\n",
+			objects:  []string{"ConfigMap/user1-dev/app-1"},
+		},
+		{
+			name: "several documents and empty ones",
+			manifest: "---\napiVersion: v1\nkind: Service\nmetadata:\n  name: app\n---\n# comment\n---\n" +
+				"apiVersion: route.openshift.io/v1\nkind: Route\nmetadata:\n  name: app\nspec:\n  host: app-{{.UserName}}.{{.AppsDomain}}\n",
+			objects: []string{"Service//app", "Route//app"},
+		},
+		{
+			name: "lists are expanded",
+			manifest: `{"apiVersion": "v1", "kind": "List", "items": [` +
+				`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}},` +
+				`{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "b"}}]}`,
+			objects: []string{"ConfigMap//a", "Secret//b"},
+		},
+		{
+			name:     "unknown template field",
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{.Email}}\n",
+			err:      "Email",
+		},
+		{
+			name:     "invalid template",
+			manifest: "name: {{.UserName",
+			err:      "unclosed action",
+		},
+		{
+			name:     "invalid YAML",
+			manifest: "apiVersion: v1\nkind: [ConfigMap\n",
+			err:      "failed to parse test.yaml",
+		},
+		{
+			name:     "object without a name",
+			manifest: "apiVersion: v1\nkind: ConfigMap\n",
+			err:      "every object needs an apiVersion, a kind and a name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := Render("test.yaml", tt.manifest, data)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Render() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			rendered := []string{}
+			for _, obj := range objects {
+				rendered = append(rendered, obj.GetKind()+"/"+obj.GetNamespace()+"/"+obj.GetName())
+			}
+			if !reflect.DeepEqual(rendered, tt.objects) {
+				t.Errorf("Render() = %v, want %v", rendered, tt.objects)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package seed
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+// GIT_REFRESH_INTERVAL is how long the manifests of a branch are used before checking whether the branch moved
+const GIT_REFRESH_INTERVAL = 5 * time.Minute
+
+// gitSource is the manifests of a directory at a commit
+type gitSource struct {
+	hash  plumbing.Hash
+	files map[string]string
+	// checked is when the branch was last resolved to hash
+	checked time.Time
+}
+
+// gitSources caches the manifests by repository, branch and directory, they are only read again once the branch moved
+var (
+	gitSourcesMu sync.Mutex
+	gitSources   = map[string]gitSource{}
+	// resolve lists the references of the remote repository, replaced in tests
+	resolve = resolveReference
+)
+
+// ReadGitFiles returns the files of a directory of a git repository by path relative to the directory.
+// branch is the default branch of the repository if empty.
+func ReadGitFiles(url string, branch string, dir string) (map[string]string, error) {
+	referenceName := plumbing.HEAD
+	if branch != "" {
+		referenceName = plumbing.NewBranchReferenceName(branch)
+	}
+	dir = strings.Trim(path.Clean("/"+dir), "/")
+
+	// Listing the references is a round trip to the remote, every reconcile of every workshop would make one
+	key := url + "#" + referenceName.String() + ":" + dir
+	gitSourcesMu.Lock()
+	cached, ok := gitSources[key]
+	gitSourcesMu.Unlock()
+	if ok && time.Since(cached.checked) < GIT_REFRESH_INTERVAL {
+		return cached.files, nil
+	}
+
+	checked := time.Now()
+	hash, err := resolve(url, referenceName)
+	if err != nil {
+		return nil, err
+	}
+	if ok && cached.hash == hash {
+		cached.checked = checked
+		gitSourcesMu.Lock()
+		gitSources[key] = cached
+		gitSourcesMu.Unlock()
+		return cached.files, nil
+	}
+
+	repository, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{
+		URL:           url,
+		ReferenceName: referenceName,
+		SingleBranch:  true,
+		Depth:         1,
+		Tags:          git.NoTags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone %s: %s", url, err)
+	}
+	head, err := repository.Head()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if tree, err = tree.Tree(dir); err != nil {
+			return nil, fmt.Errorf("failed to read %s of %s: %s", dir, url, err)
+		}
+	}
+
+	files := map[string]string{}
+	if err := tree.Files().ForEach(func(file *object.File) error {
+		contents, err := file.Contents()
+		if err != nil {
+			return err
+		}
+		files[file.Name] = contents
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	gitSourcesMu.Lock()
+	gitSources[key] = gitSource{hash: head.Hash(), files: files, checked: checked}
+	gitSourcesMu.Unlock()
+	return files, nil
+}
+
+// resolveReference returns the commit a reference of a remote repository points to
+func resolveReference(url string, referenceName plumbing.ReferenceName) (plumbing.Hash, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{url}})
+	references, err := remote.List(&git.ListOptions{})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to list the references of %s: %s", url, err)
+	}
+
+	byName := make(map[plumbing.ReferenceName]*plumbing.Reference)
+	for _, reference := range references {
+		byName[reference.Name()] = reference
+	}
+	for i := 0; i < 10; i++ {
+		reference, ok := byName[referenceName]
+		if !ok {
+			return plumbing.ZeroHash, fmt.Errorf("%s has no %s", url, referenceName.Short())
+		}
+		if reference.Type() == plumbing.HashReference {
+			return reference.Hash(), nil
+		}
+		referenceName = reference.Target()
+	}
+	return plumbing.ZeroHash, fmt.Errorf("failed to resolve %s of %s", referenceName.Short(), url)
+}
@@ -0,0 +1,52 @@
+package seed
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+func TestReadGitFilesCached(t *testing.T) {
+	const url = "https://example.com/workshop.git"
+	hash := plumbing.NewHash("0123456789abcdef0123456789abcdef01234567")
+	files := map[string]string{"deploy.yaml": "kind: Deployment"}
+	key := url + "#" + plumbing.NewBranchReferenceName("main").String() + ":seed"
+
+	tests := []struct {
+		name     string
+		checked  time.Duration
+		resolved bool
+	}{
+		{name: "checked within the refresh interval", checked: time.Minute, resolved: false},
+		{name: "checked before the refresh interval", checked: GIT_REFRESH_INTERVAL + time.Minute, resolved: true},
+	}
+
+	defer func() { resolve = resolveReference }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitSources[key] = gitSource{hash: hash, files: files, checked: time.Now().Add(-tt.checked)}
+			defer delete(gitSources, key)
+			resolved := false
+			resolve = func(string, plumbing.ReferenceName) (plumbing.Hash, error) {
+				resolved = true
+				return hash, nil
+			}
+
+			got, err := ReadGitFiles(url, "main", "seed/")
+			if err != nil {
+				t.Fatalf("ReadGitFiles() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, files) {
+				t.Errorf("ReadGitFiles() = %v, want %v", got, files)
+			}
+			if resolved != tt.resolved {
+				t.Errorf("ReadGitFiles() resolved the branch = %v, want %v", resolved, tt.resolved)
+			}
+			if checked := time.Since(gitSources[key].checked); tt.resolved && checked > time.Second {
+				t.Errorf("ReadGitFiles() kept the branch checked %s ago, want it checked again", checked)
+			}
+		})
+	}
+}
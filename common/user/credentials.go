@@ -0,0 +1,62 @@
+package user
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// CREDENTIALS_USER_LABEL holds the user name on the credentials Secret of an attendee
+	CREDENTIALS_USER_LABEL = kubernetes.WORKSHOP_USER_LABEL
+	CREDENTIALS_USERNAME   = "username"
+	CREDENTIALS_PASSWORD   = "password"
+	PASSWORD_LENGTH        = 16
+)
+
+// passwordAlphabet leaves out characters that are easily confused when read from a screen
+const passwordAlphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NewPassword returns a random password
+func NewPassword() (string, error) {
+	password := make([]byte, PASSWORD_LENGTH)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordAlphabet[n.Int64()]
+	}
+	return string(password), nil
+}
+
+// NewCredentialsSecret creates the Secret holding the credentials of an attendee
+func NewCredentialsSecret(workshop *workshopv1.Workshop, scheme *runtime.Scheme, name string, namespace string,
+	labels map[string]string, username string, password string) *corev1.Secret {
+
+	secretLabels := map[string]string{
+		CREDENTIALS_USER_LABEL: username,
+	}
+	for key, value := range labels {
+		secretLabels[key] = value
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    kubernetes.WorkshopLabels(workshop, secretLabels),
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			CREDENTIALS_USERNAME: username,
+			CREDENTIALS_PASSWORD: password,
+		},
+	}
+	return secret
+}
@@ -0,0 +1,32 @@
+package user
+
+import (
+	userv1 "github.com/openshift/api/user/v1"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	rbac "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewGroup create a group of users
+func NewGroup(workshop *workshopv1.Workshop, scheme *runtime.Scheme, name string, usernames []string, labels map[string]string) *userv1.Group {
+
+	group := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: kubernetes.WorkshopLabels(workshop, labels),
+		},
+		Users: userv1.OptionalNames(usernames),
+	}
+	return group
+}
+
+// NewGroupSubject returns the role binding subject of a group
+func NewGroupSubject(groupName string) rbac.Subject {
+	return rbac.Subject{
+		Kind:     rbac.GroupKind,
+		APIGroup: rbac.GroupName,
+		Name:     groupName,
+	}
+}
@@ -0,0 +1,103 @@
+package user
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// HTPASSWD_KEY is the key of the htpasswd file in the secret read by the OAuth server
+	HTPASSWD_KEY = "htpasswd"
+	// HTPASSWD_FINGERPRINT_ANNOTATION holds the HTPasswdFingerprint of the htpasswd secret
+	HTPASSWD_FINGERPRINT_ANNOTATION = "workshop.stakater.com/htpasswd-fingerprint"
+)
+
+// ParseHTPasswd returns the password hashes of an htpasswd file by user name
+func ParseHTPasswd(htpasswd []byte) map[string]string {
+	hashes := make(map[string]string)
+	for _, line := range strings.Split(string(htpasswd), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, ":"); i > 0 {
+			hashes[line[:i]] = line[i+1:]
+		}
+	}
+	return hashes
+}
+
+// NewHTPasswd returns an htpasswd file with a bcrypt entry per user, sorted by user name.
+// Existing hashes are kept while they still match the password, so the file only changes with the users or their passwords.
+func NewHTPasswd(passwords map[string]string, existing map[string]string) ([]byte, error) {
+	usernames := make([]string, 0, len(passwords))
+	for username := range passwords {
+		if username == "" || strings.ContainsAny(username, ":\r\n") {
+			return nil, fmt.Errorf("invalid htpasswd user name %q", username)
+		}
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	var htpasswd bytes.Buffer
+	for _, username := range usernames {
+		hash, err := HashPassword(passwords[username], existing[username])
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash the password of %s: %s", username, err)
+		}
+		fmt.Fprintf(&htpasswd, "%s:%s\n", username, hash)
+	}
+	return htpasswd.Bytes(), nil
+}
+
+// HashPassword returns a bcrypt hash of password, existing is returned as is while it is still a hash of password
+func HashPassword(password string, existing string) (string, error) {
+	if existing != "" && bcrypt.CompareHashAndPassword([]byte(existing), []byte(password)) == nil {
+		return existing, nil
+	}
+	generated, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(generated), nil
+}
+
+// HTPasswdMatches returns true if fingerprint was generated by HTPasswdFingerprint for the same passwords and file.
+// It costs a single bcrypt comparison, where checking every hash of the file costs one per user.
+func HTPasswdMatches(fingerprint string, passwords map[string]string, htpasswd []byte) bool {
+	if fingerprint == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(fingerprint), htpasswdDigest(passwords, htpasswd)) == nil
+}
+
+// HTPasswdFingerprint returns a bcrypt hash of the passwords and the htpasswd file generated from them
+func HTPasswdFingerprint(passwords map[string]string, htpasswd []byte) (string, error) {
+	fingerprint, err := bcrypt.GenerateFromPassword(htpasswdDigest(passwords, htpasswd), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(fingerprint), nil
+}
+
+// htpasswdDigest returns a sha256 of the passwords and the htpasswd file, short enough for bcrypt
+func htpasswdDigest(passwords map[string]string, htpasswd []byte) []byte {
+	usernames := make([]string, 0, len(passwords))
+	for username := range passwords {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	digest := sha256.New()
+	for _, username := range usernames {
+		fmt.Fprintf(digest, "%s:%s\n", username, passwords[username])
+	}
+	digest.Write(htpasswd)
+	return []byte(hex.EncodeToString(digest.Sum(nil)))
+}
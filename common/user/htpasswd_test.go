@@ -0,0 +1,208 @@
+package user
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestParseHTPasswd(t *testing.T) {
+	tests := []struct {
+		name     string
+		htpasswd string
+		hashes   map[string]string
+	}{
+		{
+			name:     "empty",
+			htpasswd: "",
+			hashes:   map[string]string{},
+		},
+		{
+			name:     "entries",
+			htpasswd: "user1:$2a$10$one\nuser2:$2a$10$two\n",
+			hashes:   map[string]string{"user1": "$2a$10$one", "user2": "$2a$10$two"},
+		},
+		{
+			name:     "comments, blank lines and surrounding spaces are skipped",
+			htpasswd: "# generated\n\n  user1:$2a$10$one  \r\n",
+			hashes:   map[string]string{"user1": "$2a$10$one"},
+		},
+		{
+			name:     "lines without a user name are skipped",
+			htpasswd: ":$2a$10$one\nuser2\nuser3:$2a$10$three",
+			hashes:   map[string]string{"user3": "$2a$10$three"},
+		},
+		{
+			name:     "hashes may contain colons",
+			htpasswd: "user1:a:b",
+			hashes:   map[string]string{"user1": "a:b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if hashes := ParseHTPasswd([]byte(tt.htpasswd)); !reflect.DeepEqual(hashes, tt.hashes) {
+				t.Errorf("ParseHTPasswd() = %v, want %v", hashes, tt.hashes)
+			}
+		})
+	}
+}
+
+func TestNewHTPasswd(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("openshift"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	existingHash := string(hash)
+
+	tests := []struct {
+		name      string
+		passwords map[string]string
+		existing  map[string]string
+		users     []string
+		// kept are the users whose existing hash must be reused
+		kept []string
+		err  bool
+	}{
+		{
+			name:      "no users",
+			passwords: map[string]string{},
+			users:     []string{},
+		},
+		{
+			name:      "entries are sorted by user name",
+			passwords: map[string]string{"user2": "two", "user10": "ten", "user1": "one"},
+			users:     []string{"user1", "user10", "user2"},
+		},
+		{
+			name:      "matching hashes are kept",
+			passwords: map[string]string{"user1": "openshift"},
+			existing:  map[string]string{"user1": existingHash},
+			users:     []string{"user1"},
+			kept:      []string{"user1"},
+		},
+		{
+			name:      "changed passwords are hashed again",
+			passwords: map[string]string{"user1": "changed"},
+			existing:  map[string]string{"user1": existingHash},
+			users:     []string{"user1"},
+		},
+		{
+			name:      "invalid existing hashes are replaced",
+			passwords: map[string]string{"user1": "openshift"},
+			existing:  map[string]string{"user1": "not-a-hash"},
+			users:     []string{"user1"},
+		},
+		{
+			name:      "user names with a colon are rejected",
+			passwords: map[string]string{"user:1": "openshift"},
+			err:       true,
+		},
+		{
+			name:      "empty user names are rejected",
+			passwords: map[string]string{"": "openshift"},
+			err:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			htpasswd, err := NewHTPasswd(tt.passwords, tt.existing)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("NewHTPasswd() = %q, want an error", htpasswd)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewHTPasswd() error = %v", err)
+			}
+
+			users := []string{}
+			for _, line := range strings.Split(strings.TrimSuffix(string(htpasswd), "\n"), "\n") {
+				if line != "" {
+					users = append(users, line[:strings.Index(line, ":")])
+				}
+			}
+			if !reflect.DeepEqual(users, tt.users) {
+				t.Errorf("NewHTPasswd() users = %v, want %v", users, tt.users)
+			}
+
+			hashes := ParseHTPasswd(htpasswd)
+			for username, password := range tt.passwords {
+				if err := bcrypt.CompareHashAndPassword([]byte(hashes[username]), []byte(password)); err != nil {
+					t.Errorf("hash of %s does not match the password: %s", username, err)
+				}
+			}
+			for _, username := range tt.kept {
+				if hashes[username] != tt.existing[username] {
+					t.Errorf("hash of %s = %s, want the existing %s", username, hashes[username], tt.existing[username])
+				}
+			}
+		})
+	}
+}
+
+func TestHTPasswdFingerprint(t *testing.T) {
+	passwords := map[string]string{"user1": "one", "user2": "two"}
+	htpasswd := []byte("user1:hash1\nuser2:hash2\n")
+	fingerprint, err := HTPasswdFingerprint(passwords, htpasswd)
+	if err != nil {
+		t.Fatalf("HTPasswdFingerprint() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		fingerprint string
+		passwords   map[string]string
+		htpasswd    []byte
+		matches     bool
+	}{
+		{
+			name:        "same users, passwords and file",
+			fingerprint: fingerprint,
+			passwords:   map[string]string{"user2": "two", "user1": "one"},
+			htpasswd:    htpasswd,
+			matches:     true,
+		},
+		{
+			name:      "no fingerprint",
+			passwords: passwords,
+			htpasswd:  htpasswd,
+		},
+		{
+			name:        "added user",
+			fingerprint: fingerprint,
+			passwords:   map[string]string{"user1": "one", "user2": "two", "user3": "three"},
+			htpasswd:    htpasswd,
+		},
+		{
+			name:        "removed user",
+			fingerprint: fingerprint,
+			passwords:   map[string]string{"user1": "one"},
+			htpasswd:    htpasswd,
+		},
+		{
+			name:        "changed password",
+			fingerprint: fingerprint,
+			passwords:   map[string]string{"user1": "one", "user2": "changed"},
+			htpasswd:    htpasswd,
+		},
+		{
+			name:        "edited file",
+			fingerprint: fingerprint,
+			passwords:   passwords,
+			htpasswd:    []byte("user1:hash1\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if matches := HTPasswdMatches(tt.fingerprint, tt.passwords, tt.htpasswd); matches != tt.matches {
+				t.Errorf("HTPasswdMatches() = %v, want %v", matches, tt.matches)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package user
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+const (
+	DEFAULT_OPENID_USERNAME_CLAIM   = "preferred_username"
+	DEFAULT_LDAP_USERNAME_ATTRIBUTE = "uid"
+)
+
+// NewHTPasswdIdentityProvider returns an identity provider reading the htpasswd file of a secret in openshift-config
+func NewHTPasswdIdentityProvider(name string, secretName string) configv1.IdentityProvider {
+	return configv1.IdentityProvider{
+		Name:          name,
+		MappingMethod: configv1.MappingMethodClaim,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type: configv1.IdentityProviderTypeHTPasswd,
+			HTPasswd: &configv1.HTPasswdIdentityProvider{
+				FileData: configv1.SecretNameReference{
+					Name: secretName,
+				},
+			},
+		},
+	}
+}
+
+// MergeIdentityProvider adds the identity provider to the OAuth config or replaces the one of the same name.
+// Other identity providers are left as they are. It returns true if the OAuth config changed.
+func MergeIdentityProvider(oauth *configv1.OAuth, provider configv1.IdentityProvider) bool {
+	for i := range oauth.Spec.IdentityProviders {
+		if oauth.Spec.IdentityProviders[i].Name != provider.Name {
+			continue
+		}
+		if equality.Semantic.DeepEqual(oauth.Spec.IdentityProviders[i], provider) {
+			return false
+		}
+		oauth.Spec.IdentityProviders[i] = provider
+		return true
+	}
+	oauth.Spec.IdentityProviders = append(oauth.Spec.IdentityProviders, provider)
+	return true
+}
+
+// HasOtherIdentityProvider returns true if the OAuth config has a different identity provider under the name of provider
+func HasOtherIdentityProvider(oauth *configv1.OAuth, provider configv1.IdentityProvider) bool {
+	for _, existing := range oauth.Spec.IdentityProviders {
+		if existing.Name == provider.Name {
+			return !equality.Semantic.DeepEqual(existing, provider)
+		}
+	}
+	return false
+}
+
+// RemoveIdentityProvider removes the identity providers with the given names from the OAuth config.
+// It returns true if the OAuth config changed.
+func RemoveIdentityProvider(oauth *configv1.OAuth, names ...string) bool {
+	removed := make(map[string]bool, len(names))
+	for _, name := range names {
+		removed[name] = true
+	}
+	providers := []configv1.IdentityProvider{}
+	for _, provider := range oauth.Spec.IdentityProviders {
+		if !removed[provider.Name] {
+			providers = append(providers, provider)
+		}
+	}
+	if len(providers) == len(oauth.Spec.IdentityProviders) {
+		return false
+	}
+	oauth.Spec.IdentityProviders = providers
+	return true
+}
+
+// NewOpenIDIdentityProvider returns an OpenID Connect identity provider
+func NewOpenIDIdentityProvider(name string, spec *workshopv1.OpenIDProviderSpec) configv1.IdentityProvider {
+	usernameClaim := spec.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = DEFAULT_OPENID_USERNAME_CLAIM
+	}
+	return configv1.IdentityProvider{
+		Name:          name,
+		MappingMethod: configv1.MappingMethodClaim,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type: configv1.IdentityProviderTypeOpenID,
+			OpenID: &configv1.OpenIDIdentityProvider{
+				ClientID:     spec.ClientID,
+				ClientSecret: configv1.SecretNameReference{Name: spec.ClientSecretName},
+				CA:           configv1.ConfigMapNameReference{Name: spec.CAName},
+				ExtraScopes:  spec.ExtraScopes,
+				Issuer:       spec.Issuer,
+				Claims: configv1.OpenIDClaims{
+					PreferredUsername: []string{usernameClaim},
+					Name:              []string{"name"},
+					Email:             []string{"email"},
+				},
+			},
+		},
+	}
+}
+
+// NewLDAPIdentityProvider returns an LDAP identity provider
+func NewLDAPIdentityProvider(name string, spec *workshopv1.LDAPProviderSpec) configv1.IdentityProvider {
+	usernameAttribute := spec.UsernameAttribute
+	if usernameAttribute == "" {
+		usernameAttribute = DEFAULT_LDAP_USERNAME_ATTRIBUTE
+	}
+	return configv1.IdentityProvider{
+		Name:          name,
+		MappingMethod: configv1.MappingMethodClaim,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type: configv1.IdentityProviderTypeLDAP,
+			LDAP: &configv1.LDAPIdentityProvider{
+				URL:          spec.URL,
+				BindDN:       spec.BindDN,
+				BindPassword: configv1.SecretNameReference{Name: spec.BindPasswordSecretName},
+				Insecure:     spec.Insecure,
+				CA:           configv1.ConfigMapNameReference{Name: spec.CAName},
+				Attributes: configv1.LDAPAttributeMapping{
+					ID:                []string{"dn"},
+					PreferredUsername: []string{usernameAttribute},
+					Name:              []string{"cn"},
+					Email:             []string{"mail"},
+				},
+			},
+		},
+	}
+}
+
+// NewGitHubIdentityProvider returns a GitHub identity provider
+func NewGitHubIdentityProvider(name string, spec *workshopv1.GitHubProviderSpec) configv1.IdentityProvider {
+	return configv1.IdentityProvider{
+		Name:          name,
+		MappingMethod: configv1.MappingMethodClaim,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type: configv1.IdentityProviderTypeGitHub,
+			GitHub: &configv1.GitHubIdentityProvider{
+				ClientID:      spec.ClientID,
+				ClientSecret:  configv1.SecretNameReference{Name: spec.ClientSecretName},
+				Organizations: spec.Organizations,
+				Teams:         spec.Teams,
+				Hostname:      spec.Hostname,
+				CA:            configv1.ConfigMapNameReference{Name: spec.CAName},
+			},
+		},
+	}
+}
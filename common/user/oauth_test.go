@@ -0,0 +1,150 @@
+package user
+
+import (
+	"reflect"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func providerNames(oauth *configv1.OAuth) []string {
+	names := []string{}
+	for _, provider := range oauth.Spec.IdentityProviders {
+		names = append(names, provider.Name)
+	}
+	return names
+}
+
+func newOAuth(providers ...configv1.IdentityProvider) *configv1.OAuth {
+	return &configv1.OAuth{Spec: configv1.OAuthSpec{IdentityProviders: providers}}
+}
+
+func TestMergeIdentityProvider(t *testing.T) {
+	workshop := NewHTPasswdIdentityProvider("workshop", "workshop-htpasswd")
+	changed := NewHTPasswdIdentityProvider("workshop", "other-htpasswd")
+	cluster := NewHTPasswdIdentityProvider("cluster-admins", "admins-htpasswd")
+
+	tests := []struct {
+		name    string
+		oauth   *configv1.OAuth
+		changed bool
+		names   []string
+	}{
+		{
+			name:    "added to an empty config",
+			oauth:   newOAuth(),
+			changed: true,
+			names:   []string{"workshop"},
+		},
+		{
+			name:    "appended after the providers of the cluster",
+			oauth:   newOAuth(cluster),
+			changed: true,
+			names:   []string{"cluster-admins", "workshop"},
+		},
+		{
+			name:    "unchanged provider",
+			oauth:   newOAuth(cluster, workshop),
+			changed: false,
+			names:   []string{"cluster-admins", "workshop"},
+		},
+		{
+			name:    "changed provider is replaced in place",
+			oauth:   newOAuth(changed, cluster),
+			changed: true,
+			names:   []string{"workshop", "cluster-admins"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if changed := MergeIdentityProvider(tt.oauth, workshop); changed != tt.changed {
+				t.Errorf("MergeIdentityProvider() = %v, want %v", changed, tt.changed)
+			}
+			if names := providerNames(tt.oauth); !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("identity providers = %v, want %v", names, tt.names)
+			}
+			for _, provider := range tt.oauth.Spec.IdentityProviders {
+				if provider.Name == workshop.Name && !reflect.DeepEqual(provider, workshop) {
+					t.Errorf("identity provider = %+v, want %+v", provider, workshop)
+				}
+			}
+		})
+	}
+}
+
+func TestHasOtherIdentityProvider(t *testing.T) {
+	workshop := NewHTPasswdIdentityProvider("github", "workshop-htpasswd")
+	cluster := NewHTPasswdIdentityProvider("github", "admins-htpasswd")
+
+	tests := []struct {
+		name  string
+		oauth *configv1.OAuth
+		other bool
+	}{
+		{name: "no provider of the name", oauth: newOAuth(NewHTPasswdIdentityProvider("cluster-admins", "admins-htpasswd")), other: false},
+		{name: "the same provider", oauth: newOAuth(workshop), other: false},
+		{name: "a different provider of the name", oauth: newOAuth(cluster), other: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if other := HasOtherIdentityProvider(tt.oauth, workshop); other != tt.other {
+				t.Errorf("HasOtherIdentityProvider() = %v, want %v", other, tt.other)
+			}
+		})
+	}
+}
+
+func TestRemoveIdentityProvider(t *testing.T) {
+	htpasswd := NewHTPasswdIdentityProvider("workshop-htpass", "workshop-htpasswd")
+	previous := NewHTPasswdIdentityProvider("workshop-github", "workshop-htpasswd")
+	cluster := NewHTPasswdIdentityProvider("cluster-admins", "admins-htpasswd")
+
+	tests := []struct {
+		name    string
+		oauth   *configv1.OAuth
+		remove  []string
+		changed bool
+		names   []string
+	}{
+		{
+			name:    "nothing to remove",
+			oauth:   newOAuth(cluster),
+			remove:  []string{"workshop-htpass"},
+			changed: false,
+			names:   []string{"cluster-admins"},
+		},
+		{
+			name:    "one provider",
+			oauth:   newOAuth(cluster, htpasswd),
+			remove:  []string{"workshop-htpass"},
+			changed: true,
+			names:   []string{"cluster-admins"},
+		},
+		{
+			name:    "every provider of the workshop",
+			oauth:   newOAuth(htpasswd, cluster, previous),
+			remove:  []string{"workshop-htpass", "workshop-github", "workshop-ldap"},
+			changed: true,
+			names:   []string{"cluster-admins"},
+		},
+		{
+			name:    "no names",
+			oauth:   newOAuth(cluster, htpasswd),
+			changed: false,
+			names:   []string{"cluster-admins", "workshop-htpass"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if changed := RemoveIdentityProvider(tt.oauth, tt.remove...); changed != tt.changed {
+				t.Errorf("RemoveIdentityProvider() = %v, want %v", changed, tt.changed)
+			}
+			if names := providerNames(tt.oauth); !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("identity providers = %v, want %v", names, tt.names)
+			}
+		})
+	}
+}
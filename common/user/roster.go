@@ -0,0 +1,138 @@
+package user
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// MAX_ATTENDEE_NAME_LENGTH leaves room for the suffixes of the per-user resources, e.g. -credentials
+const MAX_ATTENDEE_NAME_LENGTH = 40
+
+// rosterColumns are the columns of a CSV roster without a header
+var rosterColumns = []string{"name", "email", "displayName", "role"}
+
+// RosterFormat returns the format of a roster key, csv for keys ending in .csv and yaml otherwise
+func RosterFormat(source *workshopv1.RosterSourceSpec) string {
+	if source.Format != "" {
+		return source.Format
+	}
+	if strings.HasSuffix(strings.ToLower(source.Key), ".csv") {
+		return workshopv1.RosterFormatCSV
+	}
+	return workshopv1.RosterFormatYAML
+}
+
+// ParseRoster returns the attendees of a CSV or YAML roster
+func ParseRoster(data []byte, format string) ([]workshopv1.Attendee, error) {
+	switch format {
+	case workshopv1.RosterFormatCSV:
+		return parseCSVRoster(data)
+	case workshopv1.RosterFormatYAML:
+		return parseYAMLRoster(data)
+	}
+	return nil, fmt.Errorf("unknown roster format %q", format)
+}
+
+// parseCSVRoster reads one attendee per line. A header naming the columns is optional,
+// without one the columns are name, email, displayName and role.
+func parseCSVRoster(data []byte) ([]workshopv1.Attendee, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	attendees := []workshopv1.Attendee{}
+	columns := rosterColumns
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if line == 1 && isRosterHeader(record) {
+			columns = record
+			continue
+		}
+
+		attendee := workshopv1.Attendee{}
+		for i, value := range record {
+			if i >= len(columns) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			switch strings.ToLower(strings.TrimSpace(columns[i])) {
+			case "name":
+				attendee.Name = value
+			case "email":
+				attendee.Email = value
+			case "displayname":
+				attendee.DisplayName = value
+			case "role":
+				attendee.Role = value
+			}
+		}
+		if attendee.Name == "" {
+			continue
+		}
+		attendees = append(attendees, attendee)
+	}
+	return attendees, nil
+}
+
+// isRosterHeader returns true if the record names the columns of the roster
+func isRosterHeader(record []string) bool {
+	for _, column := range record {
+		if strings.EqualFold(strings.TrimSpace(column), "name") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseYAMLRoster reads a list of attendees, or an object with an attendees list
+func parseYAMLRoster(data []byte) ([]workshopv1.Attendee, error) {
+	attendees := []workshopv1.Attendee{}
+	if err := yaml.Unmarshal(data, &attendees); err == nil {
+		return attendees, nil
+	}
+	roster := workshopv1.RosterSpec{}
+	if err := yaml.Unmarshal(data, &roster); err != nil {
+		return nil, err
+	}
+	return roster.Attendees, nil
+}
+
+// ValidateAttendee returns an error if the name of the attendee cannot be used in the names of their resources
+func ValidateAttendee(attendee workshopv1.Attendee) error {
+	if errs := validation.IsDNS1123Label(attendee.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid attendee name %q: %s", attendee.Name, strings.Join(errs, ", "))
+	}
+	if len(attendee.Name) > MAX_ATTENDEE_NAME_LENGTH {
+		return fmt.Errorf("invalid attendee name %q: must be no more than %d characters", attendee.Name, MAX_ATTENDEE_NAME_LENGTH)
+	}
+	return nil
+}
+
+// Email returns the email of the attendee, or a placeholder for attendees without one
+func Email(attendee workshopv1.Attendee) string {
+	if attendee.Email != "" {
+		return attendee.Email
+	}
+	return attendee.Name + "@none.com"
+}
+
+// DisplayName returns the display name of the attendee, or their user name
+func DisplayName(attendee workshopv1.Attendee) string {
+	if attendee.DisplayName != "" {
+		return attendee.DisplayName
+	}
+	return attendee.Name
+}
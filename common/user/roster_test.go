@@ -0,0 +1,149 @@
+package user
+
+import (
+	"reflect"
+	"testing"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+)
+
+func TestParseRoster(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		format    string
+		attendees []workshopv1.Attendee
+		err       bool
+	}{
+		{
+			name:      "empty CSV",
+			data:      "",
+			format:    workshopv1.RosterFormatCSV,
+			attendees: []workshopv1.Attendee{},
+		},
+		{
+			name:   "CSV without a header",
+			data:   "alice,alice@example.com,Alice,instructor\nbob,bob@example.com\n",
+			format: workshopv1.RosterFormatCSV,
+			attendees: []workshopv1.Attendee{
+				{Name: "alice", Email: "alice@example.com", DisplayName: "Alice", Role: "instructor"},
+				{Name: "bob", Email: "bob@example.com"},
+			},
+		},
+		{
+			name:   "CSV with a header in another order",
+			data:   "Email, Name, Role\nalice@example.com, alice, instructor\n",
+			format: workshopv1.RosterFormatCSV,
+			attendees: []workshopv1.Attendee{
+				{Name: "alice", Email: "alice@example.com", Role: "instructor"},
+			},
+		},
+		{
+			name:   "CSV comments, rows without a name and extra columns are skipped",
+			data:   "# attendees\nalice,,,,extra\n,bob@example.com\n",
+			format: workshopv1.RosterFormatCSV,
+			attendees: []workshopv1.Attendee{
+				{Name: "alice"},
+			},
+		},
+		{
+			name:   "invalid CSV",
+			data:   "alice,\"unterminated\n",
+			format: workshopv1.RosterFormatCSV,
+			err:    true,
+		},
+		{
+			name:   "YAML list",
+			data:   "- name: alice\n  email: alice@example.com\n- name: bob\n  role: instructor\n",
+			format: workshopv1.RosterFormatYAML,
+			attendees: []workshopv1.Attendee{
+				{Name: "alice", Email: "alice@example.com"},
+				{Name: "bob", Role: "instructor"},
+			},
+		},
+		{
+			name:   "YAML object with attendees",
+			data:   "attendees:\n- name: alice\n  displayName: Alice\n",
+			format: workshopv1.RosterFormatYAML,
+			attendees: []workshopv1.Attendee{
+				{Name: "alice", DisplayName: "Alice"},
+			},
+		},
+		{
+			name:   "invalid YAML",
+			data:   "attendees: [",
+			format: workshopv1.RosterFormatYAML,
+			err:    true,
+		},
+		{
+			name:   "unknown format",
+			data:   "alice",
+			format: "json",
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attendees, err := ParseRoster([]byte(tt.data), tt.format)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("ParseRoster() = %v, want an error", attendees)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRoster() error = %v", err)
+			}
+			if !reflect.DeepEqual(attendees, tt.attendees) {
+				t.Errorf("ParseRoster() = %+v, want %+v", attendees, tt.attendees)
+			}
+		})
+	}
+}
+
+func TestRosterFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		source workshopv1.RosterSourceSpec
+		format string
+	}{
+		{name: "explicit format", source: workshopv1.RosterSourceSpec{Key: "roster.csv", Format: workshopv1.RosterFormatYAML}, format: workshopv1.RosterFormatYAML},
+		{name: "csv key", source: workshopv1.RosterSourceSpec{Key: "Roster.CSV"}, format: workshopv1.RosterFormatCSV},
+		{name: "yaml key", source: workshopv1.RosterSourceSpec{Key: "roster.yaml"}, format: workshopv1.RosterFormatYAML},
+		{name: "no extension", source: workshopv1.RosterSourceSpec{Key: "roster"}, format: workshopv1.RosterFormatYAML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if format := RosterFormat(&tt.source); format != tt.format {
+				t.Errorf("RosterFormat() = %s, want %s", format, tt.format)
+			}
+		})
+	}
+}
+
+func TestValidateAttendee(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		valid    bool
+	}{
+		{name: "generated name", username: "user1", valid: true},
+		{name: "dashes", username: "alice-smith", valid: true},
+		{name: "upper case", username: "Alice", valid: false},
+		{name: "dot", username: "alice.smith", valid: false},
+		{name: "empty", username: "", valid: false},
+		{name: "longest name", username: "a123456789012345678901234567890123456789", valid: true},
+		{name: "too long", username: "a1234567890123456789012345678901234567890", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAttendee(workshopv1.Attendee{Name: tt.username})
+			if valid := err == nil; valid != tt.valid {
+				t.Errorf("ValidateAttendee(%q) error = %v, want valid %v", tt.username, err, tt.valid)
+			}
+		})
+	}
+}
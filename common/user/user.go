@@ -3,6 +3,7 @@ package user
 import (
 	userv1 "github.com/openshift/api/user/v1"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
 	corev1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,14 +11,14 @@ import (
 )
 
 // NewUser create an user
-func NewUser(workshop *workshopv1.Workshop, scheme *runtime.Scheme, username string, labels map[string]string) *userv1.User {
+func NewUser(workshop *workshopv1.Workshop, scheme *runtime.Scheme, attendee workshopv1.Attendee, labels map[string]string) *userv1.User {
 
 	user := &userv1.User{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   username,
-			Labels: labels,
+			Name:   attendee.Name,
+			Labels: kubernetes.WorkshopLabels(workshop, labels),
 		},
-		FullName: username,
+		FullName: DisplayName(attendee),
 	}
 	return user
 }
@@ -30,6 +31,7 @@ func NewUserRoleBinding(workshop *workshopv1.Workshop, scheme *runtime.Scheme, u
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      username,
 			Namespace: namespace,
+			Labels:    kubernetes.WorkshopLabels(workshop, nil),
 		},
 		Subjects: []rbac.Subject{
 			{
@@ -53,22 +55,25 @@ func NewHTPasswdSecret(workshop *workshopv1.Workshop, scheme *runtime.Scheme, na
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
+			Labels:    kubernetes.WorkshopLabels(workshop, nil),
 		},
 		Type: "Opaque",
 		Data: map[string][]byte{
-			"htpasswd": htpasswds,
+			HTPASSWD_KEY: htpasswds,
 		},
 	}
 
 	return secret
 }
 
-// NewIdentity create an identity for user
-func NewIdentity(workshop *workshopv1.Workshop, scheme *runtime.Scheme, username string, identityName string, user *userv1.User) *userv1.Identity {
+// NewIdentity create an identity for user, carrying the email and display name of the attendee
+func NewIdentity(workshop *workshopv1.Workshop, scheme *runtime.Scheme, attendee workshopv1.Attendee, identityName string, user *userv1.User) *userv1.Identity {
+	username := attendee.Name
 
 	identity := &userv1.Identity{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: identityName + ":" + username,
+			Name:   identityName + ":" + username,
+			Labels: kubernetes.WorkshopLabels(workshop, nil),
 		},
 		ProviderName:     identityName,
 		ProviderUserName: username,
@@ -77,6 +82,15 @@ func NewIdentity(workshop *workshopv1.Workshop, scheme *runtime.Scheme, username
 			UID:  user.UID,
 		},
 	}
+	if attendee.Email != "" || attendee.DisplayName != "" {
+		identity.Extra = map[string]string{}
+		if attendee.Email != "" {
+			identity.Extra["email"] = attendee.Email
+		}
+		if attendee.DisplayName != "" {
+			identity.Extra["name"] = attendee.DisplayName
+		}
+	}
 	return identity
 }
 
@@ -0,0 +1,59 @@
+package util
+
+import (
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FindCondition returns the condition of the given type, or nil if it is not set
+func FindCondition(conditions []workshopv1.Condition, conditionType string) *workshopv1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates a condition, only bumping LastTransitionTime when the status changes
+func SetCondition(conditions *[]workshopv1.Condition, newCondition workshopv1.Condition) {
+	if conditions == nil {
+		return
+	}
+	existing := FindCondition(*conditions, newCondition.Type)
+	if existing == nil {
+		if newCondition.LastTransitionTime.IsZero() {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+		*conditions = append(*conditions, newCondition)
+		return
+	}
+
+	if existing.Status != newCondition.Status {
+		existing.Status = newCondition.Status
+		existing.LastTransitionTime = metav1.Now()
+	}
+	existing.Reason = newCondition.Reason
+	existing.Message = newCondition.Message
+	existing.ObservedGeneration = newCondition.ObservedGeneration
+}
+
+// RemoveCondition removes the condition of the given type
+func RemoveCondition(conditions *[]workshopv1.Condition, conditionType string) {
+	if conditions == nil {
+		return
+	}
+	filtered := []workshopv1.Condition{}
+	for _, condition := range *conditions {
+		if condition.Type != conditionType {
+			filtered = append(filtered, condition)
+		}
+	}
+	*conditions = filtered
+}
+
+// IsConditionTrue returns true if the condition of the given type has status True
+func IsConditionTrue(conditions []workshopv1.Condition, conditionType string) bool {
+	condition := FindCondition(conditions, conditionType)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+)
+
+// DEFAULT_USER_NAME_PREFIX is the prefix of generated user names when the workshop sets none
+const DEFAULT_USER_NAME_PREFIX = "user"
+
+// UserIdentity resolves the names of the generated users of a workshop, every component names users through it
+type UserIdentity struct {
+	// Prefix is the user name prefix, including the naming prefix of the workshop
+	Prefix string
+	// Digits is the number of digits user numbers are padded to with zeros, 0 to not pad them
+	Digits int
+}
+
+// NewUserIdentity returns the user identity resolver of a workshop
+func NewUserIdentity(workshop *workshopv1.Workshop) UserIdentity {
+	prefix := workshop.Spec.UserDetails.UserNamePrefix
+	if prefix == "" {
+		prefix = DEFAULT_USER_NAME_PREFIX
+	}
+	// User names end with their number, so only the naming prefix applies to them
+	if workshop.Spec.Naming.Prefix != "" {
+		prefix = workshop.Spec.Naming.Prefix + "-" + prefix
+	}
+	return UserIdentity{
+		Prefix: prefix,
+		Digits: workshop.Spec.UserDetails.UserNumberDigits,
+	}
+}
+
+// Name returns the name of the user with the given number
+func (u UserIdentity) Name(id int) string {
+	return fmt.Sprintf("%s%0*d", u.Prefix, u.Digits, id)
+}
+
+// ID returns the number of a generated user as written in their name, e.g. 01,
+// and false if the name was not generated from the prefix
+func (u UserIdentity) ID(username string) (string, bool) {
+	id := strings.TrimPrefix(username, u.Prefix)
+	if id == username || id == "" {
+		return "", false
+	}
+	if _, err := strconv.ParseUint(id, 10, 32); err != nil {
+		return "", false
+	}
+	return id, true
+}
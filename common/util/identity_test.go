@@ -0,0 +1,73 @@
+package util
+
+import (
+	"testing"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+)
+
+func newWorkshop(prefix string, userNamePrefix string, digits int) *workshopv1.Workshop {
+	workshop := &workshopv1.Workshop{}
+	workshop.Spec.Naming.Prefix = prefix
+	workshop.Spec.UserDetails.UserNamePrefix = userNamePrefix
+	workshop.Spec.UserDetails.UserNumberDigits = digits
+	return workshop
+}
+
+func TestUserIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		workshop *workshopv1.Workshop
+		id       int
+		username string
+	}{
+		{name: "default prefix", workshop: newWorkshop("", "", 0), id: 1, username: "user1"},
+		{name: "user name prefix", workshop: newWorkshop("", "attendee", 0), id: 12, username: "attendee12"},
+		{name: "padded numbers", workshop: newWorkshop("", "", 2), id: 3, username: "user03"},
+		{name: "numbers longer than the padding", workshop: newWorkshop("", "", 2), id: 100, username: "user100"},
+		{name: "naming prefix", workshop: newWorkshop("kcd", "", 0), id: 1, username: "kcd-user1"},
+		{name: "naming and user name prefix", workshop: newWorkshop("kcd", "dev", 3), id: 7, username: "kcd-dev007"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := NewUserIdentity(tt.workshop)
+			username := identity.Name(tt.id)
+			if username != tt.username {
+				t.Fatalf("Name(%d) = %s, want %s", tt.id, username, tt.username)
+			}
+			// The number of a generated user is read back as written in the name
+			id, ok := identity.ID(username)
+			if !ok || id != username[len(identity.Prefix):] {
+				t.Errorf("ID(%s) = %s, %v, want the number of the name", username, id, ok)
+			}
+		})
+	}
+}
+
+func TestUserIdentityID(t *testing.T) {
+	identity := UserIdentity{Prefix: "user", Digits: 2}
+
+	tests := []struct {
+		username string
+		id       string
+		ok       bool
+	}{
+		{username: "user01", id: "01", ok: true},
+		{username: "user7", id: "7", ok: true},
+		{username: "user", ok: false},
+		{username: "alice", ok: false},
+		{username: "user-01", ok: false},
+		{username: "user01a", ok: false},
+		{username: "superuser01", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.username, func(t *testing.T) {
+			id, ok := identity.ID(tt.username)
+			if id != tt.id || ok != tt.ok {
+				t.Errorf("ID(%s) = %q, %v, want %q, %v", tt.username, id, ok, tt.id, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+)
+
+// ScopedName returns name with the naming prefix and suffix of the workshop.
+// Namespaces, cluster-scoped objects, user names and shared secrets use it so workshops on one cluster do not collide.
+func ScopedName(workshop *workshopv1.Workshop, name string) string {
+	if workshop.Spec.Naming.Prefix != "" {
+		name = workshop.Spec.Naming.Prefix + "-" + name
+	}
+	if workshop.Spec.Naming.Suffix != "" {
+		name = name + "-" + workshop.Spec.Naming.Suffix
+	}
+	return name
+}
+
+// UserNamePrefix returns the prefix of the generated user names of the workshop
+func UserNamePrefix(workshop *workshopv1.Workshop) string {
+	return NewUserIdentity(workshop).Prefix
+}
+
+// UserName returns the name of the user with the given id
+func UserName(workshop *workshopv1.Workshop, id int) string {
+	return NewUserIdentity(workshop).Name(id)
+}
+
+// UserID returns what tells a user apart in the names of their resources,
+// the number of a generated user or the whole name of a roster attendee
+func UserID(workshop *workshopv1.Workshop, username string) string {
+	if id, ok := NewUserIdentity(workshop).ID(username); ok {
+		return id
+	}
+	return username
+}
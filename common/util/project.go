@@ -0,0 +1,100 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// UserProject is a project generated for a user from a project template
+type UserProject struct {
+	Name        string
+	DisplayName string
+	Template    workshopv1.ProjectTemplateSpec
+}
+
+// projectTemplateData is what the name and display name patterns of a project template can refer to
+type projectTemplateData struct {
+	UserName string
+	UserID   string
+}
+
+// ProjectTemplates returns the project templates of the workshop, or a template for the staging project if none is set
+func ProjectTemplates(workshop *workshopv1.Workshop) []workshopv1.ProjectTemplateSpec {
+	project := workshop.Spec.Infrastructure.Project
+	if len(project.Templates) > 0 {
+		return project.Templates
+	}
+	if project.StagingName == "" {
+		return nil
+	}
+	// The staging project is named after the user number, or the whole name of a roster attendee
+	return []workshopv1.ProjectTemplateSpec{{
+		Name: project.StagingName + `{{if eq .UserID .UserName}}-{{end}}{{.UserID}}`,
+	}}
+}
+
+// UserProjects returns the projects of a user, in the order of the project templates
+func UserProjects(workshop *workshopv1.Workshop, username string) ([]UserProject, error) {
+	data := projectTemplateData{
+		UserName: username,
+		UserID:   UserID(workshop, username),
+	}
+
+	projects := []UserProject{}
+	for _, projectTemplate := range ProjectTemplates(workshop) {
+		name, err := renderProjectPattern(projectTemplate.Name, data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project name %q: %s", projectTemplate.Name, err)
+		}
+		name = ScopedName(workshop, name)
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid project name %q of %s: %s", name, username, strings.Join(errs, ", "))
+		}
+
+		displayName, err := renderProjectPattern(projectTemplate.DisplayName, data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project display name %q: %s", projectTemplate.DisplayName, err)
+		}
+
+		projects = append(projects, UserProject{
+			Name:        name,
+			DisplayName: displayName,
+			Template:    projectTemplate,
+		})
+	}
+	return projects, nil
+}
+
+// UserProjectNames returns the names of the projects of a user
+func UserProjectNames(workshop *workshopv1.Workshop, username string) ([]string, error) {
+	projects, err := UserProjects(workshop, username)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, project := range projects {
+		names = append(names, project.Name)
+	}
+	return names, nil
+}
+
+// renderProjectPattern executes a name or display name pattern of a project template
+func renderProjectPattern(pattern string, data projectTemplateData) (string, error) {
+	if pattern == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("project").Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return "", err
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(rendered.String()), nil
+}
@@ -0,0 +1,112 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+)
+
+func TestUserProjects(t *testing.T) {
+	tests := []struct {
+		name         string
+		naming       workshopv1.NamingSpec
+		project      workshopv1.ProjectSpec
+		username     string
+		names        []string
+		displayNames []string
+		err          bool
+	}{
+		{
+			name:     "no project",
+			project:  workshopv1.ProjectSpec{Enabled: true},
+			username: "user1",
+			names:    []string{},
+		},
+		{
+			name:         "staging project of a generated user",
+			project:      workshopv1.ProjectSpec{StagingName: "staging"},
+			username:     "user1",
+			names:        []string{"staging1"},
+			displayNames: []string{""},
+		},
+		{
+			name:         "staging project of a roster attendee",
+			project:      workshopv1.ProjectSpec{StagingName: "staging"},
+			username:     "alice",
+			names:        []string{"staging-alice"},
+			displayNames: []string{""},
+		},
+		{
+			name: "templates replace the staging project",
+			project: workshopv1.ProjectSpec{
+				StagingName: "staging",
+				Templates: []workshopv1.ProjectTemplateSpec{
+					{Name: "{{.UserName}}-dev", DisplayName: "Development of {{.UserName}}"},
+					{Name: "{{.UserName}}-prod"},
+				},
+			},
+			username:     "user2",
+			names:        []string{"user2-dev", "user2-prod"},
+			displayNames: []string{"Development of user2", ""},
+		},
+		{
+			name:   "names are scoped",
+			naming: workshopv1.NamingSpec{Prefix: "kcd", Suffix: "eu"},
+			project: workshopv1.ProjectSpec{
+				Templates: []workshopv1.ProjectTemplateSpec{{Name: "project{{.UserID}}"}},
+			},
+			username:     "kcd-user3",
+			names:        []string{"kcd-project3-eu"},
+			displayNames: []string{""},
+		},
+		{
+			name: "unknown template field",
+			project: workshopv1.ProjectSpec{
+				Templates: []workshopv1.ProjectTemplateSpec{{Name: "{{.Email}}"}},
+			},
+			username: "user1",
+			err:      true,
+		},
+		{
+			name: "invalid project name",
+			project: workshopv1.ProjectSpec{
+				Templates: []workshopv1.ProjectTemplateSpec{{Name: "{{.UserName}}_dev"}},
+			},
+			username: "user1",
+			err:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workshop := &workshopv1.Workshop{}
+			workshop.Spec.Naming = tt.naming
+			workshop.Spec.Infrastructure.Project = tt.project
+
+			projects, err := UserProjects(workshop, tt.username)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("UserProjects() = %+v, want an error", projects)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UserProjects() error = %v", err)
+			}
+
+			names := []string{}
+			displayNames := []string{}
+			for _, project := range projects {
+				names = append(names, project.Name)
+				displayNames = append(displayNames, project.DisplayName)
+			}
+			if !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("UserProjects() names = %v, want %v", names, tt.names)
+			}
+			if len(tt.displayNames) > 0 && !reflect.DeepEqual(displayNames, tt.displayNames) {
+				t.Errorf("UserProjects() display names = %v, want %v", displayNames, tt.displayNames)
+			}
+		})
+	}
+}
@@ -5,11 +5,50 @@ var OperatorStatus = struct {
 	Scheduled    string
 	InProgress   string
 	Installed    string
+	Failed       string
+	Waiting      string
+	Removing     string
+	Unsupported  string
 }{
 	NotScheduled: "NOT SCHEDULED",
 	Scheduled:    "SCHEDULED",
 	InProgress:   "IN PROGRESS",
 	Installed:    "INSTALLED",
+	Failed:       "FAILED",
+	Waiting:      "WAITING",
+	Removing:     "REMOVING",
+	Unsupported:  "UNSUPPORTED",
+}
+
+// ConditionReason holds the reasons set on workshop conditions
+var ConditionReason = struct {
+	NotScheduled string
+	InProgress   string
+	Installed    string
+	Failed       string
+	Waiting      string
+	Removing     string
+	Removed      string
+	Planned      string
+	Resolved     string
+	Unresolved   string
+	Unsupported  string
+	Unique       string
+	Conflict     string
+}{
+	NotScheduled: "NotScheduled",
+	InProgress:   "InProgress",
+	Installed:    "Installed",
+	Failed:       "Failed",
+	Waiting:      "WaitingForDependencies",
+	Removing:     "Removing",
+	Removed:      "Removed",
+	Planned:      "Planned",
+	Resolved:     "Resolved",
+	Unresolved:   "Unresolved",
+	Unsupported:  "Unsupported",
+	Unique:       "Unique",
+	Conflict:     "Conflict",
 }
 
 func IsScheduled(enabled bool) string {
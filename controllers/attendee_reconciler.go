@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	"github.com/stakater/workshop-operator/common/portal"
+	"github.com/stakater/workshop-operator/common/util"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var attendeeLabels = map[string]string{
+	"app.kubernetes.io/part-of": "attendees",
+}
+
+// reconcileAttendees records a WorkshopAttendee per provisioned user, which the portal and instructors assign to attendees.
+// The operator only owns the workshop and user name, the assigned email is left to the portal and instructors.
+func (r *WorkshopReconciler) reconcileAttendees(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
+	users := make(map[string]bool)
+	for _, attendee := range attendees {
+		users[attendee.Name] = true
+
+		workshopAttendee, err := r.newWorkshopAttendee(workshop, attendee.Name)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if op, err := kubernetes.Apply(r, r.Scheme, workshopAttendee); err != nil {
+			return reconcile.Result{}, err
+		} else if op != controllerutil.OperationResultNone {
+			log.Infof("Applied %s Workshop Attendee", workshopAttendee.Name)
+		}
+	}
+
+	// Delete the WorkshopAttendees of users that are no longer provisioned
+	listAttendees, err := r.createdWorkshopAttendeeList(workshop)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range listAttendees.Items {
+		workshopAttendee := &listAttendees.Items[i]
+		if users[workshopAttendee.Spec.UserName] {
+			continue
+		}
+		if err := kubernetes.DeleteIfExists(r, workshopAttendee); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s Workshop Attendee", workshopAttendee.Name)
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// delete WorkshopAttendees
+func (r *WorkshopReconciler) deleteAttendees(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	listAttendees, err := r.createdWorkshopAttendeeList(workshop)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range listAttendees.Items {
+		if err := kubernetes.DeleteIfExists(r, &listAttendees.Items[i]); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s Workshop Attendee", listAttendees.Items[i].Name)
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// newWorkshopAttendee returns the WorkshopAttendee of a user, without the email so applying it never releases the user
+func (r *WorkshopReconciler) newWorkshopAttendee(workshop *workshopv1.Workshop, username string) (*workshopv1.WorkshopAttendee, error) {
+	userLabels := kubernetes.WorkshopLabels(workshop, attendeeLabels)
+	userLabels[kubernetes.WORKSHOP_USER_LABEL] = username
+
+	workshopAttendee := &workshopv1.WorkshopAttendee{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      portal.AttendeeName(workshop.Name, username),
+			Namespace: workshop.Namespace,
+			Labels:    userLabels,
+		},
+		Spec: workshopv1.WorkshopAttendeeSpec{
+			Workshop: workshop.Name,
+			UserName: username,
+		},
+	}
+	if err := controllerutil.SetControllerReference(workshop, workshopAttendee, r.Scheme); err != nil {
+		return nil, err
+	}
+	return workshopAttendee, nil
+}
+
+// createdWorkshopAttendeeList returns the WorkshopAttendees of the workshop
+func (r *WorkshopReconciler) createdWorkshopAttendeeList(workshop *workshopv1.Workshop) (*workshopv1.WorkshopAttendeeList, error) {
+	labelSelector := labels.SelectorFromSet(kubernetes.WorkshopLabels(workshop, attendeeLabels))
+	listAttendees := &workshopv1.WorkshopAttendeeList{}
+	listOps := &client.ListOptions{
+		Namespace:     workshop.Namespace,
+		LabelSelector: labelSelector,
+	}
+	if err := r.List(context.TODO(), listAttendees, listOps); err != nil {
+		log.Errorf("Failed to get list of Workshop Attendees, filtered by labelSelector {%s}, {%s}", labelSelector, err)
+		return nil, err
+	}
+	return listAttendees, nil
+}
+
+// updateAttendeeStatus records the claims of the portal and instructors and the readiness of the components in the
+// status of every WorkshopAttendee. Failures are only logged, they must not fail the workshop.
+func (r *WorkshopReconciler) updateAttendeeStatus(workshop *workshopv1.Workshop) {
+	listAttendees, err := r.createdWorkshopAttendeeList(workshop)
+	if err != nil {
+		return
+	}
+
+	// Components that are not scheduled are of no interest to attendees
+	var conditions []workshopv1.Condition
+	for _, condition := range workshop.Status.Conditions {
+		if condition.Type == workshopv1.ConditionReady || !strings.HasSuffix(condition.Type, CONDITION_TYPE_SUFFIX) ||
+			condition.Reason == util.ConditionReason.NotScheduled || condition.Reason == util.ConditionReason.Removed {
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+
+	for i := range listAttendees.Items {
+		workshopAttendee := &listAttendees.Items[i]
+		status := workshopAttendee.Status.DeepCopy()
+		// The portal records claims itself, this covers the users instructors assign or release
+		if status.Email != workshopAttendee.Spec.Email {
+			status.Email = workshopAttendee.Spec.Email
+			status.ClaimedAt = nil
+			status.LastLogin = nil
+			if status.Email != "" {
+				now := metav1.Now()
+				status.ClaimedAt = &now
+			}
+		}
+		status.Conditions = conditions
+
+		if equality.Semantic.DeepEqual(status, &workshopAttendee.Status) {
+			continue
+		}
+		workshopAttendee.Status = *status
+		if err := r.Status().Update(context.TODO(), workshopAttendee); err != nil {
+			log.Errorf("Failed to update %s Workshop Attendee status: %s", workshopAttendee.Name, err)
+		}
+	}
+}
@@ -1,20 +1,14 @@
 package controllers
 
 import (
-	"context"
-	"fmt"
-	"reflect"
-	"strconv"
-
 	"github.com/prometheus/common/log"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
 	"github.com/stakater/workshop-operator/common/bookbag"
 	"github.com/stakater/workshop-operator/common/kubernetes"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
 
 	"github.com/stakater/workshop-operator/common/util"
-	appsv1 "k8s.io/api/apps/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -32,203 +26,211 @@ var bookbagConfigData = map[string]string{
 }
 
 // Reconciling Bookbag
-func (r *WorkshopReconciler) reconcileBookbag(workshop *workshopv1.Workshop, users int,
+func (r *WorkshopReconciler) reconcileBookbag(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, removed []string,
 	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
 	enabled := workshop.Spec.Infrastructure.Guide.Bookbag.Enabled
-	id := 1
-	for {
-		if id <= users && enabled {
 
-			if result, err := r.addUpdateBookbag(workshop, strconv.Itoa(id),
+	if enabled {
+		for _, attendee := range attendees {
+			if result, err := r.addUpdateBookbag(workshop, attendee.Name,
 				appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
 				return result, err
 			}
-		} else {
-
-			bookbagName := fmt.Sprintf("bookbag-%d", id)
-
-			depFound := &appsv1.Deployment{}
-			depErr := r.Get(context.TODO(), types.NamespacedName{Name: bookbagName, Namespace: BOOKBAG_NAMESPACE_NAME}, depFound)
+		}
+	}
 
-			if depErr != nil && errors.IsNotFound(depErr) {
-				break
-			}
+	// Delete the bookbags of the attendees removed from the roster
+	for _, username := range removed {
+		if result, err := r.deleteUserBookbag(workshop, username, appsHostnameSuffix); util.IsRequeued(result, err) {
+			return result, err
 		}
-		id++
 	}
 
 	//Success
 	return reconcile.Result{}, nil
 }
 
-func (r *WorkshopReconciler) addUpdateBookbag(workshop *workshopv1.Workshop, userID string,
+func (r *WorkshopReconciler) addUpdateBookbag(workshop *workshopv1.Workshop, username string,
 	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
 
 	// Create Namespace
-	namespace := kubernetes.NewNamespace(workshop, r.Scheme, BOOKBAG_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), namespace); err != nil && !errors.IsAlreadyExists(err) {
+	namespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, namespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Project", namespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Project", namespace.Name)
 	}
 
-	bookbagName := fmt.Sprintf("user%s-bookbag", userID)
+	bookbagName := userBookbagName(username)
 	labels := map[string]string{
 		"app":                       bookbagName,
 		"app.kubernetes.io/part-of": "bookbag",
 	}
 
 	// Create ConfigMap
-	envConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, bookbagName+"-env", BOOKBAG_NAMESPACE_NAME, labels, bookbagConfigData)
-	if err := r.Create(context.TODO(), envConfigMap); err != nil && !errors.IsAlreadyExists(err) {
+	envConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, bookbagName+"-env", util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, bookbagConfigData)
+	if op, err := kubernetes.Apply(r, r.Scheme, envConfigMap); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s ConfigMap", envConfigMap.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s ConfigMap", envConfigMap.Name)
 	}
 
 	// Create ConfigMap
-	varConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, bookbagName+"-vars", BOOKBAG_NAMESPACE_NAME, labels, nil)
-	if err := r.Create(context.TODO(), varConfigMap); err != nil && !errors.IsAlreadyExists(err) {
+	varConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, bookbagName+"-vars", util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, nil)
+	if op, err := kubernetes.Apply(r, r.Scheme, varConfigMap); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s ConfigMap", varConfigMap.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s ConfigMap", varConfigMap.Name)
 	}
 
 	// Create Service Account
-	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels)
-	if err := r.Create(context.TODO(), serviceAccount); err != nil && !errors.IsAlreadyExists(err) {
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels)
+	if op, err := kubernetes.Apply(r, r.Scheme, serviceAccount); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Service Account", serviceAccount.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Service Account", serviceAccount.Name)
 	}
 
 	// Create Role Binding
-	roleBinding := kubernetes.NewRoleBindingSA(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels,
+	roleBinding := kubernetes.NewRoleBindingSA(workshop, r.Scheme, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels,
 		serviceAccount.Name, BOOKBAG_ROLE_BINDING_NAME, BOOKBAG_ROLE_KIND_NAME)
-	if err := r.Create(context.TODO(), roleBinding); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, roleBinding); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role Binding", roleBinding.Name)
+	}
+
+	// Create Credentials Secret, pods only read Secrets of their own namespace
+	password, err := r.userPassword(workshop, username)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	credentialsSecret := openshiftuser.NewCredentialsSecret(workshop, r.Scheme, credentialsSecretName(username), util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, username, password)
+	if op, err := kubernetes.Apply(r, r.Scheme, credentialsSecret); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Role Binding", roleBinding.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Secret", credentialsSecret.Name)
 	}
 
 	// Deploy/Update Bookbag
-	dep := bookbag.NewDeployment(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels, userID, appsHostnameSuffix, openshiftConsoleURL)
-	if err := r.Create(context.TODO(), dep); err != nil && !errors.IsAlreadyExists(err) {
-		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Deployment", dep.Name)
-	} else if errors.IsAlreadyExists(err) {
-		deploymentFound := &appsv1.Deployment{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: dep.Name, Namespace: BOOKBAG_NAMESPACE_NAME}, deploymentFound); err != nil {
-			return reconcile.Result{}, err
-		} else if err == nil {
-			if !reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].Env, deploymentFound.Spec.Template.Spec.Containers[0].Env) {
-				// Update Guide
-				if err := r.Update(context.TODO(), dep); err != nil {
-					return reconcile.Result{}, err
-				}
-				log.Infof("Updated %s Deployment", dep.Name)
-			}
-		}
+	dep := bookbag.NewDeployment(workshop, r.Scheme, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, username, credentialsSecret.Name, appsHostnameSuffix, openshiftConsoleURL)
+	if op, err := kubernetes.Apply(r, r.Scheme, dep); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Deployment", dep.Name)
 	}
 
 	// Create Service
-	service := kubernetes.NewService(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels, []string{"http"}, []int32{BOOKBAG_PORT})
-	if err := r.Create(context.TODO(), service); err != nil && !errors.IsAlreadyExists(err) {
+	service := kubernetes.NewService(workshop, r.Scheme, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, []string{"http"}, []int32{BOOKBAG_PORT})
+	if op, err := kubernetes.Apply(r, r.Scheme, service); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Service", service.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Service", service.Name)
 	}
 
 	// Create Route
-	route := kubernetes.NewRoute(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels, bookbagName, BOOKBAG_PORT)
-	if err := r.Create(context.TODO(), route); err != nil && !errors.IsAlreadyExists(err) {
+	route := r.newRoute(workshop, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, bookbagName, BOOKBAG_PORT, appsHostnameSuffix)
+	if op, err := kubernetes.Apply(r, r.Scheme, route); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Route", route.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Route", bookbagName)
 	}
 
 	//Success
 	return reconcile.Result{}, nil
 }
 
-func (r *WorkshopReconciler) deleteBookbag(workshop *workshopv1.Workshop, userID int, appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
+func (r *WorkshopReconciler) deleteBookbag(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
 
-	id := 1
-	for {
-		bookbagName := fmt.Sprintf("user%s-bookbag", strconv.Itoa(id))
-		labels := map[string]string{
-			"app":                       bookbagName,
-			"app.kubernetes.io/part-of": "bookbag",
+	for _, attendee := range attendees {
+		if result, err := r.deleteUserBookbag(workshop, attendee.Name, appsHostnameSuffix); util.IsRequeued(result, err) {
+			return result, err
 		}
+	}
 
-		route := kubernetes.NewRoute(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels, bookbagName, BOOKBAG_PORT)
-		// Delete route
-		if err := r.Delete(context.TODO(), route); err != nil {
-			return reconcile.Result{}, err
-		}
-		log.Infof("Deleted %s Route", route.Name)
+	namespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME))
+	// delete namespace
+	if err := kubernetes.DeleteIfExists(r, namespace); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s namespace", namespace.Name)
 
-		service := kubernetes.NewService(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels, []string{"http"}, []int32{BOOKBAG_PORT})
-		// Delete Service
-		if err := r.Delete(context.TODO(), service); err != nil {
-			return reconcile.Result{}, err
-		}
-		log.Infof("Deleted %s Service", service.Name)
+	return reconcile.Result{}, nil
+	//Success
 
-		dep := bookbag.NewDeployment(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels, strconv.Itoa(userID), appsHostnameSuffix, openshiftConsoleURL)
-		// Delete Deployment
-		if err := r.Delete(context.TODO(), dep); err != nil {
-			return reconcile.Result{}, err
-		}
-		log.Infof("Deleted %s Deployment", dep.Name)
+}
+
+// deleteUserBookbag deletes the bookbag of an attendee
+func (r *WorkshopReconciler) deleteUserBookbag(workshop *workshopv1.Workshop, username string, appsHostnameSuffix string) (reconcile.Result, error) {
+	bookbagName := userBookbagName(username)
+	labels := map[string]string{
+		"app":                       bookbagName,
+		"app.kubernetes.io/part-of": "bookbag",
+	}
 
-		serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels)
+	route := r.newRoute(workshop, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, bookbagName, BOOKBAG_PORT, appsHostnameSuffix)
+	// Delete route
+	if err := kubernetes.DeleteIfExists(r, route); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Route", bookbagName)
 
-		roleBinding := kubernetes.NewRoleBindingSA(workshop, r.Scheme, bookbagName, BOOKBAG_NAMESPACE_NAME, labels,
-			serviceAccount.Name, BOOKBAG_ROLE_BINDING_NAME, BOOKBAG_ROLE_KIND_NAME)
-		//Delete  Role Binding
-		if err := r.Delete(context.TODO(), roleBinding); err != nil {
-			return reconcile.Result{}, err
-		}
-		log.Infof("Deleted %s RoleBinding", roleBinding.Name)
+	service := kubernetes.NewService(workshop, r.Scheme, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, []string{"http"}, []int32{BOOKBAG_PORT})
+	// Delete Service
+	if err := kubernetes.DeleteIfExists(r, service); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Service", service.Name)
 
-		// Delete  Service Account
-		if err := r.Delete(context.TODO(), serviceAccount); err != nil {
-			return reconcile.Result{}, err
-		}
-		log.Infof("Deleted %s Service Account", serviceAccount.Name)
+	dep := bookbag.NewDeployment(workshop, r.Scheme, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, username, credentialsSecretName(username), appsHostnameSuffix, "")
+	// Delete Deployment
+	if err := kubernetes.DeleteIfExists(r, dep); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Deployment", dep.Name)
 
-		varConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, bookbagName+"-vars", BOOKBAG_NAMESPACE_NAME, labels, nil)
-		// Delete ConfigMap
-		if err := r.Delete(context.TODO(), varConfigMap); err != nil {
-			return reconcile.Result{}, err
-		}
-		log.Infof("Deleted %s ConfigMap", varConfigMap.Name)
+	credentialsSecret := openshiftuser.NewCredentialsSecret(workshop, r.Scheme, credentialsSecretName(username), util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, username, "")
+	// Delete Credentials Secret
+	if err := kubernetes.DeleteIfExists(r, credentialsSecret); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Secret", credentialsSecret.Name)
 
-		envConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, bookbagName+"-env", BOOKBAG_NAMESPACE_NAME, labels, bookbagConfigData)
-		// Delete ConfigMap
-		if err := r.Delete(context.TODO(), envConfigMap); err != nil {
-			return reconcile.Result{}, err
-		}
-		log.Infof("Deleted %s ConfigMap", envConfigMap.Name)
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels)
 
-		depFound := &appsv1.Deployment{}
-		depErr := r.Get(context.TODO(), types.NamespacedName{Name: bookbagName, Namespace: BOOKBAG_NAMESPACE_NAME}, depFound)
-		if depErr != nil && errors.IsNotFound(depErr) {
-			break
-		}
-		id++
+	roleBinding := kubernetes.NewRoleBindingSA(workshop, r.Scheme, bookbagName, util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels,
+		serviceAccount.Name, BOOKBAG_ROLE_BINDING_NAME, BOOKBAG_ROLE_KIND_NAME)
+	//Delete  Role Binding
+	if err := kubernetes.DeleteIfExists(r, roleBinding); err != nil {
+		return reconcile.Result{}, err
 	}
+	log.Infof("Deleted %s RoleBinding", roleBinding.Name)
 
-	namespace := kubernetes.NewNamespace(workshop, r.Scheme, BOOKBAG_NAMESPACE_NAME)
-	// delete namespace
-	if err := r.Delete(context.TODO(), namespace); err != nil {
+	// Delete  Service Account
+	if err := kubernetes.DeleteIfExists(r, serviceAccount); err != nil {
 		return reconcile.Result{}, err
 	}
-	log.Infof("Deleted %s namespace", namespace.Name)
+	log.Infof("Deleted %s Service Account", serviceAccount.Name)
+
+	varConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, bookbagName+"-vars", util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, nil)
+	// Delete ConfigMap
+	if err := kubernetes.DeleteIfExists(r, varConfigMap); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s ConfigMap", varConfigMap.Name)
+
+	envConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, bookbagName+"-env", util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), labels, bookbagConfigData)
+	// Delete ConfigMap
+	if err := kubernetes.DeleteIfExists(r, envConfigMap); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s ConfigMap", envConfigMap.Name)
 
-	return reconcile.Result{}, nil
 	//Success
+	return reconcile.Result{}, nil
+}
 
+// userBookbagName returns the name of the bookbag of an attendee
+func userBookbagName(username string) string {
+	return username + "-bookbag"
 }
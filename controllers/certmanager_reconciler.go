@@ -1,14 +1,12 @@
 package controllers
 
 import (
-	"context"
-
 	"github.com/prometheus/common/log"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
 	certmanager "github.com/stakater/workshop-operator/common/certmanager"
 	"github.com/stakater/workshop-operator/common/kubernetes"
 	"github.com/stakater/workshop-operator/common/util"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -46,10 +44,11 @@ func (r *WorkshopReconciler) addCertManager(workshop *workshopv1.Workshop) (reco
 	// Create CertManager Subscription
 	CertManagerSubscription := kubernetes.NewCertifiedSubscription(workshop, r.Scheme, CERT_MANAGER_SUBSCRIPTION_NAME, CERT_MANAGER_SUBSCRIPTION_NAMESPACE_NAME,
 		CERT_MANAGER_PACKAGE_NAME, channel, clusterServiceVersion)
-	if err := r.Create(context.TODO(), CertManagerSubscription); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(CertManagerSubscription, "CertManager")
+	if op, err := kubernetes.Apply(r, r.Scheme, CertManagerSubscription); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Subscription", CertManagerSubscription.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Subscription", CertManagerSubscription.Name)
 	}
 
 	// Approve the installation
@@ -58,20 +57,29 @@ func (r *WorkshopReconciler) addCertManager(workshop *workshopv1.Workshop) (reco
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	// Wait for Operator to be installed
+	if ready, err := kubernetes.IsSubscriptionReady(r, CERT_MANAGER_SUBSCRIPTION_NAME, CERT_MANAGER_SUBSCRIPTION_NAMESPACE_NAME); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
 	// Create CertManager Namespace
 	namespace := kubernetes.NewNamespace(workshop, r.Scheme, CERT_MANAGER_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), namespace); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(namespace, "CertManager")
+	if op, err := kubernetes.Apply(r, r.Scheme, namespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Namespace", namespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Namespace", namespace.Name)
 	}
 
 	// Create CertManager CustomResource
 	customresource := certmanager.NewCustomResource(workshop, r.Scheme, CERT_MANAGER_CUSTOM_RESOURCE_NAME, namespace.Name, certManagerLabels)
-	if err := r.Create(context.TODO(), customresource); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(customresource, "CertManager")
+	if op, err := kubernetes.Apply(r, r.Scheme, customresource); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Custom Resource", customresource.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Custom Resource", customresource.Name)
 	}
 
 	//Success
@@ -80,6 +88,15 @@ func (r *WorkshopReconciler) addCertManager(workshop *workshopv1.Workshop) (reco
 
 func (r *WorkshopReconciler) deleteCertManager(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
+	// The cert-manager operator and its namespace are shared with the other workshops on the cluster
+	if shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.CertManager.Enabled
+	}); err != nil {
+		return reconcile.Result{}, err
+	} else if shared {
+		return reconcile.Result{}, nil
+	}
+
 	channel := workshop.Spec.Infrastructure.CertManager.OperatorHub.Channel
 	clusterServiceVersion := workshop.Spec.Infrastructure.CertManager.OperatorHub.ClusterServiceVersion
 
@@ -87,13 +104,13 @@ func (r *WorkshopReconciler) deleteCertManager(workshop *workshopv1.Workshop) (r
 
 	// Delete CertManager CustomResource
 	customresource := certmanager.NewCustomResource(workshop, r.Scheme, CERT_MANAGER_CUSTOM_RESOURCE_NAME, namespace.Name, certManagerLabels)
-	if err := r.Delete(context.TODO(), customresource); err != nil {
+	if err := kubernetes.DeleteIfExists(r, customresource); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Custom Resource", customresource.Name)
 
 	// Delete CertManager Namespace
-	if err := r.Delete(context.TODO(), namespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, namespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s  Namespace", namespace.Name)
@@ -101,7 +118,7 @@ func (r *WorkshopReconciler) deleteCertManager(workshop *workshopv1.Workshop) (r
 	// Delete certManager Subscription
 	CertManagerSubscription := kubernetes.NewCertifiedSubscription(workshop, r.Scheme, CERT_MANAGER_SUBSCRIPTION_NAME, CERT_MANAGER_SUBSCRIPTION_NAMESPACE_NAME,
 		CERT_MANAGER_PACKAGE_NAME, channel, clusterServiceVersion)
-	if err := r.Delete(context.TODO(), CertManagerSubscription); err != nil {
+	if err := kubernetes.DeleteIfExists(r, CertManagerSubscription); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Subscription", CertManagerSubscription.Name)
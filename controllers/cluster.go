@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	configv1 "github.com/openshift/api/config/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	CLUSTER_INGRESS_NAME       = "cluster"
+	CONSOLE_ROUTE_NAME         = "console"
+	CONSOLE_ROUTE_NAMESPACE    = "openshift-console"
+	CONSOLE_ROUTE_HOST_PREFIX  = "console-openshift-console."
+	APPS_DOMAIN_SOURCE_SPEC    = "spec.cluster.appsDomain"
+	APPS_DOMAIN_SOURCE_INGRESS = "ingresses.config.openshift.io/cluster"
+	APPS_DOMAIN_SOURCE_CONSOLE = "openshift-console/console route"
+)
+
+var consoleHostRegexp = regexp.MustCompile(`^` + regexp.QuoteMeta(CONSOLE_ROUTE_HOST_PREFIX) + `(.+)$`)
+
+// resolveAppsDomain returns the domain of the application routes and the URL of the OpenShift console.
+// The domain is taken from the spec, then from the cluster ingress config, then from the console route.
+func (r *WorkshopReconciler) resolveAppsDomain(workshop *workshopv1.Workshop) (string, string, error) {
+	// The console route is optional, a console with a custom hostname still has a URL
+	consoleRoute := &routev1.Route{}
+	consoleURL := ""
+	if r.Platform.IsOpenShift() {
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: CONSOLE_ROUTE_NAME, Namespace: CONSOLE_ROUTE_NAMESPACE}, consoleRoute); err == nil {
+			consoleURL = "https://" + consoleRoute.Spec.Host
+		} else if !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			log.Warnf("Failed to get OpenShift Console route: %s", err)
+		}
+	}
+
+	// Plain Kubernetes has neither an ingress config nor a console, the domain must be set in the spec
+	appsDomain, source := workshop.Spec.Cluster.AppsDomain, APPS_DOMAIN_SOURCE_SPEC
+	if appsDomain == "" && r.Platform.IsOpenShift() {
+		ingress := &configv1.Ingress{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: CLUSTER_INGRESS_NAME}, ingress); err == nil {
+			appsDomain, source = ingress.Spec.Domain, APPS_DOMAIN_SOURCE_INGRESS
+		} else if !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			log.Warnf("Failed to get cluster ingress config: %s", err)
+		}
+	}
+	if appsDomain == "" {
+		if match := consoleHostRegexp.FindStringSubmatch(consoleRoute.Spec.Host); match != nil {
+			appsDomain, source = match[1], APPS_DOMAIN_SOURCE_CONSOLE
+		}
+	}
+
+	if appsDomain == "" {
+		err := fmt.Errorf("unable to resolve the apps domain, set %s", APPS_DOMAIN_SOURCE_SPEC)
+		if r.Platform.IsOpenShift() {
+			err = fmt.Errorf("unable to resolve the apps domain from %s, %s or %s, set %s",
+				APPS_DOMAIN_SOURCE_SPEC, APPS_DOMAIN_SOURCE_INGRESS, APPS_DOMAIN_SOURCE_CONSOLE, APPS_DOMAIN_SOURCE_SPEC)
+		}
+		util.SetCondition(&workshop.Status.Conditions, workshopv1.Condition{
+			Type:               workshopv1.ConditionAppsDomainResolved,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workshop.Generation,
+			Reason:             util.ConditionReason.Unresolved,
+			Message:            err.Error(),
+		})
+		return "", consoleURL, err
+	}
+
+	if consoleURL == "" && r.Platform.IsOpenShift() {
+		consoleURL = "https://" + CONSOLE_ROUTE_HOST_PREFIX + appsDomain
+	}
+	util.SetCondition(&workshop.Status.Conditions, workshopv1.Condition{
+		Type:               workshopv1.ConditionAppsDomainResolved,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workshop.Generation,
+		Reason:             util.ConditionReason.Resolved,
+		Message:            fmt.Sprintf("Apps domain %s from %s", appsDomain, source),
+	})
+	return appsDomain, consoleURL, nil
+}
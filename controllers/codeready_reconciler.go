@@ -2,7 +2,6 @@ package controllers
 
 import (
 	"bytes"
-	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -11,7 +10,6 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
-	"time"
 
 	_ "k8s.io/api/rbac/v1"
 
@@ -19,9 +17,10 @@ import (
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
 	"github.com/stakater/workshop-operator/common/codeready"
 	"github.com/stakater/workshop-operator/common/kubernetes"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
 	"github.com/stakater/workshop-operator/common/util"
 
-	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/yaml"
 )
@@ -42,16 +41,17 @@ const (
 	CHE_CLUSTER_ROLE_BINDING_NAME       = "che"
 	CHE_SERVICEACCOUNT_NAME             = "che"
 	CHE_CODE_FLAVOR_NAME                = "codeready"
+	CHE_INSTRUCTORS_ROLE_BINDING_NAME   = "instructors"
 )
 
 // Reconciling CodeReadyWorkspace
-func (r *WorkshopReconciler) reconcileCodeReadyWorkspace(workshop *workshopv1.Workshop, users int,
+func (r *WorkshopReconciler) reconcileCodeReadyWorkspace(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, removed []string,
 	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
 
 	enabled := workshop.Spec.Infrastructure.CodeReadyWorkspace.Enabled
 
 	if enabled {
-		if result, err := r.addCodeReadyWorkspace(workshop, users, appsHostnameSuffix); util.IsRequeued(result, err) {
+		if result, err := r.addCodeReadyWorkspace(workshop, attendees, removed, appsHostnameSuffix); util.IsRequeued(result, err) {
 			return result, err
 		}
 	}
@@ -60,58 +60,80 @@ func (r *WorkshopReconciler) reconcileCodeReadyWorkspace(workshop *workshopv1.Wo
 	return reconcile.Result{}, nil
 }
 
-func (r *WorkshopReconciler) addCodeReadyWorkspace(workshop *workshopv1.Workshop, users int,
+func (r *WorkshopReconciler) addCodeReadyWorkspace(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, removed []string,
 	appsHostnameSuffix string) (reconcile.Result, error) {
 
 	channel := workshop.Spec.Infrastructure.CodeReadyWorkspace.OperatorHub.Channel
 	clusterServiceVersion := workshop.Spec.Infrastructure.CodeReadyWorkspace.OperatorHub.ClusterServiceVersion
 
 	// Create Project
-	codeReadyWorkspacesNamespace := kubernetes.NewNamespace(workshop, r.Scheme, CODEREADY_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), codeReadyWorkspacesNamespace); err != nil && !errors.IsAlreadyExists(err) {
+	codeReadyWorkspacesNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, codeReadyWorkspacesNamespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Project", codeReadyWorkspacesNamespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Project", codeReadyWorkspacesNamespace.Name)
+	}
+
+	// Create Instructors Role Binding, instructors administer CodeReady Workspaces
+	instructorsRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, CHE_INSTRUCTORS_ROLE_BINDING_NAME, codeReadyWorkspacesNamespace.Name, codeReadyLabels,
+		r.instructorSubjects(workshop, attendees), INSTRUCTOR_TOOLS_ROLE, KIND_CLUSTER_ROLE)
+	if !isInstructorToolsAdmin(workshop) || len(instructorsRoleBinding.Subjects) == 0 {
+		if err := kubernetes.DeleteIfExists(r, instructorsRoleBinding); err != nil {
+			return reconcile.Result{}, err
+		}
+	} else if op, err := kubernetes.Apply(r, r.Scheme, instructorsRoleBinding); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role Binding", instructorsRoleBinding.Name)
 	}
 
 	// Create OperatorGroup
-	codeReadyWorkspacesOperatorGroup := kubernetes.NewOperatorGroup(workshop, r.Scheme, CODEREADY_OPERATORGROUP_NAME, CODEREADY_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), codeReadyWorkspacesOperatorGroup); err != nil && !errors.IsAlreadyExists(err) {
+	codeReadyWorkspacesOperatorGroup := kubernetes.NewOperatorGroup(workshop, r.Scheme, CODEREADY_OPERATORGROUP_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, codeReadyWorkspacesOperatorGroup); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s OperatorGroup", codeReadyWorkspacesOperatorGroup.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s OperatorGroup", codeReadyWorkspacesOperatorGroup.Name)
 	}
 
 	// Create Subscription
-	codeReadyWorkspacesSubscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, CODEREADY_SUBSCRIPTION_NAME, CODEREADY_NAMESPACE_NAME,
+	codeReadyWorkspacesSubscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, CODEREADY_SUBSCRIPTION_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME),
 		CODEREADY_SUBSCRIPTION_PACKAGE_NAME, channel, clusterServiceVersion)
-	if err := r.Create(context.TODO(), codeReadyWorkspacesSubscription); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, codeReadyWorkspacesSubscription); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Subscription", codeReadyWorkspacesSubscription.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Subscription", codeReadyWorkspacesSubscription.Name)
 	}
 
 	// Approve the Installation
-	if err := r.ApproveInstallPlan(clusterServiceVersion, CODEREADY_SUBSCRIPTION_NAME, CODEREADY_NAMESPACE_NAME); err != nil {
+	if err := r.ApproveInstallPlan(clusterServiceVersion, CODEREADY_SUBSCRIPTION_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME)); err != nil {
 		log.Warnf("Waiting for Subscription to create InstallPlan for %s", "codeready-workspaces")
 		return reconcile.Result{Requeue: true}, nil
 	}
 
 	// Wait for CodeReadyWorkspace Operator to be running
-	if !kubernetes.GetK8Client().GetDeploymentStatus(CODEREADY_OPERATOR_DEPLOYMENT_NAME, CODEREADY_NAMESPACE_NAME) {
+	if ready, err := kubernetes.IsDeploymentReady(r, CODEREADY_OPERATOR_DEPLOYMENT_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME)); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	codeReadyWorkspacesCustomResource := codeready.NewCustomResource(workshop, r.Scheme, CHE_CUSTOM_RESOURCE_NAME, CODEREADY_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), codeReadyWorkspacesCustomResource); err != nil && !errors.IsAlreadyExists(err) {
+	codeReadyWorkspacesCustomResource := codeready.NewCustomResource(workshop, r.Scheme, CHE_CUSTOM_RESOURCE_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, codeReadyWorkspacesCustomResource); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Custom Resource", codeReadyWorkspacesCustomResource.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Custom Resource", codeReadyWorkspacesCustomResource.Name)
 	}
 
 	// Wait for CodeReadyWorkspace to be running
-	if !kubernetes.GetK8Client().GetDeploymentStatus(CODEREADY_DEPLOYMENT_NAME, CODEREADY_NAMESPACE_NAME) {
-		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 1}, nil
+	if ready, err := kubernetes.IsDeploymentReady(r, CODEREADY_DEPLOYMENT_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME)); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	// Users and workspaces are created through the CodeReady API, which cannot be planned
+	if r.Planning() {
+		return reconcile.Result{}, nil
 	}
 
 	// Initialize Workspaces from devfile
@@ -122,64 +144,91 @@ func (r *WorkshopReconciler) addCodeReadyWorkspace(workshop *workshopv1.Workshop
 
 	// Users and Workspaces
 	if !workshop.Spec.Infrastructure.CodeReadyWorkspace.OpenshiftOAuth {
-		masterAccessToken, result, err := getKeycloakAdminToken(workshop, CODEREADY_NAMESPACE_NAME, appsHostnameSuffix)
+		masterAccessToken, result, err := getKeycloakAdminToken(workshop, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), appsHostnameSuffix)
 		if err != nil {
 			return result, err
 		}
 
 		// Create Che Cluster Role
 		cheClusterRole :=
-			kubernetes.NewClusterRole(workshop, r.Scheme, CHE_CLUSTER_ROLE_NAME, CODEREADY_NAMESPACE_NAME, codeReadyLabels, kubernetes.CheRules())
-		if err := r.Create(context.TODO(), cheClusterRole); err != nil && !errors.IsAlreadyExists(err) {
+			kubernetes.NewClusterRole(workshop, r.Scheme, util.ScopedName(workshop, CHE_CLUSTER_ROLE_NAME), util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), codeReadyLabels, kubernetes.CheRules())
+		if op, err := kubernetes.Apply(r, r.Scheme, cheClusterRole); err != nil {
 			return reconcile.Result{}, err
-		} else if err == nil {
-			log.Infof("Created %s Cluster Role", cheClusterRole.Name)
+		} else if op != controllerutil.OperationResultNone {
+			log.Infof("Applied %s Cluster Role", cheClusterRole.Name)
 		}
 
 		//Create Che Cluster Role Binding
-		cheClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, CHE_CLUSTER_ROLE_BINDING_NAME, CODEREADY_NAMESPACE_NAME, codeReadyLabels, CHE_SERVICEACCOUNT_NAME, cheClusterRole.Name, KIND_CLUSTER_ROLE)
-		if err := r.Create(context.TODO(), cheClusterRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
+		cheClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, CHE_CLUSTER_ROLE_BINDING_NAME), util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), codeReadyLabels, CHE_SERVICEACCOUNT_NAME, cheClusterRole.Name, KIND_CLUSTER_ROLE)
+		if op, err := kubernetes.Apply(r, r.Scheme, cheClusterRoleBinding); err != nil {
 			return reconcile.Result{}, err
-		} else if err == nil {
-			log.Infof("Created %s Cluster Role Binding", cheClusterRoleBinding.Name)
+		} else if op != controllerutil.OperationResultNone {
+			log.Infof("Applied %s Cluster Role Binding", cheClusterRoleBinding.Name)
 		}
 
-		for id := 1; id <= users; id++ {
-			username := fmt.Sprintf("user%d", id)
+		for _, attendee := range attendees {
+			username := attendee.Name
+			password, err := r.userPassword(workshop, username)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
 
-			if result, err := createUser(workshop, username, CHE_CODE_FLAVOR_NAME, CODEREADY_NAMESPACE_NAME, appsHostnameSuffix, masterAccessToken); err != nil {
+			if result, err := createUser(workshop, username, openshiftuser.Email(attendee), password, CHE_CODE_FLAVOR_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), appsHostnameSuffix, masterAccessToken); err != nil {
 				return result, err
 			}
 
-			userAccessToken, result, err := getUserToken(workshop, username, CHE_CODE_FLAVOR_NAME, CODEREADY_NAMESPACE_NAME, appsHostnameSuffix)
+			userAccessToken, result, err := getUserToken(workshop, username, password, CHE_CODE_FLAVOR_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), appsHostnameSuffix)
 			if err != nil {
 				return result, err
 			}
 
-			if result, err := initWorkspace(workshop, username, CHE_CODE_FLAVOR_NAME, CODEREADY_NAMESPACE_NAME, userAccessToken, devfile, appsHostnameSuffix); err != nil {
+			if result, err := initWorkspace(workshop, username, CHE_CODE_FLAVOR_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), userAccessToken, devfile, appsHostnameSuffix); err != nil {
 				return result, err
 			}
 
 		}
 	} else {
-		for id := 1; id <= users; id++ {
-			username := fmt.Sprintf("user%d", id)
+		for _, attendee := range attendees {
+			username := attendee.Name
+			password, err := r.userPassword(workshop, username)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
 
-			userAccessToken, result, err := getOAuthUserToken(workshop, username, CHE_CODE_FLAVOR_NAME, CODEREADY_NAMESPACE_NAME, appsHostnameSuffix)
+			userAccessToken, result, err := getOAuthUserToken(workshop, username, password, CHE_CODE_FLAVOR_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), appsHostnameSuffix)
 			if err != nil {
 				return result, err
 			}
 
-			if result, err := updateUserEmail(workshop, username, CHE_CODE_FLAVOR_NAME, CODEREADY_NAMESPACE_NAME, appsHostnameSuffix); err != nil {
+			if result, err := updateUserEmail(workshop, username, openshiftuser.Email(attendee), CHE_CODE_FLAVOR_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), appsHostnameSuffix); err != nil {
 				return result, err
 			}
 
-			if result, err := initWorkspace(workshop, username, CHE_CODE_FLAVOR_NAME, CODEREADY_NAMESPACE_NAME, userAccessToken, devfile, appsHostnameSuffix); err != nil {
+			if result, err := initWorkspace(workshop, username, CHE_CODE_FLAVOR_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), userAccessToken, devfile, appsHostnameSuffix); err != nil {
 				return result, err
 			}
 		}
 	}
 
+	// Delete the Keycloak users and workspaces of the attendees removed from the roster
+	if len(removed) > 0 {
+		masterAccessToken, result, err := getKeycloakAdminToken(workshop, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), appsHostnameSuffix)
+		if err != nil {
+			return result, err
+		}
+		for _, username := range removed {
+			if result, err := deleteUser(workshop, username, CHE_CODE_FLAVOR_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), appsHostnameSuffix, masterAccessToken); err != nil {
+				return result, err
+			}
+
+			userWorkspacesNamespace := kubernetes.NewNamespace(workshop, r.Scheme, username+"-"+"workspace")
+			if err := kubernetes.DeleteIfExists(r, userWorkspacesNamespace); err != nil {
+				return reconcile.Result{}, err
+			}
+			log.Infof("Deleted %s Namespace", userWorkspacesNamespace.Name)
+		}
+	}
+
 	//Success
 	return reconcile.Result{}, nil
 }
@@ -239,16 +288,15 @@ func getDevFile(workshop *workshopv1.Workshop) (string, reconcile.Result, error)
 }
 
 // Create user
-func createUser(workshop *workshopv1.Workshop, username string, codeflavor string,
+func createUser(workshop *workshopv1.Workshop, username string, email string, openshiftUserPassword string, codeflavor string,
 	namespace string, appsHostnameSuffix string, masterToken string) (reconcile.Result, error) {
 
 	var (
-		openshiftUserPassword = workshop.Spec.UserDetails.DefaultPassword
-		body                  []byte
-		err                   error
-		httpResponse          *http.Response
-		httpRequest           *http.Request
-		keycloakCheUserURL    = "https://keycloak-" + namespace + "." + appsHostnameSuffix + "/auth/admin/realms/" + codeflavor + "/users"
+		body               []byte
+		err                error
+		httpResponse       *http.Response
+		httpRequest        *http.Request
+		keycloakCheUserURL = "https://keycloak-" + namespace + "." + appsHostnameSuffix + "/auth/admin/realms/" + codeflavor + "/users"
 
 		client = &http.Client{
 			Transport: &http.Transport{
@@ -261,7 +309,7 @@ func createUser(workshop *workshopv1.Workshop, username string, codeflavor strin
 		}
 	)
 
-	body, err = json.Marshal(codeready.NewUser(username, openshiftUserPassword))
+	body, err = json.Marshal(codeready.NewUser(username, email, openshiftUserPassword))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -284,15 +332,83 @@ func createUser(workshop *workshopv1.Workshop, username string, codeflavor strin
 	return reconcile.Result{}, nil
 }
 
+// Delete user
+func deleteUser(workshop *workshopv1.Workshop, username string, codeflavor string,
+	namespace string, appsHostnameSuffix string, masterToken string) (reconcile.Result, error) {
+
+	var (
+		err                error
+		httpResponse       *http.Response
+		httpRequest        *http.Request
+		keycloakCheUserURL = "https://keycloak-" + namespace + "." + appsHostnameSuffix + "/auth/admin/realms/" + codeflavor + "/users"
+		client             = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+			// Do not follow Redirect
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		cheUsers []struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+		}
+	)
+
+	// GET USER
+	httpRequest, err = http.NewRequest("GET", keycloakCheUserURL+"?username="+url.QueryEscape(username), nil)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	httpRequest.Header.Set("Authorization", "Bearer "+masterToken)
+
+	httpResponse, err = client.Do(httpRequest)
+	if err != nil {
+		log.Errorf("Error when getting %s user: %v", username, err)
+		return reconcile.Result{}, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return reconcile.Result{}, fmt.Errorf("error when getting %s user: %d", username, httpResponse.StatusCode)
+	}
+	if err := json.NewDecoder(httpResponse.Body).Decode(&cheUsers); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// The search matches substrings, only delete the user with this exact name
+	for _, cheUser := range cheUsers {
+		if cheUser.Username != username {
+			continue
+		}
+		httpRequest, err = http.NewRequest("DELETE", keycloakCheUserURL+"/"+cheUser.ID, nil)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		httpRequest.Header.Set("Authorization", "Bearer "+masterToken)
+
+		deleteResponse, err := client.Do(httpRequest)
+		if err != nil {
+			log.Errorf("Error when deleting %s user: %v", username, err)
+			return reconcile.Result{}, err
+		}
+		deleteResponse.Body.Close()
+		if deleteResponse.StatusCode == http.StatusNoContent {
+			log.Infof("Deleted %s in CodeReady Workspaces", username)
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
 // Get user token
-func getUserToken(workshop *workshopv1.Workshop, username string, codeflavor string, namespace string, appsHostnameSuffix string) (string, reconcile.Result, error) {
+func getUserToken(workshop *workshopv1.Workshop, username string, openshiftUserPassword string, codeflavor string, namespace string, appsHostnameSuffix string) (string, reconcile.Result, error) {
 
 	var (
-		openshiftUserPassword = workshop.Spec.UserDetails.DefaultPassword
-		err                   error
-		httpResponse          *http.Response
-		httpRequest           *http.Request
-		keycloakCheTokenURL   = "https://keycloak-" + namespace + "." + appsHostnameSuffix + "/auth/realms/" + codeflavor + "/protocol/openid-connect/token"
+		err                 error
+		httpResponse        *http.Response
+		httpRequest         *http.Request
+		keycloakCheTokenURL = "https://keycloak-" + namespace + "." + appsHostnameSuffix + "/auth/realms/" + codeflavor + "/protocol/openid-connect/token"
 
 		userToken util.Token
 		client    = &http.Client{
@@ -340,15 +456,14 @@ func getUserToken(workshop *workshopv1.Workshop, username string, codeflavor str
 }
 
 // Get oauthUserToken
-func getOAuthUserToken(workshop *workshopv1.Workshop, username string,
+func getOAuthUserToken(workshop *workshopv1.Workshop, username string, openshiftUserPassword string,
 	codeflavor string, namespace string, appsHostnameSuffix string) (string, reconcile.Result, error) {
 	var (
-		openshiftUserPassword = workshop.Spec.UserDetails.DefaultPassword
-		err                   error
-		httpResponse          *http.Response
-		httpRequest           *http.Request
-		keycloakCheTokenURL   = "https://keycloak-" + namespace + "." + appsHostnameSuffix + "/auth/realms/" + codeflavor + "/protocol/openid-connect/token"
-		oauthOpenShiftURL     = "https://oauth-openshift." + appsHostnameSuffix + "/oauth/authorize?client_id=openshift-challenging-client&response_type=token"
+		err                 error
+		httpResponse        *http.Response
+		httpRequest         *http.Request
+		keycloakCheTokenURL = "https://keycloak-" + namespace + "." + appsHostnameSuffix + "/auth/realms/" + codeflavor + "/protocol/openid-connect/token"
+		oauthOpenShiftURL   = "https://oauth-openshift." + appsHostnameSuffix + "/oauth/authorize?client_id=openshift-challenging-client&response_type=token"
 
 		userToken util.Token
 		client    = &http.Client{
@@ -462,7 +577,7 @@ func getKeycloakAdminToken(workshop *workshopv1.Workshop, namespace string, apps
 }
 
 // Update User Email
-func updateUserEmail(workshop *workshopv1.Workshop, username string,
+func updateUserEmail(workshop *workshopv1.Workshop, username string, email string,
 	codeflavor string, namespace string, appsHostnameSuffix string) (reconcile.Result, error) {
 	var (
 		err                    error
@@ -528,9 +643,9 @@ func updateUserEmail(workshop *workshopv1.Workshop, username string,
 			return reconcile.Result{}, err
 		}
 
-		if cheUser[0].Email == "" {
+		if len(cheUser) > 0 && cheUser[0].Email != email {
 			httpRequest, err = http.NewRequest("PUT", keycloakUserURL+"/"+cheUser[0].ID,
-				strings.NewReader(`{"email":"`+username+`@none.com"}`))
+				strings.NewReader(`{"email":"`+email+`"}`))
 			if err != nil {
 				log.Error(err, "Failed http PUT Request")
 			}
@@ -594,20 +709,20 @@ func initWorkspace(workshop *workshopv1.Workshop, username string,
 	return reconcile.Result{}, nil
 }
 
-func (r *WorkshopReconciler) deleteCodeReadyWorkspace(workshop *workshopv1.Workshop, users int, appsHostnameSuffix string) (reconcile.Result, error) {
+func (r *WorkshopReconciler) deleteCodeReadyWorkspace(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, appsHostnameSuffix string) (reconcile.Result, error) {
 
 	channel := workshop.Spec.Infrastructure.CodeReadyWorkspace.OperatorHub.Channel
 	clusterServiceVersion := workshop.Spec.Infrastructure.CodeReadyWorkspace.OperatorHub.ClusterServiceVersion
 
 	if !workshop.Spec.Infrastructure.CodeReadyWorkspace.OpenshiftOAuth {
 
-		for id := 1; id <= users; id++ {
-			username := fmt.Sprintf("user%d", id)
+		for _, attendee := range attendees {
+			username := attendee.Name
 
 			userWorkspacesNamespaceName := username + "-" + "workspace"
 			userWorkspacesNamespace := kubernetes.NewNamespace(workshop, r.Scheme, userWorkspacesNamespaceName)
 			// Delete Project
-			if err := r.Delete(context.TODO(), userWorkspacesNamespace); err != nil {
+			if err := kubernetes.DeleteIfExists(r, userWorkspacesNamespace); err != nil {
 				log.Errorf("Failed to Delete %s Namespace", userWorkspacesNamespace.Name)
 
 				return reconcile.Result{}, err
@@ -616,47 +731,55 @@ func (r *WorkshopReconciler) deleteCodeReadyWorkspace(workshop *workshopv1.Works
 
 		}
 
-		cheClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, CHE_CLUSTER_ROLE_NAME, CODEREADY_NAMESPACE_NAME, codeReadyLabels, kubernetes.CheRules())
+		cheClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, util.ScopedName(workshop, CHE_CLUSTER_ROLE_NAME), util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), codeReadyLabels, kubernetes.CheRules())
 		// Delete che Cluster Role
-		if err := r.Delete(context.TODO(), cheClusterRole); err != nil {
+		if err := kubernetes.DeleteIfExists(r, cheClusterRole); err != nil {
 			return reconcile.Result{}, err
 		}
 		log.Infof("Deleted %s Cluster Role ", cheClusterRole.Name)
 
-		cheClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, CHE_CLUSTER_ROLE_BINDING_NAME, CODEREADY_NAMESPACE_NAME, codeReadyLabels, CHE_SERVICEACCOUNT_NAME, cheClusterRole.Name, KIND_CLUSTER_ROLE)
+		cheClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, CHE_CLUSTER_ROLE_BINDING_NAME), util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), codeReadyLabels, CHE_SERVICEACCOUNT_NAME, cheClusterRole.Name, KIND_CLUSTER_ROLE)
 		// Delete che Cluster RoleBinding
-		if err := r.Delete(context.TODO(), cheClusterRoleBinding); err != nil {
+		if err := kubernetes.DeleteIfExists(r, cheClusterRoleBinding); err != nil {
 			return reconcile.Result{}, err
 		}
 		log.Infof("Deleted %s Cluster RoleBinding ", cheClusterRoleBinding.Name)
 
 	}
 
-	codeReadyWorkspacesCustomResource := codeready.NewCustomResource(workshop, r.Scheme, CHE_CUSTOM_RESOURCE_NAME, CODEREADY_NAMESPACE_NAME)
+	instructorsRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, CHE_INSTRUCTORS_ROLE_BINDING_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME), codeReadyLabels,
+		nil, INSTRUCTOR_TOOLS_ROLE, KIND_CLUSTER_ROLE)
+	// Delete instructors Role Binding
+	if err := kubernetes.DeleteIfExists(r, instructorsRoleBinding); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Role Binding", instructorsRoleBinding.Name)
+
+	codeReadyWorkspacesCustomResource := codeready.NewCustomResource(workshop, r.Scheme, CHE_CUSTOM_RESOURCE_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME))
 	// Delete codeReadyWorkspaces CustomResource
-	if err := r.Delete(context.TODO(), codeReadyWorkspacesCustomResource); err != nil {
+	if err := kubernetes.DeleteIfExists(r, codeReadyWorkspacesCustomResource); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s  CustomResource", codeReadyWorkspacesCustomResource.Name)
 
-	codeReadyWorkspacesSubscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, CODEREADY_SUBSCRIPTION_NAME, CODEREADY_NAMESPACE_NAME,
+	codeReadyWorkspacesSubscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, CODEREADY_SUBSCRIPTION_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME),
 		CODEREADY_SUBSCRIPTION_PACKAGE_NAME, channel, clusterServiceVersion)
 	// Delete Subscription
-	if err := r.Delete(context.TODO(), codeReadyWorkspacesSubscription); err != nil {
+	if err := kubernetes.DeleteIfExists(r, codeReadyWorkspacesSubscription); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Subscription", codeReadyWorkspacesSubscription.Name)
 
-	codeReadyWorkspacesOperatorGroup := kubernetes.NewOperatorGroup(workshop, r.Scheme, CODEREADY_OPERATORGROUP_NAME, CODEREADY_NAMESPACE_NAME)
+	codeReadyWorkspacesOperatorGroup := kubernetes.NewOperatorGroup(workshop, r.Scheme, CODEREADY_OPERATORGROUP_NAME, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME))
 	// Delete OperatorGroup
-	if err := r.Delete(context.TODO(), codeReadyWorkspacesOperatorGroup); err != nil {
+	if err := kubernetes.DeleteIfExists(r, codeReadyWorkspacesOperatorGroup); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s OperatorGroup", codeReadyWorkspacesOperatorGroup.Name)
 
-	codeReadyWorkspacesNamespace := kubernetes.NewNamespace(workshop, r.Scheme, CODEREADY_NAMESPACE_NAME)
+	codeReadyWorkspacesNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME))
 	// Delete Project
-	if err := r.Delete(context.TODO(), codeReadyWorkspacesNamespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, codeReadyWorkspacesNamespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Namespace", codeReadyWorkspacesNamespace.Name)
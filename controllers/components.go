@@ -0,0 +1,391 @@
+package controllers
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/component"
+	"github.com/stakater/workshop-operator/common/platform"
+	"github.com/stakater/workshop-operator/common/util"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// openShiftOnly is the platform list of components that rely on OLM, Routes or SCCs
+var openShiftOnly = []platform.Platform{platform.OpenShift}
+
+// builtinComponents returns the components shipped with the operator, in reconcile order
+func (r *WorkshopReconciler) builtinComponents() []component.Component {
+	return []component.Component{
+		&component.Func{
+			ComponentName: "Credentials",
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileCredentials(env.Workshop, env.Attendees)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteCredentials(env.Workshop)
+			},
+		},
+		&component.Func{
+			ComponentName: "Users",
+			DependsOn:     []string{"Credentials"},
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileUser(env.Workshop, env.Attendees)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteUsers(env.Workshop)
+			},
+		},
+		&component.Func{
+			ComponentName: "Attendees",
+			DependsOn:     []string{"Users"},
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileAttendees(env.Workshop, env.Attendees)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteAttendees(env.Workshop)
+			},
+		},
+		&component.Func{
+			ComponentName: "OAuth",
+			DependsOn:     []string{"Users"},
+			Platforms:     openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileOAuth(env.Workshop)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteOAuth(env.Workshop)
+			},
+		},
+		&component.Func{
+			ComponentName: "Portal",
+			DependsOn:     []string{"Credentials"},
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcilePortal(env.Workshop, env.Attendees, env.AppsHostnameSuffix, env.OpenshiftConsoleURL)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deletePortal(env.Workshop, env.Attendees, env.AppsHostnameSuffix, env.OpenshiftConsoleURL)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.UsernameDistribution
+			},
+		},
+		&component.Func{
+			ComponentName: "Project",
+			DependsOn:     []string{"Users"},
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.Project.Enabled
+			},
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileProject(env.Workshop, env.Attendees, env.RemovedAttendees)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteProject(env.Workshop, env.Attendees)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.Project
+			},
+		},
+		&component.Func{
+			ComponentName: "Seed",
+			DependsOn:     []string{"Project"},
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.Project.Enabled && spec.Infrastructure.Project.Seed != nil
+			},
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileSeed(env.Workshop, env.Attendees, env.AppsHostnameSuffix)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteSeed(env.Workshop)
+			},
+		},
+		&component.Func{
+			ComponentName: "Bookbag",
+			DependsOn:     []string{"Credentials", "Users", "Portal", "Project", "Nexus", "Gitea", "GitOps", "CodeReadyWorkspace", "ServiceMesh"},
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.Guide.Bookbag.Enabled
+			},
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileBookbag(env.Workshop, env.Attendees, env.RemovedAttendees, env.AppsHostnameSuffix, env.OpenshiftConsoleURL)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteBookbag(env.Workshop, env.Attendees, env.AppsHostnameSuffix, env.OpenshiftConsoleURL)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.Bookbag
+			},
+		},
+		&component.Func{
+			ComponentName: "Nexus",
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.Nexus.Enabled
+			},
+			Platforms: openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileNexus(env.Workshop)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteNexus(env.Workshop)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.Nexus
+			},
+		},
+		&component.Func{
+			ComponentName: "Gitea",
+			DependsOn:     []string{"Credentials"},
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.Gitea.Enabled
+			},
+			Platforms: openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileGitea(env.Workshop, env.Attendees, env.RemovedAttendees)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteGitea(env.Workshop)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.Gitea
+			},
+		},
+		&component.Func{
+			ComponentName: "Pipeline",
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.Pipeline.Enabled
+			},
+			Platforms: openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcilePipelines(env.Workshop)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deletePipelines(env.Workshop)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.Pipeline
+			},
+		},
+		&component.Func{
+			ComponentName: "GitOps",
+			DependsOn:     []string{"Credentials", "Project", "Gitea"},
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.GitOps.Enabled
+			},
+			Platforms: openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileGitOps(env.Workshop, env.Attendees, env.RemovedAttendees, env.AppsHostnameSuffix, env.OpenshiftConsoleURL)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteGitOps(env.Workshop, env.Attendees, env.AppsHostnameSuffix, env.OpenshiftConsoleURL)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.GitOps
+			},
+		},
+		&component.Func{
+			ComponentName: "CodeReadyWorkspace",
+			DependsOn:     []string{"Credentials"},
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.CodeReadyWorkspace.Enabled
+			},
+			Platforms: openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileCodeReadyWorkspace(env.Workshop, env.Attendees, env.RemovedAttendees, env.AppsHostnameSuffix, env.OpenshiftConsoleURL)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteCodeReadyWorkspace(env.Workshop, env.Attendees, env.AppsHostnameSuffix)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.CodeReadyWorkspace
+			},
+		},
+		&component.Func{
+			ComponentName: "ServiceMesh",
+			DependsOn:     []string{"Project"},
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.ServiceMesh.Enabled || spec.Infrastructure.Serverless.Enabled
+			},
+			Platforms: openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileServiceMesh(env.Workshop, env.Attendees)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteServiceMeshService(env.Workshop, env.Attendees)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.ServiceMesh
+			},
+		},
+		&component.Func{
+			ComponentName: "Serverless",
+			DependsOn:     []string{"ServiceMesh"},
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.Serverless.Enabled
+			},
+			Platforms: openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileServerless(env.Workshop)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteServerless(env.Workshop)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.Serverless
+			},
+		},
+		&component.Func{
+			ComponentName: "Vault",
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.Vault.Enabled
+			},
+			Platforms: openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileVault(env.Workshop, env.Users)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteVault(env.Workshop)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.Vault
+			},
+		},
+		&component.Func{
+			ComponentName: "CertManager",
+			EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+				return spec.Infrastructure.CertManager.Enabled
+			},
+			Platforms: openShiftOnly,
+			ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.reconcileCertManager(env.Workshop, env.Users)
+			},
+			DeleteFunc: func(env *component.Environment) (reconcile.Result, error) {
+				return r.deleteCertManager(env.Workshop)
+			},
+			StatusFunc: func(status *workshopv1.WorkshopStatus) *string {
+				return &status.CertManager
+			},
+		},
+	}
+}
+
+// componentOutcome is the result of reconciling a single component
+type componentOutcome struct {
+	component component.Component
+	result    reconcile.Result
+	err       error
+}
+
+// removeDisabledComponents runs the delete path of every disabled component that is still provisioned.
+// Components are removed in reverse dependency order, so dependents go before their dependencies.
+func (r *WorkshopReconciler) removeDisabledComponents(env *component.Environment) []componentOutcome {
+	workshop := env.Workshop
+	outcomes := []componentOutcome{}
+
+	components := r.graph.Sorted()
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+		if c.Enabled(&workshop.Spec) || !needsRemoval(workshop, c) {
+			continue
+		}
+		log.Infof("Removing disabled component %s", c.Name())
+		result, err := c.Delete(env)
+		r.setComponentRemoval(workshop, c, result, err)
+		outcomes = append(outcomes, componentOutcome{component: c, result: result, err: err})
+	}
+	return outcomes
+}
+
+// reconcileComponents reconciles the components in dependency order, running independent components concurrently.
+// A component is only reconciled once all of its enabled dependencies are ready.
+func (r *WorkshopReconciler) reconcileComponents(env *component.Environment) (reconcile.Result, error) {
+	workshop := env.Workshop
+
+	// Tear down components that were disabled since they were provisioned
+	outcomes := r.removeDisabledComponents(env)
+	removed := make(map[string]bool)
+	for _, outcome := range outcomes {
+		removed[outcome.component.Name()] = true
+	}
+
+	settled := make(map[string]bool)
+	ready := make(map[string]bool)
+
+	pending := r.graph.Sorted()
+	for len(pending) > 0 {
+		wave := []component.Component{}
+		next := []component.Component{}
+		for _, c := range pending {
+			runnable := true
+			waitingOn := []string{}
+			for _, dependency := range r.graph.Dependencies(c.Name()) {
+				if !settled[dependency.Name()] {
+					runnable = false
+				} else if !ready[dependency.Name()] {
+					waitingOn = append(waitingOn, dependency.Name())
+				}
+			}
+
+			switch {
+			case !runnable:
+				next = append(next, c)
+			case !c.Enabled(&workshop.Spec):
+				if !removed[c.Name()] {
+					r.setComponentStatus(workshop, c, false, reconcile.Result{}, nil)
+				}
+				settled[c.Name()] = true
+				ready[c.Name()] = true
+			case !c.Supports(env.Platform):
+				// Dependents run without a component the platform cannot host
+				r.setComponentUnsupported(workshop, c, env.Platform)
+				settled[c.Name()] = true
+				ready[c.Name()] = true
+			case len(waitingOn) > 0:
+				r.setComponentWaiting(workshop, c, waitingOn)
+				settled[c.Name()] = true
+			default:
+				wave = append(wave, c)
+			}
+		}
+
+		// Reconcile the wave concurrently
+		waveOutcomes := make([]componentOutcome, len(wave))
+		var wg sync.WaitGroup
+		for i, c := range wave {
+			wg.Add(1)
+			go func(i int, c component.Component) {
+				defer wg.Done()
+				result, err := c.Reconcile(env)
+				waveOutcomes[i] = componentOutcome{component: c, result: result, err: err}
+			}(i, c)
+		}
+		wg.Wait()
+
+		for _, outcome := range waveOutcomes {
+			name := outcome.component.Name()
+			r.setComponentStatus(workshop, outcome.component, true, outcome.result, outcome.err)
+			settled[name] = true
+			ready[name] = !util.IsRequeued(outcome.result, outcome.err)
+			outcomes = append(outcomes, outcome)
+		}
+
+		pending = next
+	}
+
+	// Merge results, the shortest requeue wins
+	result := reconcile.Result{}
+	errs := []error{}
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", outcome.component.Name(), outcome.err))
+		}
+		if outcome.result.Requeue {
+			result.Requeue = true
+		}
+		if outcome.result.RequeueAfter > 0 && (result.RequeueAfter == 0 || outcome.result.RequeueAfter < result.RequeueAfter) {
+			result.RequeueAfter = outcome.result.RequeueAfter
+		}
+	}
+
+	return result, utilerrors.NewAggregate(errs)
+}
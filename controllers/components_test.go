@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/component"
+	"github.com/stakater/workshop-operator/common/platform"
+	"github.com/stakater/workshop-operator/common/util"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// outcome is how a test component behaves
+type outcome int
+
+const (
+	ready outcome = iota
+	requeued
+	failing
+	disabled
+	unsupported
+)
+
+type testComponent struct {
+	name      string
+	dependsOn []string
+	outcome   outcome
+}
+
+func TestReconcileComponentsWaves(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []testComponent
+		// waves are the components reconciled together, in order
+		waves   [][]string
+		reasons map[string]string
+		requeue bool
+		err     bool
+	}{
+		{
+			name: "independent components run in one wave",
+			components: []testComponent{
+				{name: "Users"},
+				{name: "Gitea"},
+				{name: "Nexus"},
+			},
+			waves: [][]string{{"Users", "Gitea", "Nexus"}},
+			reasons: map[string]string{
+				"Users": util.ConditionReason.Installed,
+				"Gitea": util.ConditionReason.Installed,
+				"Nexus": util.ConditionReason.Installed,
+			},
+		},
+		{
+			name: "dependents run after their dependencies",
+			components: []testComponent{
+				{name: "Users"},
+				{name: "Project", dependsOn: []string{"Users"}},
+				{name: "Gitea", dependsOn: []string{"Users"}},
+				{name: "Bookbag", dependsOn: []string{"Project", "Gitea"}},
+			},
+			waves: [][]string{{"Users"}, {"Project", "Gitea"}, {"Bookbag"}},
+			reasons: map[string]string{
+				"Users":   util.ConditionReason.Installed,
+				"Project": util.ConditionReason.Installed,
+				"Gitea":   util.ConditionReason.Installed,
+				"Bookbag": util.ConditionReason.Installed,
+			},
+		},
+		{
+			name: "dependents wait on a dependency that is not ready",
+			components: []testComponent{
+				{name: "Users", outcome: requeued},
+				{name: "Project", dependsOn: []string{"Users"}},
+				{name: "Gitea"},
+			},
+			waves: [][]string{{"Users", "Gitea"}},
+			reasons: map[string]string{
+				"Users":   util.ConditionReason.InProgress,
+				"Project": util.ConditionReason.Waiting,
+				"Gitea":   util.ConditionReason.Installed,
+			},
+			requeue: true,
+		},
+		{
+			name: "a failed dependency holds back its transitive dependents",
+			components: []testComponent{
+				{name: "Users", outcome: failing},
+				{name: "Project", dependsOn: []string{"Users"}},
+				{name: "Portal", dependsOn: []string{"Project"}},
+			},
+			waves: [][]string{{"Users"}},
+			reasons: map[string]string{
+				"Users":   util.ConditionReason.Failed,
+				"Project": util.ConditionReason.Waiting,
+				"Portal":  util.ConditionReason.Waiting,
+			},
+			err: true,
+		},
+		{
+			name: "disabled and unsupported dependencies do not hold back dependents",
+			components: []testComponent{
+				{name: "Users", outcome: disabled},
+				{name: "Gitea", outcome: unsupported},
+				{name: "Project", dependsOn: []string{"Users", "Gitea"}},
+			},
+			waves: [][]string{{"Project"}},
+			reasons: map[string]string{
+				"Users":   util.ConditionReason.NotScheduled,
+				"Gitea":   util.ConditionReason.Unsupported,
+				"Project": util.ConditionReason.Installed,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waveOf := make(map[string]int)
+			barriers := make([]*sync.WaitGroup, len(tt.waves))
+			for i, wave := range tt.waves {
+				barriers[i] = &sync.WaitGroup{}
+				barriers[i].Add(len(wave))
+				for _, name := range wave {
+					waveOf[name] = i
+				}
+			}
+
+			var mu sync.Mutex
+			done := make(map[string]bool)
+			startedAfter := make(map[string]map[string]bool)
+			problems := []string{}
+
+			components := []component.Component{}
+			for _, tc := range tt.components {
+				tc := tc
+				c := &component.Func{
+					ComponentName: tc.name,
+					DependsOn:     tc.dependsOn,
+					EnabledFunc: func(spec *workshopv1.WorkshopSpec) bool {
+						return tc.outcome != disabled
+					},
+					ReconcileFunc: func(env *component.Environment) (reconcile.Result, error) {
+						mu.Lock()
+						snapshot := make(map[string]bool, len(done))
+						for name := range done {
+							snapshot[name] = true
+						}
+						startedAfter[tc.name] = snapshot
+						wave, ok := waveOf[tc.name]
+						if !ok {
+							problems = append(problems, fmt.Sprintf("%s was reconciled", tc.name))
+						}
+						mu.Unlock()
+
+						// Every component of a wave must run at the same time to pass the barrier
+						if ok {
+							barriers[wave].Done()
+							passed := make(chan struct{})
+							go func() {
+								barriers[wave].Wait()
+								close(passed)
+							}()
+							select {
+							case <-passed:
+							case <-time.After(5 * time.Second):
+								mu.Lock()
+								problems = append(problems, fmt.Sprintf("%s did not run concurrently with its wave", tc.name))
+								mu.Unlock()
+							}
+						}
+
+						mu.Lock()
+						done[tc.name] = true
+						mu.Unlock()
+
+						switch tc.outcome {
+						case requeued:
+							return reconcile.Result{Requeue: true}, nil
+						case failing:
+							return reconcile.Result{}, errors.New("failed")
+						}
+						return reconcile.Result{}, nil
+					},
+				}
+				if tc.outcome == unsupported {
+					c.Platforms = []platform.Platform{platform.OpenShift}
+				}
+				components = append(components, c)
+			}
+
+			graph, err := component.NewGraph(components)
+			if err != nil {
+				t.Fatalf("NewGraph() error = %v", err)
+			}
+			r := &WorkshopReconciler{graph: graph}
+			workshop := &workshopv1.Workshop{}
+			env := &component.Environment{Workshop: workshop, Platform: platform.Kubernetes}
+
+			result, err := r.reconcileComponents(env)
+			if (err != nil) != tt.err {
+				t.Errorf("reconcileComponents() error = %v, want error %v", err, tt.err)
+			}
+			if result.Requeue != tt.requeue {
+				t.Errorf("reconcileComponents() requeue = %v, want %v", result.Requeue, tt.requeue)
+			}
+			for _, problem := range problems {
+				t.Error(problem)
+			}
+
+			// A component starts after the earlier waves are done and before the later ones
+			for name, wave := range waveOf {
+				snapshot, ok := startedAfter[name]
+				if !ok {
+					t.Errorf("%s was not reconciled", name)
+					continue
+				}
+				for i, other := range tt.waves {
+					for _, otherName := range other {
+						if i < wave && !snapshot[otherName] {
+							t.Errorf("%s started before %s of an earlier wave was done", name, otherName)
+						}
+						if i > wave && snapshot[otherName] {
+							t.Errorf("%s started after %s of a later wave", name, otherName)
+						}
+					}
+				}
+			}
+
+			for name, reason := range tt.reasons {
+				condition := util.FindCondition(workshop.Status.Conditions, componentConditionType(name))
+				if condition == nil {
+					t.Errorf("no condition for %s", name)
+				} else if condition.Reason != reason {
+					t.Errorf("%s condition reason = %s, want %s", name, condition.Reason, reason)
+				}
+			}
+		})
+	}
+}
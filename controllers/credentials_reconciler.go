@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const CREDENTIALS_SECRET_SUFFIX = "-credentials"
+
+var credentialsLabels = map[string]string{
+	"app.kubernetes.io/part-of": "credentials",
+}
+
+// isPerUserPassword returns true if every attendee gets a generated password
+func isPerUserPassword(workshop *workshopv1.Workshop) bool {
+	return workshop.Spec.UserDetails.PasswordMode == workshopv1.PasswordModePerUser
+}
+
+// credentialsSecretName returns the name of the Secret holding the credentials of an attendee
+func credentialsSecretName(username string) string {
+	return username + CREDENTIALS_SECRET_SUFFIX
+}
+
+// reconcileCredentials generates a password per attendee in perUser mode.
+// Passwords are never regenerated, the Secrets of attendees that left and of the shared mode are deleted.
+func (r *WorkshopReconciler) reconcileCredentials(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
+	createUsers := make(map[string]bool)
+	if isPerUserPassword(workshop) {
+		for _, attendee := range attendees {
+			createUsers[attendee.Name] = true
+		}
+	}
+
+	listSecrets, err := r.createdCredentialsList(workshop)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range listSecrets.Items {
+		secret := &listSecrets.Items[i]
+		if createUsers[secret.Labels[openshiftuser.CREDENTIALS_USER_LABEL]] {
+			continue
+		}
+		if err := kubernetes.DeleteIfExists(r, secret); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s Credentials Secret", secret.Name)
+	}
+
+	// The cache only sees a created Secret later, dependents wait for the next reconcile instead of failing to read it
+	created := false
+	for username := range createUsers {
+		secretFound := &corev1.Secret{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: credentialsSecretName(username), Namespace: workshop.Namespace}, secretFound); err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+
+		password, err := openshiftuser.NewPassword()
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		// Create, never apply, so a password is not replaced once attendees received it
+		secret := openshiftuser.NewCredentialsSecret(workshop, r.Scheme, credentialsSecretName(username), workshop.Namespace, credentialsLabels, username, password)
+		if err := controllerutil.SetControllerReference(workshop, secret, r.Scheme); err != nil {
+			return reconcile.Result{}, err
+		}
+		created = true
+		if err := r.Create(context.TODO(), secret); errors.IsAlreadyExists(err) {
+			continue
+		} else if err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Created %s Credentials Secret", secret.Name)
+	}
+	if created && !r.Planning() {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// deleteCredentials deletes the credentials Secrets of all attendees
+func (r *WorkshopReconciler) deleteCredentials(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	listSecrets, err := r.createdCredentialsList(workshop)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range listSecrets.Items {
+		if err := kubernetes.DeleteIfExists(r, &listSecrets.Items[i]); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s Credentials Secret", listSecrets.Items[i].Name)
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// sharedPassword returns the password of all attendees, or an empty string if every attendee has their own
+func sharedPassword(workshop *workshopv1.Workshop) string {
+	if isPerUserPassword(workshop) {
+		return ""
+	}
+	return workshop.Spec.UserDetails.DefaultPassword
+}
+
+// userPassword returns the password of an attendee, DefaultPassword unless passwords are generated per attendee
+func (r *WorkshopReconciler) userPassword(workshop *workshopv1.Workshop, username string) (string, error) {
+	if !isPerUserPassword(workshop) {
+		return workshop.Spec.UserDetails.DefaultPassword, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: credentialsSecretName(username), Namespace: workshop.Namespace}, secret); err != nil {
+		// Passwords are only generated when the plan is applied
+		if errors.IsNotFound(err) && r.Planning() {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read the credentials of %s: %s", username, err)
+	}
+	password, ok := secret.Data[openshiftuser.CREDENTIALS_PASSWORD]
+	if !ok || len(password) == 0 {
+		return "", fmt.Errorf("the credentials Secret %s has no %s", secret.Name, openshiftuser.CREDENTIALS_PASSWORD)
+	}
+	return string(password), nil
+}
+
+// createdCredentialsList returns the credentials Secrets of the workshop
+func (r *WorkshopReconciler) createdCredentialsList(workshop *workshopv1.Workshop) (*corev1.SecretList, error) {
+	labelSelector := labels.SelectorFromSet(kubernetes.WorkshopLabels(workshop, credentialsLabels))
+	listSecrets := &corev1.SecretList{}
+	listOps := &client.ListOptions{
+		Namespace:     workshop.Namespace,
+		LabelSelector: labelSelector,
+	}
+	if err := r.List(context.TODO(), listSecrets, listOps); err != nil {
+		log.Errorf("Failed to get list of Credentials Secrets, filtered by labelSelector {%s}, {%s}", labelSelector, err)
+		return nil, err
+	}
+	return listSecrets, nil
+}
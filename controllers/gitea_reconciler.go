@@ -1,23 +1,27 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
-	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/prometheus/common/log"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
 	"github.com/stakater/workshop-operator/common/gitea"
 	"github.com/stakater/workshop-operator/common/kubernetes"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
 	"github.com/stakater/workshop-operator/common/util"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -43,14 +47,16 @@ const (
 	GITEAROLEBINDINGNAME       = "gitea-operator"
 	GITEASERVICEACCOUNTNAME    = "gitea-operator"
 	GITEACLUSTERROLENAME       = "gitea-operator"
+	GITEAADMINUSERNAME         = "workshop-admin"
+	GITEAADMINSECRETNAME       = "gitea-admin-credentials"
 )
 
 // Reconciling Gitea
-func (r *WorkshopReconciler) reconcileGitea(workshop *workshopv1.Workshop, users int) (reconcile.Result, error) {
+func (r *WorkshopReconciler) reconcileGitea(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, removed []string) (reconcile.Result, error) {
 	enabledGitea := workshop.Spec.Infrastructure.Gitea.Enabled
 
 	if enabledGitea {
-		if result, err := r.addGitea(workshop, users); util.IsRequeued(result, err) {
+		if result, err := r.addGitea(workshop, attendees, removed); util.IsRequeued(result, err) {
 			return result, err
 		}
 	}
@@ -60,71 +66,84 @@ func (r *WorkshopReconciler) reconcileGitea(workshop *workshopv1.Workshop, users
 }
 
 // Add Gitea
-func (r *WorkshopReconciler) addGitea(workshop *workshopv1.Workshop, users int) (reconcile.Result, error) {
+func (r *WorkshopReconciler) addGitea(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, removed []string) (reconcile.Result, error) {
 
 	imageName := workshop.Spec.Infrastructure.Gitea.Image.Name
 	imageTag := workshop.Spec.Infrastructure.Gitea.Image.Tag
 
 	// Create Project
-	giteaNamespace := kubernetes.NewNamespace(workshop, r.Scheme, GITEANAMESPACENAME)
-	if err := r.Create(context.TODO(), giteaNamespace); err != nil && !errors.IsAlreadyExists(err) {
+	giteaNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, GITEANAMESPACENAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, giteaNamespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Project", giteaNamespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Project", giteaNamespace.Name)
 	}
 
 	// Create CRD
 	giteaCustomResourceDefinition := kubernetes.NewCustomResourceDefinition(workshop, r.Scheme, GITEACRDNAME, GITEACRDGROUPNAME, GITEACRDKINDNAME, GITEACRDLISTKINDNAME, GITEACRDPLURALNAME, GITEACRDSINGULARNAME, GITEACRDVERSIONAME, nil, nil)
-	if err := r.Create(context.TODO(), giteaCustomResourceDefinition); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(giteaCustomResourceDefinition, "Gitea")
+	if op, err := kubernetes.Apply(r, r.Scheme, giteaCustomResourceDefinition); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Custom Resource Definition", giteaCustomResourceDefinition.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Custom Resource Definition", giteaCustomResourceDefinition.Name)
 	}
 
 	// Create Service Account
 	giteaServiceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, GITEASERVICEACCOUNTNAME, giteaNamespace.Name, gitealabels)
-	if err := r.Create(context.TODO(), giteaServiceAccount); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, giteaServiceAccount); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Service Account", giteaServiceAccount.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Service Account", giteaServiceAccount.Name)
 	}
 
 	// Create Cluster Role
-	giteaClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, GITEACLUSTERROLENAME, giteaNamespace.Name, gitealabels, kubernetes.GiteaRules())
-	if err := r.Create(context.TODO(), giteaClusterRole); err != nil && !errors.IsAlreadyExists(err) {
+	giteaClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, util.ScopedName(workshop, GITEACLUSTERROLENAME), giteaNamespace.Name, gitealabels, kubernetes.GiteaRules())
+	if op, err := kubernetes.Apply(r, r.Scheme, giteaClusterRole); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Cluster Role", giteaClusterRole.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Cluster Role", giteaClusterRole.Name)
 	}
 
 	// Create Cluster Role Binding
-	giteaClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, GITEAROLEBINDINGNAME, giteaNamespace.Name, gitealabels, GITEASERVICEACCOUNTNAME, GITEAROLEBINDINGNAME, CLUSTERROLEKINDNAME)
-	if err := r.Create(context.TODO(), giteaClusterRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
+	giteaClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, GITEAROLEBINDINGNAME), giteaNamespace.Name, gitealabels, GITEASERVICEACCOUNTNAME, util.ScopedName(workshop, GITEAROLEBINDINGNAME), CLUSTERROLEKINDNAME)
+	if op, err := kubernetes.Apply(r, r.Scheme, giteaClusterRoleBinding); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Cluster Role Binding", giteaClusterRoleBinding.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Cluster Role Binding", giteaClusterRoleBinding.Name)
 	}
 
 	giteaOperator := kubernetes.NewAnsibleOperatorDeployment(workshop, r.Scheme, GITEAANSIBLEDEPLOYMENTNAME, giteaNamespace.Name, gitealabels, imageName+":"+imageTag, GITEASERVICEACCOUNTNAME)
 
 	// Create Operator
-	if err := r.Create(context.TODO(), giteaOperator); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, giteaOperator); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Operator", giteaOperator.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Operator", giteaOperator.Name)
 	}
 
-	// Create Custom Resource
-	giteaCustomResource := gitea.NewCustomResource(workshop, r.Scheme, GITEACRNAME, giteaNamespace.Name, gitealabels)
-	if err := r.Create(context.TODO(), giteaCustomResource); err != nil && !errors.IsAlreadyExists(err) {
+	// Create Custom Resource, the operator creates the administrator
+	adminPassword, err := r.giteaAdminPassword(workshop, giteaNamespace.Name)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	giteaCustomResource := gitea.NewCustomResource(workshop, r.Scheme, GITEACRNAME, giteaNamespace.Name, gitealabels,
+		GITEAADMINUSERNAME, adminPassword, openshiftuser.Email(workshopv1.Attendee{Name: GITEAADMINUSERNAME}))
+	if op, err := kubernetes.Apply(r, r.Scheme, giteaCustomResource); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Custom Resource", giteaCustomResource.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Custom Resource", giteaCustomResource.Name)
 	}
 
 	// Wait for server to be running
-	if !kubernetes.GetK8Client().GetDeploymentStatus(GITEADEPLOYMENTNAME, giteaNamespace.Name) {
-		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 1}, nil
+	if ready, err := kubernetes.IsDeploymentReady(r, GITEADEPLOYMENTNAME, giteaNamespace.Name); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	// Users are created through the Gitea API, which cannot be planned
+	if r.Planning() {
+		return reconcile.Result{}, nil
 	}
 
 	// Extract app route suffix from openshift-console
@@ -137,28 +156,48 @@ func (r *WorkshopReconciler) addGitea(workshop *workshopv1.Workshop, users int)
 	giteaURL := "https://" + giteaRouteFound.Spec.Host
 
 	// Create workshop users in gitea
-	for id := 1; id <= users; id++ {
-		username := fmt.Sprintf("user%d", id)
-		if result, err := createGitUser(workshop, username, giteaURL); err != nil {
+	for _, attendee := range attendees {
+		password, err := r.userPassword(workshop, attendee.Name)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if result, err := createGitUser(workshop, attendee, password, giteaURL); err != nil {
 			return result, err
 		}
 	}
 
+	// Instructors administer Gitea
+	for _, attendee := range attendees {
+		if !isInstructor(attendee) {
+			continue
+		}
+		if err := setGitUserAdmin(attendee, isInstructorToolsAdmin(workshop), adminPassword, giteaURL); err != nil {
+			log.Warnf("Failed to update the administrator role of %s in Gitea: %s", attendee.Name, err)
+		}
+	}
+
+	// Delete the users of removed attendees
+	for _, username := range removed {
+		if err := deleteGitUser(username, adminPassword, giteaURL); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to delete %s user in Gitea: %s", username, err)
+		}
+	}
+
 	//Success
 	return reconcile.Result{}, nil
 }
 
 // Create GitUser
-func createGitUser(workshop *workshopv1.Workshop, username string, giteaURL string) (reconcile.Result, error) {
+func createGitUser(workshop *workshopv1.Workshop, attendee workshopv1.Attendee, openshiftUserPassword string, giteaURL string) (reconcile.Result, error) {
+	username := attendee.Name
 
 	var (
-		openshiftUserPassword = workshop.Spec.UserDetails.DefaultPassword
-		err                   error
-		httpResponse          *http.Response
-		httpRequest           *http.Request
-		requestURL            = giteaURL + "/user/sign_up"
-		body                  = url.Values{}
-		client                = &http.Client{
+		err          error
+		httpResponse *http.Response
+		httpRequest  *http.Request
+		requestURL   = giteaURL + "/user/sign_up"
+		body         = url.Values{}
+		client       = &http.Client{
 			Transport: &http.Transport{
 				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 			},
@@ -170,7 +209,7 @@ func createGitUser(workshop *workshopv1.Workshop, username string, giteaURL stri
 	)
 
 	body.Set("user_name", username)
-	body.Set("email", username+"@none.com")
+	body.Set("email", openshiftuser.Email(attendee))
 	body.Set("password", openshiftUserPassword)
 	body.Set("retype", openshiftUserPassword)
 
@@ -196,6 +235,113 @@ func createGitUser(workshop *workshopv1.Workshop, username string, giteaURL stri
 	return reconcile.Result{}, nil
 }
 
+// giteaAdminPassword returns the password of the Gitea administrator, generated once and kept in a Secret
+func (r *WorkshopReconciler) giteaAdminPassword(workshop *workshopv1.Workshop, namespace string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: GITEAADMINSECRETNAME, Namespace: namespace}, secret); err == nil {
+		return string(secret.Data[openshiftuser.CREDENTIALS_PASSWORD]), nil
+	} else if !errors.IsNotFound(err) {
+		return "", err
+	}
+
+	password, err := openshiftuser.NewPassword()
+	if err != nil {
+		return "", err
+	}
+	secret = openshiftuser.NewCredentialsSecret(workshop, r.Scheme, GITEAADMINSECRETNAME, namespace, gitealabels, GITEAADMINUSERNAME, password)
+	if err := r.Create(context.TODO(), secret); err != nil {
+		return "", err
+	}
+	log.Infof("Created %s Secret", secret.Name)
+	return password, nil
+}
+
+// setGitUserAdmin grants or revokes the administrator role of a Gitea user
+func setGitUserAdmin(attendee workshopv1.Attendee, admin bool, adminPassword string, giteaURL string) error {
+	username := attendee.Name
+
+	var (
+		requestURL = giteaURL + "/api/v1/admin/users/" + url.PathEscape(username)
+		client     = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+	)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"login_name": username,
+		"source_id":  0,
+		"email":      openshiftuser.Email(attendee),
+		"admin":      admin,
+	})
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequest("PATCH", requestURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", "Basic "+util.GetBasicAuth(GITEAADMINUSERNAME, adminPassword))
+
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	switch httpResponse.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// The Gitea operator has not created the administrator of the custom resource yet
+		return fmt.Errorf("%s is not a Gitea administrator yet", GITEAADMINUSERNAME)
+	}
+	return fmt.Errorf("unexpected status %d", httpResponse.StatusCode)
+}
+
+// deleteGitUser deletes a Gitea user as the administrator, users that do not exist are ignored
+func deleteGitUser(username string, adminPassword string, giteaURL string) error {
+	var (
+		requestURL = giteaURL + "/api/v1/admin/users/" + url.PathEscape(username)
+		client     = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+	)
+
+	httpRequest, err := http.NewRequest("DELETE", requestURL, nil)
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Authorization", "Basic "+util.GetBasicAuth(GITEAADMINUSERNAME, adminPassword))
+
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	switch httpResponse.StatusCode {
+	case http.StatusNoContent:
+		log.Infof("Deleted %s user in Gitea", username)
+		return nil
+	case http.StatusNotFound:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// The Gitea operator has not created the administrator of the custom resource yet
+		return fmt.Errorf("%s is not a Gitea administrator yet", GITEAADMINUSERNAME)
+	case http.StatusUnprocessableEntity:
+		// Gitea refuses to delete users owning repositories or organizations, failing would retry forever
+		log.Warnf("Gitea user %s still owns repositories or organizations and is kept", username)
+		return nil
+	}
+	return fmt.Errorf("unexpected status %d", httpResponse.StatusCode)
+}
+
 // Delete Gitea
 func (r *WorkshopReconciler) deleteGitea(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
@@ -204,54 +350,63 @@ func (r *WorkshopReconciler) deleteGitea(workshop *workshopv1.Workshop) (reconci
 	imageName := workshop.Spec.Infrastructure.Gitea.Image.Name
 	imageTag := workshop.Spec.Infrastructure.Gitea.Image.Tag
 
-	giteaCustomResource := gitea.NewCustomResource(workshop, r.Scheme, GITEACRNAME, GITEANAMESPACENAME, gitealabels)
+	giteaCustomResource := gitea.NewCustomResource(workshop, r.Scheme, GITEACRNAME, util.ScopedName(workshop, GITEANAMESPACENAME), gitealabels, "", "", "")
 	// Delete Custom Resource
-	if err := r.Delete(context.TODO(), giteaCustomResource); err != nil {
+	if err := kubernetes.DeleteIfExists(r, giteaCustomResource); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s gitea Custom Resource", giteaCustomResource.Name)
 
-	giteaOperator := kubernetes.NewAnsibleOperatorDeployment(workshop, r.Scheme, GITEAANSIBLEDEPLOYMENTNAME, GITEANAMESPACENAME, gitealabels, imageName+":"+imageTag, GITEASERVICEACCOUNTNAME)
+	giteaOperator := kubernetes.NewAnsibleOperatorDeployment(workshop, r.Scheme, GITEAANSIBLEDEPLOYMENTNAME, util.ScopedName(workshop, GITEANAMESPACENAME), gitealabels, imageName+":"+imageTag, GITEASERVICEACCOUNTNAME)
 	// Delete Operator
-	if err := r.Delete(context.TODO(), giteaOperator); err != nil {
+	if err := kubernetes.DeleteIfExists(r, giteaOperator); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s gitea Operator", giteaOperator.Name)
 
-	giteaClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, GITEAROLEBINDINGNAME, GITEANAMESPACENAME, gitealabels, GITEASERVICEACCOUNTNAME, GITEACLUSTERROLENAME, CLUSTERROLEKINDNAME)
+	giteaClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, GITEAROLEBINDINGNAME), util.ScopedName(workshop, GITEANAMESPACENAME), gitealabels, GITEASERVICEACCOUNTNAME, util.ScopedName(workshop, GITEACLUSTERROLENAME), CLUSTERROLEKINDNAME)
 	// Delete Cluster Role Binding
-	if err := r.Delete(context.TODO(), giteaClusterRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, giteaClusterRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s gitea Cluster  Role Binding", giteaClusterRoleBinding.Name)
 
-	giteaClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, GITEACLUSTERROLENAME, GITEANAMESPACENAME, gitealabels, kubernetes.GiteaRules())
+	giteaClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, util.ScopedName(workshop, GITEACLUSTERROLENAME), util.ScopedName(workshop, GITEANAMESPACENAME), gitealabels, kubernetes.GiteaRules())
 	// Delete Cluster Role
-	if err := r.Delete(context.TODO(), giteaClusterRole); err != nil {
+	if err := kubernetes.DeleteIfExists(r, giteaClusterRole); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s gitea Cluster Role", giteaClusterRole.Name)
 
-	giteaServiceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, GITEASERVICEACCOUNTNAME, GITEANAMESPACENAME, gitealabels)
+	giteaServiceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, GITEASERVICEACCOUNTNAME, util.ScopedName(workshop, GITEANAMESPACENAME), gitealabels)
 	// Delete Service Account
-	if err := r.Delete(context.TODO(), giteaServiceAccount); err != nil {
+	if err := kubernetes.DeleteIfExists(r, giteaServiceAccount); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s gitea Service Account", giteaServiceAccount.Name)
 
-	giteaCustomResourceDefinition := kubernetes.NewCustomResourceDefinition(workshop, r.Scheme, GITEACRDNAME, GITEACRDGROUPNAME, GITEACRDKINDNAME, GITEACRDLISTKINDNAME, GITEACRDPLURALNAME, GITEACRDSINGULARNAME, GITEACRDVERSIONAME, nil, nil)
-	// Delete CRD
-	if err := r.Delete(context.TODO(), giteaCustomResourceDefinition); err != nil {
+	// Deleting the CRD deletes the Gitea resources of every workshop
+	shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.Gitea.Enabled
+	})
+	if err != nil {
 		return reconcile.Result{}, err
 	}
-	log.Infof("Deleted %s gitea Custom Resource Definition", giteaCustomResourceDefinition.Name)
+	if !shared {
+		giteaCustomResourceDefinition := kubernetes.NewCustomResourceDefinition(workshop, r.Scheme, GITEACRDNAME, GITEACRDGROUPNAME, GITEACRDKINDNAME, GITEACRDLISTKINDNAME, GITEACRDPLURALNAME, GITEACRDSINGULARNAME, GITEACRDVERSIONAME, nil, nil)
+		// Delete CRD
+		if err := kubernetes.DeleteIfExists(r, giteaCustomResourceDefinition); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s gitea Custom Resource Definition", giteaCustomResourceDefinition.Name)
+	}
 
-	giteaNamespace := kubernetes.NewNamespace(workshop, r.Scheme, GITEANAMESPACENAME)
+	giteaNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, GITEANAMESPACENAME))
 	// Delete Project
-	if err := r.Delete(context.TODO(), giteaNamespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, giteaNamespace); err != nil {
 		return reconcile.Result{}, err
 	}
-	log.Infof("Deleted %s gitea Project ", GITEANAMESPACENAME)
+	log.Infof("Deleted %s gitea Project ", util.ScopedName(workshop, GITEANAMESPACENAME))
 	log.Info("Gitea deleted succesfully")
 
 	//Success
@@ -3,8 +3,6 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"reflect"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	argocdoperatorv1 "github.com/argoproj-labs/argocd-operator/pkg/apis/argoproj/v1alpha1"
 	argocdv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
@@ -12,13 +10,17 @@ import (
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
 	"github.com/stakater/workshop-operator/common/argocd"
 	"github.com/stakater/workshop-operator/common/kubernetes"
-	"golang.org/x/crypto/bcrypt"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
 	corev1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	labelspkg "k8s.io/apimachinery/pkg/labels"
 
 	"github.com/stakater/workshop-operator/common/util"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -36,26 +38,53 @@ const (
 	ARGOCD_CUSTOMRESOURCE_NAME       = "argocd"
 	ARGOCD_DEPLOYMENT_NAME           = "argocd-server"
 	ARGOCD_CONFIG_SECRET_NAME        = "argocd-default-cluster-config"
+	ARGOCD_ADMIN_ROLE                = "role:admin"
 )
 
+// argocdControllerUser returns the user of the Argo CD application controller of the workshop
+func argocdControllerUser(workshop *workshopv1.Workshop) string {
+	return "system:serviceaccount:" + util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME) + ":argocd-argocd-application-controller"
+}
+
 // Reconciling GitOps
-func (r *WorkshopReconciler) reconcileGitOps(workshop *workshopv1.Workshop, users int,
+func (r *WorkshopReconciler) reconcileGitOps(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, removed []string,
 	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
 
 	enabledGitOps := workshop.Spec.Infrastructure.GitOps.Enabled
 
 	if enabledGitOps {
-		if result, err := r.addGitOps(workshop, users, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
+		if result, err := r.addGitOps(workshop, attendees, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
 			return result, err
 		}
 	}
 
+	// Delete the Argo CD projects of the attendees removed from the roster, applying argocd-secret and argocd-cm
+	// without their accounts prunes them
+	for _, username := range removed {
+		labels := map[string]string{
+			"app.kubernetes.io/part-of": "argocd",
+			"app.kubernetes.io/name":    "appproject-cr",
+		}
+		projectNames, err := util.UserProjectNames(workshop, username)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(projectNames) == 0 {
+			continue
+		}
+		appProjectCustomResource := argocd.NewAppProjectCustomResource(workshop, r.Scheme, projectNames[0], util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, nil)
+		if err := kubernetes.DeleteIfExists(r, appProjectCustomResource); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s Custom Resource", appProjectCustomResource.Name)
+	}
+
 	//Success
 	return reconcile.Result{}, nil
 }
 
 // Add GitOps
-func (r *WorkshopReconciler) addGitOps(workshop *workshopv1.Workshop, users int,
+func (r *WorkshopReconciler) addGitOps(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee,
 	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
 	log.Infoln("Creating GitOps ")
 	channel := workshop.Spec.Infrastructure.GitOps.OperatorHub.Channel
@@ -68,10 +97,11 @@ func (r *WorkshopReconciler) addGitOps(workshop *workshopv1.Workshop, users int,
 	// Create subscription
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, GITOPS_SUBSCRIPTION_NAME, GITOPS_OPERATOR_NAMESPACE_NAME,
 		GITOPS_SUBSCRIPTION_PACKAGE_NAME, channel, clusterServiceVersion)
-	if err := r.Create(context.TODO(), subscription); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(subscription, "GitOps")
+	if op, err := kubernetes.Apply(r, r.Scheme, subscription); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s  Subscription", subscription.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s  Subscription", subscription.Name)
 	}
 
 	// Approve the installation
@@ -81,181 +111,171 @@ func (r *WorkshopReconciler) addGitOps(workshop *workshopv1.Workshop, users int,
 	}
 
 	// Wait for Operator to be running
-	if !kubernetes.GetK8Client().GetDeploymentStatus(GITOPS_DEPLOYMENT_NAME, GITOPS_OPERATOR_NAMESPACE_NAME) {
+	if ready, err := kubernetes.IsDeploymentReady(r, GITOPS_DEPLOYMENT_NAME, GITOPS_OPERATOR_NAMESPACE_NAME); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
 		return reconcile.Result{Requeue: true}, nil
 	}
 
 	// Create a Project
-	namespace := kubernetes.NewNamespace(workshop, r.Scheme, ARGOCD_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), namespace); err != nil && !errors.IsAlreadyExists(err) {
+	namespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, namespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s  Project", namespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s  Project", namespace.Name)
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(workshop.Spec.UserDetails.DefaultPassword), bcrypt.DefaultCost)
-	if err != nil {
-		log.Errorf("Error when Bcrypt encrypt password for Argo CD: %v", err)
+	// Reuse the hashes of unchanged passwords, hashes are salted so regenerating them would change the secret
+	existingSecret := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: ARGOCD_SECRET_NAME, Namespace: namespace.Name}, existingSecret); err != nil && !errors.IsNotFound(err) {
 		return reconcile.Result{}, err
 	}
-	bcryptPassword := string(hashedPassword)
 
 	argocdPolicy := ""
 	namespaceList := ""
-	secretData := map[string]string{}
+	secretData := map[string][]byte{}
 	configMapData := map[string]string{}
+	appProjects := make(map[string]bool)
 
-	for id := 1; id <= users; id++ {
-		username := fmt.Sprintf("user%d", id)
+	for _, attendee := range attendees {
+		username := attendee.Name
 		userRole := fmt.Sprintf("role:%s", username)
-		projectName := fmt.Sprintf("%s%d", workshop.Spec.Infrastructure.Project.StagingName, id)
-		if id == 1 {
-			namespaceList = projectName
-		} else {
-			namespaceList = fmt.Sprintf("%s,%s", namespaceList, projectName)
+		projectNames, err := util.UserProjectNames(workshop, username)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(projectNames) == 0 {
+			continue
 		}
+		for _, projectName := range projectNames {
+			if namespaceList == "" {
+				namespaceList = projectName
+			} else {
+				namespaceList = fmt.Sprintf("%s,%s", namespaceList, projectName)
+			}
+		}
+		// The Argo CD project of a user deploys to all their projects and is named after the first one
+		appProjectName := projectNames[0]
 
-		userPolicy := `p, ` + userRole + `, applications, *, ` + projectName + `/*, allow
+		userPolicy := `p, ` + userRole + `, applications, *, ` + appProjectName + `/*, allow
 p, ` + userRole + `, clusters, get, https://kubernetes.default.svc, allow
-p, ` + userRole + `, projects, *,` + projectName + `, allow
-p, ` + userRole + `, repositories, *, http://gitea-server.gitea.svc:3000/` + username + `/*, allow
+p, ` + userRole + `, projects, *,` + appProjectName + `, allow
+p, ` + userRole + `, repositories, *, http://gitea-server.` + util.ScopedName(workshop, GITEANAMESPACENAME) + `.svc:3000/` + username + `/*, allow
 g, ` + username + `, ` + userRole + `
 `
+		// Instructors administer every project
+		if isInstructor(attendee) && isInstructorToolsAdmin(workshop) {
+			userPolicy = fmt.Sprintf("%sg, %s, %s\n", userPolicy, username, ARGOCD_ADMIN_ROLE)
+		}
 		argocdPolicy = fmt.Sprintf("%s%s", argocdPolicy, userPolicy)
 
-		secretData[fmt.Sprintf("accounts.%s.password", username)] = bcryptPassword
+		password, err := r.userPassword(workshop, username)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		passwordKey := fmt.Sprintf("accounts.%s.password", username)
+		bcryptPassword, err := openshiftuser.HashPassword(password, string(existingSecret.Data[passwordKey]))
+		if err != nil {
+			log.Errorf("Error when Bcrypt encrypt password for Argo CD: %v", err)
+			return reconcile.Result{}, err
+		}
+		secretData[passwordKey] = []byte(bcryptPassword)
 
 		configMapData[fmt.Sprintf("accounts.%s", username)] = "login"
 
 		labels["app.kubernetes.io/name"] = "appproject-cr"
-		appProjectCustomResource := argocd.NewAppProjectCustomResource(workshop, r.Scheme, projectName, ARGOCD_NAMESPACE_NAME, labels, argocdPolicy)
-		if err := r.Create(context.TODO(), appProjectCustomResource); err != nil && !errors.IsAlreadyExists(err) {
+		appProjectCustomResource := argocd.NewAppProjectCustomResource(workshop, r.Scheme, appProjectName, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, projectNames)
+		if op, err := kubernetes.Apply(r, r.Scheme, appProjectCustomResource); err != nil {
 			return reconcile.Result{}, err
-		} else if err == nil {
-			log.Infof("Created %s  Custom Resource", appProjectCustomResource.Name)
-		} else if errors.IsAlreadyExists(err) {
-			customResourceFound := &argocdv1.AppProject{}
-			if err := r.Get(context.TODO(), types.NamespacedName{Name: appProjectCustomResource.Name, Namespace: ARGOCD_NAMESPACE_NAME}, customResourceFound); err != nil {
-				return reconcile.Result{}, err
-			} else if err == nil {
-				if !reflect.DeepEqual(appProjectCustomResource.Spec, customResourceFound.Spec) {
-					customResourceFound.Spec = appProjectCustomResource.Spec
-					if err := r.Update(context.TODO(), customResourceFound); err != nil {
-						return reconcile.Result{}, err
-					}
-					log.Infof("Updated %s  Custom Resource", customResourceFound.Name)
-				}
-			}
+		} else if op != controllerutil.OperationResultNone {
+			log.Infof("Applied %s Custom Resource", appProjectCustomResource.Name)
 		}
+		appProjects[appProjectCustomResource.Name] = true
 
 		subjects := []rbac.Subject{}
 		argocdSubject := rbac.Subject{
 			Kind:     rbac.UserKind,
-			Name:     "system:serviceaccount:argocd:argocd-argocd-application-controller",
+			Name:     argocdControllerUser(workshop),
 			APIGroup: "rbac.authorization.k8s.io",
 		}
 
 		subjects = append(subjects, argocdSubject)
 
-		role := kubernetes.NewRole(workshop, r.Scheme,
-			ARGOCD_ROLE_NAME, projectName, labels, kubernetes.ArgoCDRules())
-		if err := r.Create(context.TODO(), role); err != nil && !errors.IsAlreadyExists(err) {
-			return reconcile.Result{}, err
-		} else if err == nil {
-			log.Infof("Created %s  Role in %s namespace", role.Name, projectName)
-		}
+		for _, projectName := range projectNames {
+			role := kubernetes.NewRole(workshop, r.Scheme,
+				ARGOCD_ROLE_NAME, projectName, labels, kubernetes.ArgoCDRules())
+			if op, err := kubernetes.Apply(r, r.Scheme, role); err != nil {
+				return reconcile.Result{}, err
+			} else if op != controllerutil.OperationResultNone {
+				log.Infof("Applied %s  Role in %s namespace", role.Name, projectName)
+			}
 
-		roleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, ARGOCD_ROLE_BINDING_NAME, projectName, labels, subjects, role.Name, ARGOCD_ROLE_KIND_NAME)
-		if err := r.Create(context.TODO(), roleBinding); err != nil && !errors.IsAlreadyExists(err) {
-			return reconcile.Result{}, err
-		} else if err == nil {
-			log.Infof("Created %s  Role Binding in %s namespace", roleBinding.Name, projectName)
-		} else if errors.IsAlreadyExists(err) {
-			found := &rbac.RoleBinding{}
-			if err := r.Get(context.TODO(), types.NamespacedName{Name: roleBinding.Name, Namespace: projectName}, found); err != nil {
+			roleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, ARGOCD_ROLE_BINDING_NAME, projectName, labels, subjects, role.Name, ARGOCD_ROLE_KIND_NAME)
+			if op, err := kubernetes.Apply(r, r.Scheme, roleBinding); err != nil {
 				return reconcile.Result{}, err
-			} else if err == nil {
-				if !reflect.DeepEqual(subjects, found.Subjects) {
-					found.Subjects = subjects
-					if err := r.Update(context.TODO(), found); err != nil {
-						return reconcile.Result{}, err
-					}
-					log.Infof("Updated %s  Role Binding in %s namespace", found.Name, projectName)
-				}
+			} else if op != controllerutil.OperationResultNone {
+				log.Infof("Applied %s Role Binding", roleBinding.Name)
 			}
 		}
 	}
 
+	// Delete the Argo CD projects left over from earlier project templates
+	labels["app.kubernetes.io/name"] = "appproject-cr"
+	labelSelector := labelspkg.SelectorFromSet(kubernetes.WorkshopLabels(workshop, labels))
+	listAppProjects := &argocdv1.AppProjectList{}
+	listOps := &client.ListOptions{
+		Namespace:     namespace.Name,
+		LabelSelector: labelSelector,
+	}
+	if err := r.List(context.TODO(), listAppProjects, listOps); err != nil && !meta.IsNoMatchError(err) {
+		log.Errorf("Failed to get list of AppProjects, filtered by labelSelector {%s}, {%s}", labelSelector, err)
+		return reconcile.Result{}, err
+	}
+	for i := range listAppProjects.Items {
+		appProject := &listAppProjects.Items[i]
+		if appProjects[appProject.Name] {
+			continue
+		}
+		if err := kubernetes.DeleteIfExists(r, appProject); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s Custom Resource", appProject.Name)
+	}
+
 	labels["app.kubernetes.io/name"] = "argocd-secret"
-	secret := kubernetes.NewStringDataSecret(workshop, r.Scheme, ARGOCD_SECRET_NAME, ARGOCD_NAMESPACE_NAME, labels, secretData)
-	if err := r.Create(context.TODO(), secret); err != nil && !errors.IsAlreadyExists(err) {
+	// Apply byte values so the password hashes of removed attendees are pruned, string data would leave them
+	secret := kubernetes.NewDataSecret(workshop, r.Scheme, ARGOCD_SECRET_NAME, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, secretData)
+	if op, err := kubernetes.Apply(r, r.Scheme, secret); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s  Secret", secret.Name)
-		// } else if errors.IsAlreadyExists(err) {
-		// 	secretFound := &corev1.Secret{}
-		// 	if err := r.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: namespace.Name}, secretFound); err != nil {
-		// 		return reconcile.Result{}, err
-		// 	} else if err == nil {
-		// 		if !util.IsIntersectMap(secretData, secretFound.StringData) {
-		// 			secretFound.StringData = secretData
-		// 			if err := r.Update(context.TODO(), secretFound); err != nil {
-		// 				return reconcile.Result{}, err
-		// 			}
-		// 			log.Infof("Updated %s Secret", secretFound.Name)
-		// 		}
-		// 	}
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Secret", secret.Name)
 	}
 
 	labels["app.kubernetes.io/name"] = "argocd-cm"
-	configmap := kubernetes.NewConfigMap(workshop, r.Scheme, ARGOCD_CONFIGMAP_NAME, ARGOCD_NAMESPACE_NAME, labels, configMapData)
-	if err := r.Create(context.TODO(), configmap); err != nil && !errors.IsAlreadyExists(err) {
+	configmap := kubernetes.NewConfigMap(workshop, r.Scheme, ARGOCD_CONFIGMAP_NAME, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, configMapData)
+	if op, err := kubernetes.Apply(r, r.Scheme, configmap); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s  ConfigMap", configmap.Name)
-	} else if errors.IsAlreadyExists(err) {
-		configmapFound := &corev1.ConfigMap{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: configmap.Name, Namespace: namespace.Name}, configmapFound); err != nil {
-			return reconcile.Result{}, err
-		} else if err == nil {
-			if !util.IsIntersectMap(configMapData, configmapFound.Data) {
-				configmapFound.Data = configMapData
-				if err := r.Update(context.TODO(), configmapFound); err != nil {
-					return reconcile.Result{}, err
-				}
-				log.Infof("Updated %s  ConfigMap", configmapFound.Name)
-			}
-		}
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s ConfigMap", configmap.Name)
 	}
 
 	labels["app.kubernetes.io/name"] = "argocd-cr"
-	argoCDCustomResource := argocd.NewArgoCDCustomResource(workshop, r.Scheme, ARGOCD_CUSTOMRESOURCE_NAME, ARGOCD_NAMESPACE_NAME, labels, argocdPolicy)
-	if err := r.Create(context.TODO(), argoCDCustomResource); err != nil && !errors.IsAlreadyExists(err) {
+	argoCDCustomResource := argocd.NewArgoCDCustomResource(workshop, r.Scheme, ARGOCD_CUSTOMRESOURCE_NAME, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, argocdPolicy)
+	if op, err := kubernetes.Apply(r, r.Scheme, argoCDCustomResource); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s  Custom Resource", argoCDCustomResource.Name)
-	} else if errors.IsAlreadyExists(err) {
-		customResourceFound := &argocdoperatorv1.ArgoCD{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: argoCDCustomResource.Name, Namespace: namespace.Name}, customResourceFound); err != nil {
-			return reconcile.Result{}, err
-		} else if err == nil {
-			if !reflect.DeepEqual(&argocdPolicy, customResourceFound.Spec.RBAC.Policy) {
-				customResourceFound.Spec.RBAC.Policy = &argocdPolicy
-				if err := r.Update(context.TODO(), customResourceFound); err != nil {
-					return reconcile.Result{}, err
-				}
-				log.Infof("Updated %s  Custom Resource", customResourceFound.Name)
-			}
-		}
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Custom Resource", argoCDCustomResource.Name)
 	}
 
 	// Wait for ArgoCD Dex Server to be running
-	// if !kubernetes.GetK8Client().GetDeploymentStatus("argocd-dex-server", namespace.Name) {
-	// 	return reconcile.Result{Requeue: true}, nil
+	// if ready, err := kubernetes.IsDeploymentReady(r, "argocd-dex-server", namespace.Name); err != nil || !ready {
+	// 	return reconcile.Result{Requeue: true}, err
 	// }
 
 	// Wait for ArgoCD Server to be running
-	if !kubernetes.GetK8Client().GetDeploymentStatus(ARGOCD_DEPLOYMENT_NAME, namespace.Name) {
+	if ready, err := kubernetes.IsDeploymentReady(r, ARGOCD_DEPLOYMENT_NAME, namespace.Name); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
 		return reconcile.Result{Requeue: true}, nil
 	}
 
@@ -279,23 +299,10 @@ func (r *WorkshopReconciler) manageArgocdDefaultClusterConfigSecret(workshop *wo
 	clusterConfigSecretData["server"] = "https://kubernetes.default.svc"
 
 	clusterConfigSecret := kubernetes.NewStringDataSecret(workshop, r.Scheme, ARGOCD_CONFIG_SECRET_NAME, namespaceName, labels, clusterConfigSecretData)
-	if err := r.Create(context.TODO(), clusterConfigSecret); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, clusterConfigSecret); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Argocd Secret", clusterConfigSecret.Name)
-	} else if errors.IsAlreadyExists(err) {
-		clusterConfigSecretFound := &corev1.Secret{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: clusterConfigSecret.Name, Namespace: namespaceName}, clusterConfigSecretFound); err != nil {
-			return reconcile.Result{}, err
-		} else if err == nil {
-			if !util.IsIntersectMap(clusterConfigSecretData, clusterConfigSecretFound.StringData) {
-				clusterConfigSecretFound.StringData = clusterConfigSecretData
-				if err := r.Update(context.TODO(), clusterConfigSecretFound); err != nil {
-					return reconcile.Result{}, err
-				}
-				log.Infof("Updated %s Argocd Secret", clusterConfigSecretFound.Name)
-			}
-		}
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Argocd Secret", clusterConfigSecret.Name)
 	}
 
 	//Success
@@ -303,7 +310,7 @@ func (r *WorkshopReconciler) manageArgocdDefaultClusterConfigSecret(workshop *wo
 }
 
 // delete GitOps
-func (r *WorkshopReconciler) deleteGitOps(workshop *workshopv1.Workshop, users int,
+func (r *WorkshopReconciler) deleteGitOps(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee,
 	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
 	log.Infoln("Deleting GitOps ")
 	channel := workshop.Spec.Infrastructure.GitOps.OperatorHub.Channel
@@ -311,136 +318,119 @@ func (r *WorkshopReconciler) deleteGitOps(workshop *workshopv1.Workshop, users i
 	labels := map[string]string{
 		"app.kubernetes.io/part-of": "argocd",
 	}
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(workshop.Spec.UserDetails.DefaultPassword), bcrypt.DefaultCost)
-	if err != nil {
-		log.Errorf("Error when Bcrypt encrypt password for Argo CD: %v", err)
-		return reconcile.Result{}, err
-	}
-	bcryptPassword := string(hashedPassword)
-
 	argocdPolicy := ""
 	namespaceList := ""
 	secretData := map[string]string{}
 	configMapData := map[string]string{}
 
-	if result, err := r.deleteArgocdDefaultClusterConfigSecret(workshop, ARGOCD_NAMESPACE_NAME, labels, namespaceList); util.IsRequeued(result, err) {
+	if result, err := r.deleteArgocdDefaultClusterConfigSecret(workshop, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, namespaceList); util.IsRequeued(result, err) {
 		return result, err
 	}
 
 	labels["app.kubernetes.io/name"] = "argocd-cr"
-	argoCDCustomResource := argocd.NewArgoCDCustomResource(workshop, r.Scheme, ARGOCD_CUSTOMRESOURCE_NAME, ARGOCD_NAMESPACE_NAME, labels, argocdPolicy)
+	argoCDCustomResource := argocd.NewArgoCDCustomResource(workshop, r.Scheme, ARGOCD_CUSTOMRESOURCE_NAME, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, argocdPolicy)
 	// Delete argoCD Custom Resource
-	if err := r.Delete(context.TODO(), argoCDCustomResource); err != nil {
+	if err := kubernetes.DeleteIfExists(r, argoCDCustomResource); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s  Custom Resource", argoCDCustomResource.Name)
 
 	labels["app.kubernetes.io/name"] = "argocd-cm"
-	configmap := kubernetes.NewConfigMap(workshop, r.Scheme, ARGOCD_CONFIGMAP_NAME, ARGOCD_NAMESPACE_NAME, labels, configMapData)
+	configmap := kubernetes.NewConfigMap(workshop, r.Scheme, ARGOCD_CONFIGMAP_NAME, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, configMapData)
 	// Delete Configmap
-	if err := r.Delete(context.TODO(), configmap); err != nil {
+	if err := kubernetes.DeleteIfExists(r, configmap); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s  Configmap", configmap.Name)
 
 	labels["app.kubernetes.io/name"] = "argocd-secret"
-	secret := kubernetes.NewStringDataSecret(workshop, r.Scheme, ARGOCD_SECRET_NAME, ARGOCD_NAMESPACE_NAME, labels, secretData)
+	secret := kubernetes.NewStringDataSecret(workshop, r.Scheme, ARGOCD_SECRET_NAME, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, secretData)
 	// Delete Secret
-	if err := r.Delete(context.TODO(), secret); err != nil {
+	if err := kubernetes.DeleteIfExists(r, secret); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s  Secret", secret.Name)
 
-	for id := 1; id <= users; id++ {
-		username := fmt.Sprintf("user%d", id)
-		userRole := fmt.Sprintf("role:%s", username)
-		projectName := fmt.Sprintf("%s%d", workshop.Spec.Infrastructure.Project.StagingName, id)
-		if id == 1 {
-			namespaceList = projectName
-		} else {
-			namespaceList = fmt.Sprintf("%s,%s", namespaceList, projectName)
+	for _, attendee := range attendees {
+		username := attendee.Name
+		projectNames, err := util.UserProjectNames(workshop, username)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(projectNames) == 0 {
+			continue
 		}
-
-		userPolicy := `p, ` + userRole + `, applications, *, ` + projectName + `/*, allow
-p, ` + userRole + `, clusters, get, https://kubernetes.default.svc, allow
-p, ` + userRole + `, projects, *,` + projectName + `, allow
-p, ` + userRole + `, repositories, *, http://gitea-server.gitea.svc:3000/` + username + `/*, allow
-g, ` + username + `, ` + userRole + `
-`
-		argocdPolicy = fmt.Sprintf("%s%s", argocdPolicy, userPolicy)
-
-		secretData[fmt.Sprintf("accounts.%s.password", username)] = bcryptPassword
-
-		configMapData[fmt.Sprintf("accounts.%s", username)] = "login"
 
 		subjects := []rbac.Subject{}
 		argocdSubject := rbac.Subject{
 			Kind:     rbac.UserKind,
-			Name:     "system:serviceaccount:argocd:argocd-argocd-application-controller",
+			Name:     argocdControllerUser(workshop),
 			APIGroup: "rbac.authorization.k8s.io",
 		}
 
 		subjects = append(subjects, argocdSubject)
 
-		role := kubernetes.NewRole(workshop, r.Scheme, ARGOCD_ROLE_NAME, projectName, labels, kubernetes.ArgoCDRules())
+		for _, projectName := range projectNames {
+			role := kubernetes.NewRole(workshop, r.Scheme, ARGOCD_ROLE_NAME, projectName, labels, kubernetes.ArgoCDRules())
 
-		roleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, ARGOCD_ROLE_BINDING_NAME, projectName, labels, subjects, role.Name, ARGOCD_ROLE_KIND_NAME)
-		// Delete roleBinding
-		if err := r.Delete(context.TODO(), roleBinding); err != nil {
-			return reconcile.Result{}, err
-		}
-		log.Infof("Deleted %s  Role Binding  in %s namespace", roleBinding.Name, projectName)
+			roleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, ARGOCD_ROLE_BINDING_NAME, projectName, labels, subjects, role.Name, ARGOCD_ROLE_KIND_NAME)
+			// Delete roleBinding
+			if err := kubernetes.DeleteIfExists(r, roleBinding); err != nil {
+				return reconcile.Result{}, err
+			}
+			log.Infof("Deleted %s  Role Binding  in %s namespace", roleBinding.Name, projectName)
 
-		// Delete role
-		if err := r.Delete(context.TODO(), role); err != nil {
-			return reconcile.Result{}, err
+			// Delete role
+			if err := kubernetes.DeleteIfExists(r, role); err != nil {
+				return reconcile.Result{}, err
+			}
+			log.Infof("Deleted %s  role in %s namespace ", role.Name, projectName)
 		}
-		log.Infof("Deleted %s  role in %s namespace ", role.Name, projectName)
 
 		labels["app.kubernetes.io/name"] = "appproject-cr"
-		appProjectCustomResource := argocd.NewAppProjectCustomResource(workshop, r.Scheme, projectName, ARGOCD_NAMESPACE_NAME, labels, argocdPolicy)
+		appProjectCustomResource := argocd.NewAppProjectCustomResource(workshop, r.Scheme, projectNames[0], util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), labels, projectNames)
 		// Delete appProject Custom Resource
-		if err := r.Delete(context.TODO(), appProjectCustomResource); err != nil {
+		if err := kubernetes.DeleteIfExists(r, appProjectCustomResource); err != nil {
 			return reconcile.Result{}, err
 		}
 		log.Infof("Deleted %s  appProject Custom Resource ", appProjectCustomResource.Name)
 	}
 
-	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, GITOPS_SUBSCRIPTION_NAME, GITOPS_OPERATOR_NAMESPACE_NAME,
-		GITOPS_SUBSCRIPTION_PACKAGE_NAME, channel, clusterServiceVersion)
-	gitopsCSV := subscription.Spec.StartingCSV
-	// Delete subscription
-	if err := r.Delete(context.TODO(), subscription); err != nil {
+	// The GitOps operator is shared with the other workshops on the cluster
+	shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.GitOps.Enabled
+	})
+	if err != nil {
 		return reconcile.Result{}, err
 	}
-	log.Infof("Deleted %s  Subscription", subscription.Name)
+	if !shared {
+		subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, GITOPS_SUBSCRIPTION_NAME, GITOPS_OPERATOR_NAMESPACE_NAME,
+			GITOPS_SUBSCRIPTION_PACKAGE_NAME, channel, clusterServiceVersion)
+		gitopsCSV := subscription.Spec.StartingCSV
+		// Delete subscription
+		if err := kubernetes.DeleteIfExists(r, subscription); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s  Subscription", subscription.Name)
 
-	operatorCSV := kubernetes.NewRedHatClusterServiceVersion(workshop, r.Scheme, gitopsCSV, GITOPS_OPERATOR_NAMESPACE_NAME)
-	if err := r.Delete(context.TODO(), operatorCSV); err != nil {
-		return reconcile.Result{}, err
+		operatorCSV := kubernetes.NewRedHatClusterServiceVersion(workshop, r.Scheme, gitopsCSV, GITOPS_OPERATOR_NAMESPACE_NAME)
+		if err := kubernetes.DeleteIfExists(r, operatorCSV); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s  ClusterServiceVersion", operatorCSV.Name)
 	}
-	log.Infof("Deleted %s  ClusterServiceVersion", operatorCSV.Name)
 
-	namespace := kubernetes.NewNamespace(workshop, r.Scheme, ARGOCD_NAMESPACE_NAME)
+	namespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME))
 	// Delete a Project
-	if err := r.Delete(context.TODO(), namespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, namespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleting %s  Project", namespace.Name)
-	namespaceFound := kubernetes.NewNamespace(workshop, r.Scheme, ARGOCD_NAMESPACE_NAME)
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: ARGOCD_NAMESPACE_NAME}, namespaceFound); err != nil {
+	namespaceFound := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME))
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME)}, namespaceFound); err != nil && !errors.IsNotFound(err) {
 		return reconcile.Result{}, err
-	}
-
-	if namespaceFound.Spec.Finalizers[0] == "kubernetes" {
-		argoCD := &argocdoperatorv1.ArgoCD{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: ARGOCD_CUSTOMRESOURCE_NAME, Namespace: ARGOCD_NAMESPACE_NAME}, argoCD); err != nil {
-			return reconcile.Result{}, err
-		}
-
-		patch := client.MergeFrom(argoCD.DeepCopy())
-		argoCD.Finalizers = nil
-		if err := r.Patch(context.TODO(), argoCD, patch); err != nil {
+	} else if err == nil && len(namespaceFound.Spec.Finalizers) > 0 && namespaceFound.Spec.Finalizers[0] == "kubernetes" {
+		if err := kubernetes.RemoveFinalizers(r, ARGOCD_CUSTOMRESOURCE_NAME, util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME), &argocdoperatorv1.ArgoCD{}); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
@@ -462,7 +452,7 @@ func (r *WorkshopReconciler) deleteArgocdDefaultClusterConfigSecret(workshop *wo
 
 	clusterConfigSecret := kubernetes.NewStringDataSecret(workshop, r.Scheme, ARGOCD_CONFIG_SECRET_NAME, namespaceName, labels, clusterConfigSecretData)
 	// delete cluster Config Secret
-	if err := r.Delete(context.TODO(), clusterConfigSecret); err != nil {
+	if err := kubernetes.DeleteIfExists(r, clusterConfigSecret); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s  Secret", clusterConfigSecret.Name)
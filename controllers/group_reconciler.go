@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
+	"github.com/stakater/workshop-operator/common/util"
+	rbac "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ATTENDEES_GROUP_SUFFIX          = "attendees"
+	INSTRUCTORS_GROUP_SUFFIX        = "instructors"
+	DEFAULT_INSTRUCTOR_PROJECT_ROLE = "view"
+	INSTRUCTOR_TOOLS_ROLE           = "admin"
+)
+
+// groupName returns the name of a group of the workshop. Groups are cluster-wide, so every workshop has its own
+// and deleting a workshop leaves the groups of the others
+func groupName(workshop *workshopv1.Workshop, suffix string) string {
+	return util.ScopedName(workshop, workshop.Name+"-"+suffix)
+}
+
+// isInstructor returns true if the attendee runs the workshop
+func isInstructor(attendee workshopv1.Attendee) bool {
+	return attendee.Role == workshopv1.RoleInstructor
+}
+
+// instructorProjectRole returns the cluster role of instructors in the attendee projects
+func instructorProjectRole(workshop *workshopv1.Workshop) string {
+	if instructors := workshop.Spec.UserDetails.Instructors; instructors != nil && instructors.ProjectRole != "" {
+		return instructors.ProjectRole
+	}
+	return DEFAULT_INSTRUCTOR_PROJECT_ROLE
+}
+
+// isInstructorToolsAdmin returns true if instructors administer Gitea, Argo CD and CodeReady Workspaces
+func isInstructorToolsAdmin(workshop *workshopv1.Workshop) bool {
+	if instructors := workshop.Spec.UserDetails.Instructors; instructors != nil && instructors.ToolsAdmin != nil {
+		return *instructors.ToolsAdmin
+	}
+	return true
+}
+
+// instructorSubjects returns the subjects granted the roles of instructors: their group on OpenShift,
+// their Service Accounts on plain Kubernetes where there are no groups
+func (r *WorkshopReconciler) instructorSubjects(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) []rbac.Subject {
+	if r.Platform.IsOpenShift() {
+		return []rbac.Subject{openshiftuser.NewGroupSubject(groupName(workshop, INSTRUCTORS_GROUP_SUFFIX))}
+	}
+	subjects := []rbac.Subject{}
+	for _, attendee := range attendees {
+		if isInstructor(attendee) {
+			subjects = append(subjects, r.userSubject(workshop, attendee.Name))
+		}
+	}
+	return subjects
+}
+
+// reconcileGroups puts attendees and instructors in their groups and grants the roles of every attendee to both groups,
+// so adding an attendee only changes a group
+func (r *WorkshopReconciler) reconcileGroups(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
+	members := map[string][]string{
+		ATTENDEES_GROUP_SUFFIX:   {},
+		INSTRUCTORS_GROUP_SUFFIX: {},
+	}
+	for _, attendee := range attendees {
+		if isInstructor(attendee) {
+			members[INSTRUCTORS_GROUP_SUFFIX] = append(members[INSTRUCTORS_GROUP_SUFFIX], attendee.Name)
+		} else {
+			members[ATTENDEES_GROUP_SUFFIX] = append(members[ATTENDEES_GROUP_SUFFIX], attendee.Name)
+		}
+	}
+
+	for _, suffix := range []string{ATTENDEES_GROUP_SUFFIX, INSTRUCTORS_GROUP_SUFFIX} {
+		// Create Group
+		group := openshiftuser.NewGroup(workshop, r.Scheme, groupName(workshop, suffix), members[suffix], userLabels)
+		if op, err := kubernetes.Apply(r, r.Scheme, group); err != nil {
+			return reconcile.Result{}, err
+		} else if op != controllerutil.OperationResultNone {
+			log.Infof("Applied %s Group", group.Name)
+		}
+
+		// Create Group Role Binding
+		groupRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, group.Name, USER_ROLE_BINDING_NAMESPACE_NAME, nil,
+			[]rbac.Subject{openshiftuser.NewGroupSubject(group.Name)}, USER_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
+		if op, err := kubernetes.Apply(r, r.Scheme, groupRoleBinding); err != nil {
+			return reconcile.Result{}, err
+		} else if op != controllerutil.OperationResultNone {
+			log.Infof("Applied %s Role Binding", groupRoleBinding.Name)
+		}
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// deleteGroups deletes the attendees and instructors groups and their role bindings
+func (r *WorkshopReconciler) deleteGroups(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	for _, suffix := range []string{ATTENDEES_GROUP_SUFFIX, INSTRUCTORS_GROUP_SUFFIX} {
+		name := groupName(workshop, suffix)
+
+		groupRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, name, USER_ROLE_BINDING_NAMESPACE_NAME, nil,
+			[]rbac.Subject{openshiftuser.NewGroupSubject(name)}, USER_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
+		if err := kubernetes.DeleteIfExists(r, groupRoleBinding); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s Role Binding", groupRoleBinding.Name)
+
+		group := openshiftuser.NewGroup(workshop, r.Scheme, name, nil, userLabels)
+		if err := kubernetes.DeleteIfExists(r, group); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s Group", group.Name)
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	userv1 "github.com/openshift/api/user/v1"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
+	"github.com/stakater/workshop-operator/common/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const IDENTITY_PROVIDER_NAME_SUFFIX = "-workshop-users"
+
+// identityProviderType returns the type of the identity provider of the attendees, HTPasswd unless set
+func identityProviderType(workshop *workshopv1.Workshop) string {
+	if provider := workshop.Spec.UserDetails.IdentityProvider; provider != nil && provider.Type != "" {
+		return provider.Type
+	}
+	return workshopv1.IdentityProviderHTPasswd
+}
+
+// isExternalIdentityProvider returns true if attendees log in with existing accounts rather than users created by the operator
+func isExternalIdentityProvider(workshop *workshopv1.Workshop) bool {
+	return identityProviderType(workshop) != workshopv1.IdentityProviderHTPasswd
+}
+
+// identityProviderName returns the name of the identity provider of the workshop, identities are qualified with it
+func identityProviderName(workshop *workshopv1.Workshop) string {
+	if provider := workshop.Spec.UserDetails.IdentityProvider; provider != nil && provider.Name != "" {
+		return provider.Name
+	}
+	if !isExternalIdentityProvider(workshop) {
+		return util.ScopedName(workshop, IDENTITY_NAME)
+	}
+	return util.ScopedName(workshop, strings.ToLower(identityProviderType(workshop))+IDENTITY_PROVIDER_NAME_SUFFIX)
+}
+
+// identityProviderNames returns every name an identity provider of the workshop may have in the cluster OAuth config:
+// the generated names of every type, scoped to the workshop, and the names recorded in its status.
+// The entries of a previous type or name are removed when they change, others are left alone.
+func identityProviderNames(workshop *workshopv1.Workshop) []string {
+	names := []string{util.ScopedName(workshop, IDENTITY_NAME)}
+	for _, providerType := range []string{workshopv1.IdentityProviderOpenID, workshopv1.IdentityProviderLDAP, workshopv1.IdentityProviderGitHub} {
+		names = append(names, util.ScopedName(workshop, strings.ToLower(providerType)+IDENTITY_PROVIDER_NAME_SUFFIX))
+	}
+	for _, name := range workshop.Status.IdentityProviders {
+		if !util.StringInSlice(name, names) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// newIdentityProvider returns the identity provider entry of the workshop in the cluster OAuth config
+func newIdentityProvider(workshop *workshopv1.Workshop) (configv1.IdentityProvider, error) {
+	provider := workshop.Spec.UserDetails.IdentityProvider
+	name := identityProviderName(workshop)
+
+	switch identityProviderType(workshop) {
+	case workshopv1.IdentityProviderHTPasswd:
+		return openshiftuser.NewHTPasswdIdentityProvider(name, util.ScopedName(workshop, HTPASSWD_SECRET_NAME)), nil
+	case workshopv1.IdentityProviderOpenID:
+		if provider.OpenID != nil {
+			return openshiftuser.NewOpenIDIdentityProvider(name, provider.OpenID), nil
+		}
+	case workshopv1.IdentityProviderLDAP:
+		if provider.LDAP != nil {
+			return openshiftuser.NewLDAPIdentityProvider(name, provider.LDAP), nil
+		}
+	case workshopv1.IdentityProviderGitHub:
+		if provider.GitHub != nil {
+			return openshiftuser.NewGitHubIdentityProvider(name, provider.GitHub), nil
+		}
+	default:
+		return configv1.IdentityProvider{}, fmt.Errorf("unknown identity provider type %s", provider.Type)
+	}
+	return configv1.IdentityProvider{}, fmt.Errorf("the %s identity provider has no %s configuration", provider.Type, provider.Type)
+}
+
+// loggedInAttendees returns the attendees to provision with an external identity provider.
+// Users only appear on their first login, attendees are provisioned from then on, even if their user is deleted later.
+func (r *WorkshopReconciler) loggedInAttendees(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) ([]workshopv1.Attendee, error) {
+	if !isExternalIdentityProvider(workshop) || !r.Platform.IsOpenShift() {
+		return attendees, nil
+	}
+
+	provisioned := make(map[string]bool)
+	for _, username := range workshop.Status.Attendees {
+		provisioned[username] = true
+	}
+
+	loggedIn := []workshopv1.Attendee{}
+	for _, attendee := range attendees {
+		if !provisioned[attendee.Name] {
+			user := &userv1.User{}
+			if err := r.Get(context.TODO(), types.NamespacedName{Name: attendee.Name}, user); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+		}
+		loggedIn = append(loggedIn, attendee)
+	}
+	return loggedIn, nil
+}
+
+// workshopsForUser maps a User to the Workshops with an external identity provider, one of their attendees may have logged in
+func (r *WorkshopReconciler) workshopsForUser(obj handler.MapObject) []reconcile.Request {
+	if obj.Meta == nil {
+		return nil
+	}
+	workshops := &workshopv1.WorkshopList{}
+	if err := r.List(context.TODO(), workshops); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for i := range workshops.Items {
+		workshop := &workshops.Items[i]
+		if isExternalIdentityProvider(workshop) && !util.StringInSlice(obj.Meta.GetName(), workshop.Status.Attendees) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: workshop.Name, Namespace: workshop.Namespace}})
+		}
+	}
+	return requests
+}
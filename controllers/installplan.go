@@ -12,6 +12,10 @@ import (
 
 // ApproveInstallPlan approves manually the install of a specific CSV
 func (r *WorkshopReconciler) ApproveInstallPlan(clusterServiceVersion string, subscriptionName string, namespace string) error {
+	// There is no InstallPlan to approve while planning
+	if r.Planning() {
+		return nil
+	}
 
 	subscription := &olmv1alpha1.Subscription{}
 	if err := kubernetes.GetObject(r, subscriptionName, namespace, subscription); err != nil {
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkNamingScope refuses a workshop whose namespaces or user names collide with those of an older workshop,
+// as both would provision and delete the same objects. The oldest workshop keeps the scope.
+func (r *WorkshopReconciler) checkNamingScope(workshop *workshopv1.Workshop) error {
+	owner, err := r.namingScopeOwner(workshop)
+	if err != nil {
+		return err
+	}
+
+	if owner != nil {
+		err := fmt.Errorf("the naming scope is used by %s/%s Workshop, set a different spec.naming prefix or suffix",
+			owner.Namespace, owner.Name)
+		util.SetCondition(&workshop.Status.Conditions, workshopv1.Condition{
+			Type:               workshopv1.ConditionNamingScopeUnique,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: workshop.Generation,
+			Reason:             util.ConditionReason.Conflict,
+			Message:            err.Error(),
+		})
+		return err
+	}
+
+	util.SetCondition(&workshop.Status.Conditions, workshopv1.Condition{
+		Type:               workshopv1.ConditionNamingScopeUnique,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workshop.Generation,
+		Reason:             util.ConditionReason.Unique,
+		Message:            "No other workshop uses the naming scope",
+	})
+	return nil
+}
+
+// namingScopeOwner returns the workshop keeping the naming scope of workshop, nil if workshop keeps it
+func (r *WorkshopReconciler) namingScopeOwner(workshop *workshopv1.Workshop) (*workshopv1.Workshop, error) {
+	workshops := &workshopv1.WorkshopList{}
+	if err := r.List(context.TODO(), workshops); err != nil {
+		return nil, err
+	}
+	return oldestInNamingScope(workshop, workshops.Items), nil
+}
+
+// oldestInNamingScope returns the oldest other workshop sharing the naming scope of workshop, nil if there is none.
+// Workshops being deleted keep their scope until their objects are gone.
+func oldestInNamingScope(workshop *workshopv1.Workshop, workshops []workshopv1.Workshop) *workshopv1.Workshop {
+	var owner *workshopv1.Workshop
+	for i := range workshops {
+		other := &workshops[i]
+		if other.UID == workshop.UID || !sharesNamingScope(workshop, other) || !isOlderWorkshop(other, workshop) {
+			continue
+		}
+		if owner == nil || isOlderWorkshop(other, owner) {
+			owner = other
+		}
+	}
+	return owner
+}
+
+// sharesNamingScope returns true if two workshops would create the same namespaces or generate the same user names
+func sharesNamingScope(a *workshopv1.Workshop, b *workshopv1.Workshop) bool {
+	return a.Spec.Naming == b.Spec.Naming || util.UserNamePrefix(a) == util.UserNamePrefix(b)
+}
+
+// isOlderWorkshop returns true if a was created before b, workshops created in the same second are ordered by name
+func isOlderWorkshop(a *workshopv1.Workshop, b *workshopv1.Workshop) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newNamedWorkshop(name string, created time.Time, naming workshopv1.NamingSpec, userNamePrefix string) workshopv1.Workshop {
+	workshop := workshopv1.Workshop{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "workshops",
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+	workshop.Spec.Naming = naming
+	workshop.Spec.UserDetails.UserNamePrefix = userNamePrefix
+	return workshop
+}
+
+func TestOldestInNamingScope(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		workshop workshopv1.Workshop
+		others   []workshopv1.Workshop
+		owner    string
+	}{
+		{
+			name:     "only workshop",
+			workshop: newNamedWorkshop("b", now, workshopv1.NamingSpec{}, ""),
+			owner:    "",
+		},
+		{
+			name:     "older workshop without naming",
+			workshop: newNamedWorkshop("b", now, workshopv1.NamingSpec{}, ""),
+			others:   []workshopv1.Workshop{newNamedWorkshop("a", earlier, workshopv1.NamingSpec{}, "")},
+			owner:    "a",
+		},
+		{
+			name:     "newer workshop without naming",
+			workshop: newNamedWorkshop("a", earlier, workshopv1.NamingSpec{}, ""),
+			others:   []workshopv1.Workshop{newNamedWorkshop("b", now, workshopv1.NamingSpec{}, "")},
+			owner:    "",
+		},
+		{
+			name:     "different prefixes",
+			workshop: newNamedWorkshop("b", now, workshopv1.NamingSpec{Prefix: "blue"}, ""),
+			others:   []workshopv1.Workshop{newNamedWorkshop("a", earlier, workshopv1.NamingSpec{Prefix: "red"}, "")},
+			owner:    "",
+		},
+		{
+			name:     "different suffixes generate the same user names",
+			workshop: newNamedWorkshop("b", now, workshopv1.NamingSpec{Suffix: "blue"}, ""),
+			others:   []workshopv1.Workshop{newNamedWorkshop("a", earlier, workshopv1.NamingSpec{Suffix: "red"}, "")},
+			owner:    "a",
+		},
+		{
+			name:     "different suffixes and user name prefixes",
+			workshop: newNamedWorkshop("b", now, workshopv1.NamingSpec{Suffix: "blue"}, "student"),
+			others:   []workshopv1.Workshop{newNamedWorkshop("a", earlier, workshopv1.NamingSpec{Suffix: "red"}, "")},
+			owner:    "",
+		},
+		{
+			name:     "created in the same second",
+			workshop: newNamedWorkshop("b", now, workshopv1.NamingSpec{}, ""),
+			others:   []workshopv1.Workshop{newNamedWorkshop("a", now, workshopv1.NamingSpec{}, "")},
+			owner:    "a",
+		},
+		{
+			name:     "oldest of several",
+			workshop: newNamedWorkshop("c", now, workshopv1.NamingSpec{}, ""),
+			others: []workshopv1.Workshop{
+				newNamedWorkshop("b", earlier, workshopv1.NamingSpec{}, ""),
+				newNamedWorkshop("a", earlier.Add(-time.Hour), workshopv1.NamingSpec{}, ""),
+			},
+			owner: "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workshops := append([]workshopv1.Workshop{tt.workshop}, tt.others...)
+			owner := ""
+			if found := oldestInNamingScope(&tt.workshop, workshops); found != nil {
+				owner = found.Name
+			}
+			if owner != tt.owner {
+				t.Errorf("oldestInNamingScope() = %q, want %q", owner, tt.owner)
+			}
+		})
+	}
+}
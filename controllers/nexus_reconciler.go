@@ -1,16 +1,13 @@
 package controllers
 
 import (
-	"context"
-	"time"
-
 	"github.com/prometheus/common/log"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
 	"github.com/stakater/workshop-operator/common/kubernetes"
 	nexus "github.com/stakater/workshop-operator/common/nexus"
 
 	"github.com/stakater/workshop-operator/common/util"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -56,64 +53,67 @@ func (r *WorkshopReconciler) addNexus(workshop *workshopv1.Workshop) (reconcile.
 	imageTag := workshop.Spec.Infrastructure.Nexus.Image.Tag
 
 	// Create Project
-	nexusNamespace := kubernetes.NewNamespace(workshop, r.Scheme, NEXUSNAMESPACENAME)
-	if err := r.Create(context.TODO(), nexusNamespace); err != nil && !errors.IsAlreadyExists(err) {
+	nexusNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, NEXUSNAMESPACENAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, nexusNamespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s nexus Project", NEXUSNAMESPACENAME)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s nexus Project", util.ScopedName(workshop, NEXUSNAMESPACENAME))
 	}
 
 	// Create CRD
 	nexusCustomResourceDefinition := kubernetes.NewCustomResourceDefinition(workshop, r.Scheme, NEXUSCRDNAME, NEXUSCRDGROUPNAME, NEXUSCRDKINDNAME, NEXUSCRDLISTKINDNAME, NEXUSCRDPLURALNAME, NEXUSCRDSINGULARNAME, NEXUSCRDVERSIONAME, nil, nil)
-	if err := r.Create(context.TODO(), nexusCustomResourceDefinition); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(nexusCustomResourceDefinition, "Nexus")
+	if op, err := kubernetes.Apply(r, r.Scheme, nexusCustomResourceDefinition); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s nexus Custom Resource Definition", nexusCustomResourceDefinition.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s nexus Custom Resource Definition", nexusCustomResourceDefinition.Name)
 	}
 
 	// Create Service Account
-	nexusServiceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, NEXUSSERVICEACCOUNTNAME, NEXUSNAMESPACENAME, nexuslabels)
-	if err := r.Create(context.TODO(), nexusServiceAccount); err != nil && !errors.IsAlreadyExists(err) {
+	nexusServiceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, NEXUSSERVICEACCOUNTNAME, util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels)
+	if op, err := kubernetes.Apply(r, r.Scheme, nexusServiceAccount); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s nexus Service Account", nexusServiceAccount.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s nexus Service Account", nexusServiceAccount.Name)
 	}
 
 	// Create Cluster Role
-	nexusClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, NEXUSCLUSTERROLENAME, NEXUSNAMESPACENAME, nexuslabels, nexus.NewRules())
-	if err := r.Create(context.TODO(), nexusClusterRole); err != nil && !errors.IsAlreadyExists(err) {
+	nexusClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, util.ScopedName(workshop, NEXUSCLUSTERROLENAME), util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels, nexus.NewRules())
+	if op, err := kubernetes.Apply(r, r.Scheme, nexusClusterRole); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s nexus Cluster Role", nexusClusterRole.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s nexus Cluster Role", nexusClusterRole.Name)
 	}
 
 	// Create Cluster Role Binding
-	nexusClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, NEXUSROLEBINDINGSANAME, NEXUSNAMESPACENAME, nexuslabels, NEXUSSERVICEACCOUNTNAME, NEXUSROLEBINDINGSANAME, NEXUSCLUSTERROLEKINDNAME)
-	if err := r.Create(context.TODO(), nexusClusterRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
+	nexusClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, NEXUSROLEBINDINGSANAME), util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels, NEXUSSERVICEACCOUNTNAME, util.ScopedName(workshop, NEXUSROLEBINDINGSANAME), NEXUSCLUSTERROLEKINDNAME)
+	if op, err := kubernetes.Apply(r, r.Scheme, nexusClusterRoleBinding); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s nexus Cluster Role Binding", nexusClusterRoleBinding.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s nexus Cluster Role Binding", nexusClusterRoleBinding.Name)
 	}
 
 	// Create Operator
-	nexusOperator := kubernetes.NewAnsibleOperatorDeployment(workshop, r.Scheme, NEXUSANSIBLEDEPLOYMENTNAME, NEXUSNAMESPACENAME, nexuslabels, imageName+":"+imageTag, NEXUSSERVICEACCOUNTNAME)
-	if err := r.Create(context.TODO(), nexusOperator); err != nil && !errors.IsAlreadyExists(err) {
+	nexusOperator := kubernetes.NewAnsibleOperatorDeployment(workshop, r.Scheme, NEXUSANSIBLEDEPLOYMENTNAME, util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels, imageName+":"+imageTag, NEXUSSERVICEACCOUNTNAME)
+	if op, err := kubernetes.Apply(r, r.Scheme, nexusOperator); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s nexus Operator", nexusOperator.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s nexus Operator", nexusOperator.Name)
 	}
 
 	// Create Custom Resource
-	nexusCustomResource := nexus.NewCustomResource(workshop, r.Scheme, NEXUSCRNAME, NEXUSNAMESPACENAME, nexuslabels)
-	if err := r.Create(context.TODO(), nexusCustomResource); err != nil && !errors.IsAlreadyExists(err) {
+	nexusCustomResource := nexus.NewCustomResource(workshop, r.Scheme, NEXUSCRNAME, util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels)
+	if op, err := kubernetes.Apply(r, r.Scheme, nexusCustomResource); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s nexus Custom Resource", nexusCustomResource.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s nexus Custom Resource", nexusCustomResource.Name)
 	}
 
 	// Wait for server to be running
-	if !kubernetes.GetK8Client().GetDeploymentStatus(NEXUSDEPLOYMENTNAME, NEXUSNAMESPACENAME) {
-		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 1}, nil
+	if ready, err := kubernetes.IsDeploymentReady(r, NEXUSDEPLOYMENTNAME, util.ScopedName(workshop, NEXUSNAMESPACENAME)); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
+		return reconcile.Result{Requeue: true}, nil
 	}
 
 	//Success
@@ -128,54 +128,63 @@ func (r *WorkshopReconciler) deleteNexus(workshop *workshopv1.Workshop) (reconci
 	imageName := workshop.Spec.Infrastructure.Nexus.Image.Name
 	imageTag := workshop.Spec.Infrastructure.Nexus.Image.Tag
 
-	nexusCustomResource := nexus.NewCustomResource(workshop, r.Scheme, NEXUSCRNAME, NEXUSNAMESPACENAME, nexuslabels)
+	nexusCustomResource := nexus.NewCustomResource(workshop, r.Scheme, NEXUSCRNAME, util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels)
 	// Delete Custom Resource
-	if err := r.Delete(context.TODO(), nexusCustomResource); err != nil {
+	if err := kubernetes.DeleteIfExists(r, nexusCustomResource); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s nexus Custom Resource", nexusCustomResource.Name)
 
-	nexusOperator := kubernetes.NewAnsibleOperatorDeployment(workshop, r.Scheme, NEXUSANSIBLEDEPLOYMENTNAME, NEXUSNAMESPACENAME, nexuslabels, imageName+":"+imageTag, NEXUSSERVICEACCOUNTNAME)
+	nexusOperator := kubernetes.NewAnsibleOperatorDeployment(workshop, r.Scheme, NEXUSANSIBLEDEPLOYMENTNAME, util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels, imageName+":"+imageTag, NEXUSSERVICEACCOUNTNAME)
 	// Delete Operator
-	if err := r.Delete(context.TODO(), nexusOperator); err != nil {
+	if err := kubernetes.DeleteIfExists(r, nexusOperator); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s nexus Operator", nexusOperator.Name)
 
-	nexusClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, NEXUSROLEBINDINGSANAME, NEXUSNAMESPACENAME, nexuslabels, NEXUSSERVICEACCOUNTNAME, NEXUSROLEBINDINGSANAME, NEXUSCLUSTERROLEKINDNAME)
+	nexusClusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, NEXUSROLEBINDINGSANAME), util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels, NEXUSSERVICEACCOUNTNAME, util.ScopedName(workshop, NEXUSROLEBINDINGSANAME), NEXUSCLUSTERROLEKINDNAME)
 	// Delete Cluster Role Binding
-	if err := r.Delete(context.TODO(), nexusClusterRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, nexusClusterRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s nexus Cluster Role Binding", nexusClusterRoleBinding.Name)
 
-	nexusClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, NEXUSCLUSTERROLENAME, NEXUSNAMESPACENAME, nexuslabels, nexus.NewRules())
+	nexusClusterRole := kubernetes.NewClusterRole(workshop, r.Scheme, util.ScopedName(workshop, NEXUSCLUSTERROLENAME), util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels, nexus.NewRules())
 	// Delete Cluster Role
-	if err := r.Delete(context.TODO(), nexusClusterRole); err != nil {
+	if err := kubernetes.DeleteIfExists(r, nexusClusterRole); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s nexus Cluster Role", nexusClusterRole.Name)
 
-	nexusServiceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, NEXUSSERVICEACCOUNTNAME, NEXUSNAMESPACENAME, nexuslabels)
+	nexusServiceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, NEXUSSERVICEACCOUNTNAME, util.ScopedName(workshop, NEXUSNAMESPACENAME), nexuslabels)
 	// Delete Service Account
-	if err := r.Delete(context.TODO(), nexusServiceAccount); err != nil {
+	if err := kubernetes.DeleteIfExists(r, nexusServiceAccount); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s nexus Service Account", nexusServiceAccount.Name)
 
-	nexusCustomResourceDefinition := kubernetes.NewCustomResourceDefinition(workshop, r.Scheme, NEXUSCRDNAME, NEXUSCRDGROUPNAME, NEXUSCRDKINDNAME, NEXUSCRDLISTKINDNAME, NEXUSCRDPLURALNAME, NEXUSCRDSINGULARNAME, NEXUSCRDVERSIONAME, nil, nil)
-	// Delete CRD
-	if err := r.Delete(context.TODO(), nexusCustomResourceDefinition); err != nil {
+	// Deleting the CRD deletes the Nexus resources of every workshop
+	shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.Nexus.Enabled
+	})
+	if err != nil {
 		return reconcile.Result{}, err
 	}
-	log.Infof("Deleted %s nexus Custom Resource Definition", nexusCustomResourceDefinition.Name)
+	if !shared {
+		nexusCustomResourceDefinition := kubernetes.NewCustomResourceDefinition(workshop, r.Scheme, NEXUSCRDNAME, NEXUSCRDGROUPNAME, NEXUSCRDKINDNAME, NEXUSCRDLISTKINDNAME, NEXUSCRDPLURALNAME, NEXUSCRDSINGULARNAME, NEXUSCRDVERSIONAME, nil, nil)
+		// Delete CRD
+		if err := kubernetes.DeleteIfExists(r, nexusCustomResourceDefinition); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s nexus Custom Resource Definition", nexusCustomResourceDefinition.Name)
+	}
 
-	nexusNamespace := kubernetes.NewNamespace(workshop, r.Scheme, NEXUSNAMESPACENAME)
+	nexusNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, NEXUSNAMESPACENAME))
 	// Delete Project
-	if err := r.Delete(context.TODO(), nexusNamespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, nexusNamespace); err != nil {
 		return reconcile.Result{}, err
 	}
-	log.Infof("Deleted %s  nexus Project", NEXUSNAMESPACENAME)
+	log.Infof("Deleted %s  nexus Project", util.ScopedName(workshop, NEXUSNAMESPACENAME))
 	log.Info("Nexus deleted successfully")
 	//Success
 	return reconcile.Result{}, nil
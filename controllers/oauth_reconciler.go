@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
+	"github.com/stakater/workshop-operator/common/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	OAUTH_NAME                   = "cluster"
+	AUTHENTICATION_OPERATOR_NAME = "authentication"
+	// OAUTH_ROLLOUT_DELAY gives the authentication operator time to start rolling out a changed OAuth config
+	OAUTH_ROLLOUT_DELAY = 15 * time.Second
+)
+
+// reconcileOAuth adds the identity provider of the workshop to the cluster OAuth config
+// and waits for the authentication operator to roll it out
+func (r *WorkshopReconciler) reconcileOAuth(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	provider, err := newIdentityProvider(workshop)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	oauth := &configv1.OAuth{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: OAUTH_NAME}, oauth); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get %s OAuth config: %s", OAUTH_NAME, err)
+	}
+	// A custom name must not take over an identity provider the workshop did not add
+	names := identityProviderNames(workshop)
+	if !util.StringInSlice(provider.Name, names) && openshiftuser.HasOtherIdentityProvider(oauth, provider) {
+		return reconcile.Result{}, fmt.Errorf("%s OAuth config already has a %s identity provider not added by the workshop, choose another name",
+			oauth.Name, provider.Name)
+	}
+
+	// Remove the entries the workshop had with another identity provider type or name
+	stale := []string{}
+	for _, name := range names {
+		if name != provider.Name {
+			stale = append(stale, name)
+		}
+	}
+	removed := openshiftuser.RemoveIdentityProvider(oauth, stale...)
+	if merged := openshiftuser.MergeIdentityProvider(oauth, provider); merged || removed {
+		// Update the OAuth config as read, a conflict means another writer changed it in between
+		if err := r.Update(context.TODO(), oauth); err != nil {
+			if errors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			return reconcile.Result{}, err
+		}
+		log.Infof("Merged %s identity provider into %s OAuth config", provider.Name, oauth.Name)
+		workshop.Status.IdentityProviders = []string{provider.Name}
+		if !r.Planning() {
+			return reconcile.Result{RequeueAfter: OAUTH_ROLLOUT_DELAY}, nil
+		}
+	}
+
+	workshop.Status.IdentityProviders = []string{provider.Name}
+
+	// Users can only log in once the OAuth server runs with the identity provider
+	if ready, err := kubernetes.IsClusterOperatorReady(r, AUTHENTICATION_OPERATOR_NAME); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// deleteOAuth removes the identity providers of the workshop from the cluster OAuth config
+func (r *WorkshopReconciler) deleteOAuth(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	oauth := &configv1.OAuth{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: OAUTH_NAME}, oauth); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if openshiftuser.RemoveIdentityProvider(oauth, identityProviderNames(workshop)...) {
+		if err := r.Update(context.TODO(), oauth); err != nil {
+			if errors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			return reconcile.Result{}, err
+		}
+		log.Infof("Removed %s identity providers from %s OAuth config", workshop.Name, oauth.Name)
+	}
+	workshop.Status.IdentityProviders = nil
+
+	//Success
+	return reconcile.Result{}, nil
+}
@@ -1,14 +1,13 @@
 package controllers
 
 import (
-	"context"
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/prometheus/common/log"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
 	"github.com/stakater/workshop-operator/common/kubernetes"
 
 	"github.com/stakater/workshop-operator/common/util"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -41,14 +40,15 @@ func (r *WorkshopReconciler) addPipelines(workshop *workshopv1.Workshop) (reconc
 	// Create Subscription
 	pipelineSubscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, PIPELINES_SUBSCRIPTION_NAME, PIPELINES_SUBSCRIPTION_NAMESPACE_NAME,
 		PIPELINES_SUBSCRIPTION_PACKAGE_NAME, channel, clusterServiceVersion)
-	if err := r.Create(context.TODO(), pipelineSubscription); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(pipelineSubscription, "Pipeline")
+	if op, err := kubernetes.Apply(r, r.Scheme, pipelineSubscription); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Subscription", pipelineSubscription.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Subscription", pipelineSubscription.Name)
 	}
 
 	subscription := &olmv1alpha1.Subscription{}
-	if err := kubernetes.GetObject(r, PIPELINES_SUBSCRIPTION_NAME, PIPELINES_SUBSCRIPTION_NAMESPACE_NAME, subscription); err != nil {
+	if err := kubernetes.GetObject(r, PIPELINES_SUBSCRIPTION_NAME, PIPELINES_SUBSCRIPTION_NAMESPACE_NAME, subscription); err != nil && !r.Planning() {
 		// Approve the installation
 		if err := r.ApproveInstallPlan(clusterServiceVersion, PIPELINES_SUBSCRIPTION_NAME, PIPELINES_SUBSCRIPTION_NAMESPACE_NAME); err != nil {
 			log.Infof("Waiting for Subscription to create InstallPlan for %s", PIPELINES_SUBSCRIPTION_NAME)
@@ -57,19 +57,35 @@ func (r *WorkshopReconciler) addPipelines(workshop *workshopv1.Workshop) (reconc
 		return reconcile.Result{}, err
 	}
 
+	// Wait for Operator to be installed
+	if ready, err := kubernetes.IsSubscriptionReady(r, PIPELINES_SUBSCRIPTION_NAME, PIPELINES_SUBSCRIPTION_NAMESPACE_NAME); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
 	//Success
 	return reconcile.Result{}, nil
 }
 
 // delete Pipelines
 func (r *WorkshopReconciler) deletePipelines(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	// The pipelines operator and its subscription are shared with the other workshops on the cluster
+	if shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.Pipeline.Enabled
+	}); err != nil {
+		return reconcile.Result{}, err
+	} else if shared {
+		return reconcile.Result{}, nil
+	}
+
 	channel := workshop.Spec.Infrastructure.Pipeline.OperatorHub.Channel
 	clusterServiceVersion := workshop.Spec.Infrastructure.Pipeline.OperatorHub.ClusterServiceVersion
 
 	pipelineSubscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, PIPELINES_SUBSCRIPTION_NAME, PIPELINES_SUBSCRIPTION_NAMESPACE_NAME,
 		PIPELINES_SUBSCRIPTION_PACKAGE_NAME, channel, clusterServiceVersion)
 	// Delete Subscription
-	if err := r.Delete(context.TODO(), pipelineSubscription); err != nil {
+	if err := kubernetes.DeleteIfExists(r, pipelineSubscription); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Subscription", pipelineSubscription.Name)
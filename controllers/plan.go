@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/component"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	"github.com/stakater/workshop-operator/common/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+)
+
+const PLAN_CONFIGMAP_SUFFIX = "-plan"
+
+var planLabels = map[string]string{
+	"app.kubernetes.io/part-of": "workshop-plan",
+}
+
+// isPlanMode returns true if the workshop only reports its changes instead of applying them
+func isPlanMode(workshop *workshopv1.Workshop) bool {
+	return workshop.Spec.Plan || workshop.GetAnnotations()[workshopv1.PlanAnnotation] == "true"
+}
+
+// planConfigMapName returns the name of the ConfigMap holding the plan of a workshop
+func planConfigMapName(workshop *workshopv1.Workshop) string {
+	return workshop.Name + PLAN_CONFIGMAP_SUFFIX
+}
+
+// Planning returns true if the reconciler only plans its writes
+func (r *WorkshopReconciler) Planning() bool {
+	return kubernetes.IsPlanning(r.Client)
+}
+
+// planComponents runs every component against a client that records writes instead of applying them,
+// and writes the planned changes to a ConfigMap next to the workshop
+func (r *WorkshopReconciler) planComponents(env *component.Environment) (reconcile.Result, error) {
+	workshop := env.Workshop
+
+	planClient := kubernetes.NewPlanClient(r.Client, r.Scheme)
+	// Copy the reconciler so the planner sees the same settings, only its writes are recorded
+	planner := *r
+	planner.Client = planClient
+	graph, err := component.NewGraph(append(planner.builtinComponents(), component.Registered()...))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	planner.graph = graph
+
+	planEnv := *env
+	planEnv.Client = planClient
+	planEnv.Workshop = workshop.DeepCopy()
+	_, planErr := planner.reconcileComponents(&planEnv)
+
+	changes := planClient.Changes()
+	plan, err := yaml.Marshal(changes)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	summary := fmt.Sprintf("%d to create, %d to update, %d to delete",
+		counts[kubernetes.PLAN_ACTION_CREATE], counts[kubernetes.PLAN_ACTION_UPDATE], counts[kubernetes.PLAN_ACTION_DELETE])
+
+	errs := ""
+	if planErr != nil {
+		errs = planErr.Error()
+	}
+
+	// Create Plan ConfigMap
+	planConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, planConfigMapName(workshop), workshop.Namespace, planLabels,
+		map[string]string{
+			"summary":   summary,
+			"plan.yaml": string(plan),
+			"errors":    errs,
+		})
+	if err := controllerutil.SetControllerReference(workshop, planConfigMap, r.Scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+	if op, err := kubernetes.Apply(r, r.Scheme, planConfigMap); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Plan ConfigMap: %s", planConfigMap.Name, summary)
+	}
+
+	condition := workshopv1.Condition{
+		Type:               workshopv1.ConditionPlanned,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workshop.Generation,
+		Reason:             util.ConditionReason.Planned,
+		Message:            fmt.Sprintf("%s, see ConfigMap %s", summary, planConfigMap.Name),
+	}
+	if planErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = util.ConditionReason.Failed
+		condition.Message = fmt.Sprintf("%s, some components could not be planned: %s",
+			condition.Message, strings.TrimSpace(errs))
+	}
+	util.SetCondition(&workshop.Status.Conditions, condition)
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// clearPlan removes the plan of a workshop once plan mode is disabled
+func (r *WorkshopReconciler) clearPlan(workshop *workshopv1.Workshop) error {
+	if util.FindCondition(workshop.Status.Conditions, workshopv1.ConditionPlanned) == nil {
+		return nil
+	}
+
+	// Delete Plan ConfigMap
+	planConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, planConfigMapName(workshop), workshop.Namespace, planLabels, nil)
+	if err := kubernetes.DeleteIfExists(r, planConfigMap); err != nil {
+		return err
+	}
+	log.Infof("Deleted %s Plan ConfigMap", planConfigMap.Name)
+
+	util.RemoveCondition(&workshop.Status.Conditions, workshopv1.ConditionPlanned)
+	return nil
+}
@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// routeHost returns the host OpenShift generates for a route, Ingresses use the same host so URLs do not depend on the platform
+func routeHost(name string, namespace string, appsHostnameSuffix string) string {
+	return name + "-" + namespace + "." + appsHostnameSuffix
+}
+
+// newRoute exposes a service on the apps domain, with a Route on OpenShift and an Ingress on Kubernetes
+func (r *WorkshopReconciler) newRoute(workshop *workshopv1.Workshop, name string, namespace string, labels map[string]string,
+	serviceName string, port int32, appsHostnameSuffix string) runtime.Object {
+
+	if r.Platform.IsOpenShift() {
+		return kubernetes.NewRoute(workshop, r.Scheme, name, namespace, labels, serviceName, port)
+	}
+	return kubernetes.NewIngress(workshop, r.Scheme, name, namespace, labels, serviceName, port, routeHost(name, namespace, appsHostnameSuffix))
+}
+
+// newSecuredRoute exposes a service on the apps domain over TLS, with a Route on OpenShift and an Ingress on Kubernetes
+func (r *WorkshopReconciler) newSecuredRoute(workshop *workshopv1.Workshop, name string, namespace string, labels map[string]string,
+	serviceName string, port int32, appsHostnameSuffix string) runtime.Object {
+
+	if r.Platform.IsOpenShift() {
+		return kubernetes.NewSecuredRoute(workshop, r.Scheme, name, namespace, labels, serviceName, port)
+	}
+	return kubernetes.NewSecuredIngress(workshop, r.Scheme, name, namespace, labels, serviceName, port, routeHost(name, namespace, appsHostnameSuffix))
+}
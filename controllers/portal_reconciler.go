@@ -2,49 +2,74 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	routev1 "github.com/openshift/api/route/v1"
 	"github.com/prometheus/common/log"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
 	"github.com/stakater/workshop-operator/common/kubernetes"
-	"github.com/stakater/workshop-operator/common/redis"
-	"github.com/stakater/workshop-operator/common/usernamedistribution"
+	"github.com/stakater/workshop-operator/common/portal"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
 	"github.com/stakater/workshop-operator/common/util"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	rbac "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
-	REDIS_VOLUME_SIZE      = "512Mi"
-	REDIS_DEPLOYMENT_NAME  = "redis"
-	REDIS_SERVICE_NAME     = "redis"
-	REDIS_PVC_NAME         = "redis"
-	REDIS_SECRET_NAME      = "redis"
-	PORTAL_SERVICE_NAME    = "portal"
-	PORTAL_DEPLOYMENT_NAME = "portal"
-	PORTAL_ROUTE_NAME      = "portal"
-	PORTAL_ROUTE_PORT      = 8080
+	PORTAL_SERVICE_NAME        = "portal"
+	PORTAL_DEPLOYMENT_NAME     = "portal"
+	PORTAL_ROUTE_NAME          = "portal"
+	PORTAL_ROUTE_PORT          = portal.PORT
+	PORTAL_SERVICEACCOUNT_NAME = "portal"
+	PORTAL_USERS_SECRET_NAME   = "portal-users"
+	PORTAL_ADMIN_SECRET_NAME   = "portal-admin-credentials"
+	PORTAL_TOKEN_SECRET_NAME   = "portal-access-token"
+	PORTAL_TITLE               = "OpenShift Workshops"
+	PORTAL_DURATION            = "168h"
+	DEFAULT_PORTAL_IMAGE       = "docker.io/stakater/workshop-operator:latest"
+	LEGACY_REDIS_NAME          = "redis"
 )
 
-var RedisLabels = map[string]string{
-	"app":                       REDIS_SERVICE_NAME,
-	"app.kubernetes.io/part-of": "portal",
+// portalName prefixes the name of a portal object with the workshop name, like the WorkshopAttendees,
+// as several workshops can share a namespace
+func portalName(workshop *workshopv1.Workshop, name string) string {
+	return workshop.Name + "-" + name
+}
+
+// portalLabels select the portal of one workshop
+func portalLabels(workshop *workshopv1.Workshop) map[string]string {
+	return map[string]string{
+		"app":                       portalName(workshop, PORTAL_SERVICE_NAME),
+		"app.kubernetes.io/part-of": "portal",
+	}
 }
 
-var RedisCredentials = map[string]string{
-	"database-password": "redis",
+// legacyPortalLabels are the labels earlier releases set on Redis and on the username-distribution Deployment
+var legacyPortalLabels = map[string]string{
+	"app":                       LEGACY_REDIS_NAME,
+	"app.kubernetes.io/part-of": "portal",
 }
 
 // reconcilePortal reconciles Portal
-func (r *WorkshopReconciler) reconcilePortal(workshop *workshopv1.Workshop, users int,
+func (r *WorkshopReconciler) reconcilePortal(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee,
 	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
 
-	if result, err := r.addRedis(workshop); util.IsRequeued(result, err) {
+	if result, err := r.deleteLegacyPortal(workshop); util.IsRequeued(result, err) {
 		return result, err
 	}
 
-	if result, err := r.addUpdateUsernameDistribution(workshop, users, appsHostnameSuffix, openshiftConsoleURL); err != nil {
+	if result, err := r.addPortal(workshop, attendees, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
 		return result, err
 	}
 
@@ -52,180 +77,459 @@ func (r *WorkshopReconciler) reconcilePortal(workshop *workshopv1.Workshop, user
 	return reconcile.Result{}, nil
 }
 
-func (r *WorkshopReconciler) addRedis(workshop *workshopv1.Workshop) (reconcile.Result, error) {
-	log.Info("Creating Redis")
-	secret := kubernetes.NewStringDataSecret(workshop, r.Scheme, REDIS_SECRET_NAME, workshop.Namespace, RedisLabels, RedisCredentials)
-	if err := r.Create(context.TODO(), secret); err != nil && !errors.IsAlreadyExists(err) {
+func (r *WorkshopReconciler) addPortal(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee,
+	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
+
+	// Create Users Secret
+	users, err := r.portalUsers(workshop, attendees, appsHostnameSuffix, openshiftConsoleURL)
+	if err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Secret", secret.Name)
 	}
-
-	persistentVolumeClaim := kubernetes.NewPersistentVolumeClaim(workshop, r.Scheme, REDIS_PVC_NAME, workshop.Namespace, RedisLabels, REDIS_VOLUME_SIZE)
-	if err := r.Create(context.TODO(), persistentVolumeClaim); err != nil && !errors.IsAlreadyExists(err) {
+	usersData, err := portal.FormatUsers(users)
+	if err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Persistent Volume Claim", persistentVolumeClaim.Name)
 	}
-
-	// Deploy/Update UsernameDistribution
-	dep := redis.NewDeployment(workshop, r.Scheme, REDIS_DEPLOYMENT_NAME, workshop.Namespace, RedisLabels)
-	if err := r.Create(context.TODO(), dep); err != nil && !errors.IsAlreadyExists(err) {
+	usersSecret := kubernetes.NewStringDataSecret(workshop, r.Scheme, portalName(workshop, PORTAL_USERS_SECRET_NAME), workshop.Namespace, portalLabels(workshop),
+		map[string]string{
+			portal.USERS_KEY: usersData,
+		})
+	if err := controllerutil.SetControllerReference(workshop, usersSecret, r.Scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+	if op, err := kubernetes.Apply(r, r.Scheme, usersSecret); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Deployment", dep.Name)
-	} else if errors.IsAlreadyExists(err) {
-		deploymentFound := &appsv1.Deployment{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: dep.Name, Namespace: workshop.Namespace}, deploymentFound); err != nil {
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Secret", usersSecret.Name)
+	}
+
+	// The admin password is generated unless the workshop references a Secret
+	if workshop.Spec.Portal.AdminPasswordSecretName == "" {
+		if err := r.createPortalAdminSecret(workshop); err != nil {
 			return reconcile.Result{}, err
-		} else if err == nil {
-			if !reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].Env, deploymentFound.Spec.Template.Spec.Containers[0].Env) {
-				// Update Guide
-				if err := r.Update(context.TODO(), dep); err != nil {
-					return reconcile.Result{}, err
-				}
-				log.Infof("Updated %s Deployment", dep.Name)
-			}
 		}
 	}
 
-	// Create Service
-	service := kubernetes.NewService(workshop, r.Scheme, REDIS_SERVICE_NAME, workshop.Namespace, RedisLabels, []string{"http"}, []int32{6379})
-	if err := r.Create(context.TODO(), service); err != nil && !errors.IsAlreadyExists(err) {
+	// The access token is generated unless the workshop references a Secret
+	if workshop.Spec.Portal.AccessTokenSecretName == "" {
+		if err := r.createPortalTokenSecret(workshop); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Create Service Account, the portal only updates the WorkshopAttendees
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, portalName(workshop, PORTAL_SERVICEACCOUNT_NAME), workshop.Namespace, portalLabels(workshop))
+	if op, err := kubernetes.Apply(r, r.Scheme, serviceAccount); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Service", service.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Service Account", serviceAccount.Name)
 	}
 
-	//Success
-	return reconcile.Result{}, nil
-}
+	role := kubernetes.NewRole(workshop, r.Scheme, portalName(workshop, PORTAL_SERVICEACCOUNT_NAME), workshop.Namespace, portalLabels(workshop), portalRules())
+	if op, err := kubernetes.Apply(r, r.Scheme, role); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role", role.Name)
+	}
 
-func (r *WorkshopReconciler) addUpdateUsernameDistribution(workshop *workshopv1.Workshop,
-	users int, appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
-	log.Info("Creating portal")
-	// Deploy/Update UsernameDistribution
-	dep := usernamedistribution.NewDeployment(workshop, r.Scheme, PORTAL_DEPLOYMENT_NAME, RedisLabels, REDIS_SERVICE_NAME, users, appsHostnameSuffix, openshiftConsoleURL)
-	if err := r.Create(context.TODO(), dep); err != nil && !errors.IsAlreadyExists(err) {
+	roleBinding := kubernetes.NewRoleBindingSA(workshop, r.Scheme, portalName(workshop, PORTAL_SERVICEACCOUNT_NAME), workshop.Namespace, portalLabels(workshop),
+		serviceAccount.Name, role.Name, "Role")
+	if op, err := kubernetes.Apply(r, r.Scheme, roleBinding); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Deployment", dep.Name)
-	} else if errors.IsAlreadyExists(err) {
-		deploymentFound := &appsv1.Deployment{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: dep.Name, Namespace: workshop.Namespace}, deploymentFound); err != nil {
-			return reconcile.Result{}, err
-		} else if err == nil {
-			if !reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].Env, deploymentFound.Spec.Template.Spec.Containers[0].Env) ||
-				!reflect.DeepEqual(dep.Spec.Template.Spec.Containers[0].Image, deploymentFound.Spec.Template.Spec.Containers[0].Image) {
-				// Update Guide
-				if err := r.Update(context.TODO(), dep); err != nil {
-					return reconcile.Result{}, err
-				}
-				log.Infof("Updated %s Deployment", dep.Name)
-			}
-		}
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role Binding", roleBinding.Name)
+	}
+
+	// Deploy/Update Portal
+	dep := portal.NewDeployment(workshop, r.Scheme, portalName(workshop, PORTAL_DEPLOYMENT_NAME), workshop.Namespace, portalLabels(workshop), r.portalSettings(workshop))
+	if op, err := kubernetes.Apply(r, r.Scheme, dep); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Deployment", dep.Name)
 	}
 
 	// Create Service
-	service := kubernetes.NewService(workshop, r.Scheme, PORTAL_SERVICE_NAME, workshop.Namespace, RedisLabels, []string{"http"}, []int32{8080})
-	if err := r.Create(context.TODO(), service); err != nil && !errors.IsAlreadyExists(err) {
+	service := kubernetes.NewService(workshop, r.Scheme, portalName(workshop, PORTAL_SERVICE_NAME), workshop.Namespace, portalLabels(workshop), []string{"http"}, []int32{PORTAL_ROUTE_PORT})
+	if op, err := kubernetes.Apply(r, r.Scheme, service); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Service", service.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Service", service.Name)
 	}
 
 	// Create Route
-	route := kubernetes.NewSecuredRoute(workshop, r.Scheme, PORTAL_ROUTE_NAME, workshop.Namespace, RedisLabels, PORTAL_SERVICE_NAME, int32(PORTAL_ROUTE_PORT))
-	if err := r.Create(context.TODO(), route); err != nil && !errors.IsAlreadyExists(err) {
+	route, err := r.newPortalRoute(workshop, appsHostnameSuffix)
+	if err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Route", route.Name)
+	}
+	if op, err := kubernetes.Apply(r, r.Scheme, route); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Route", portalName(workshop, PORTAL_ROUTE_NAME))
 	}
 
 	//Success
 	return reconcile.Result{}, nil
 }
 
-// delete Redis
-func (r *WorkshopReconciler) deletePortal(workshop *workshopv1.Workshop, userID int,
-	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
+// portalSettings returns the settings of the portal Deployment, the workshop spec overrides the defaults
+func (r *WorkshopReconciler) portalSettings(workshop *workshopv1.Workshop) portal.Settings {
+	spec := workshop.Spec.Portal
+	settings := portal.Settings{
+		Image:              spec.Image,
+		Title:              spec.Title,
+		Duration:           spec.Duration,
+		ServiceAccountName: portalName(workshop, PORTAL_SERVICEACCOUNT_NAME),
+		UsersSecretName:    portalName(workshop, PORTAL_USERS_SECRET_NAME),
+		AdminSecretName:    spec.AdminPasswordSecretName,
+		TokenSecretName:    spec.AccessTokenSecretName,
+	}
+	if settings.Image == "" {
+		settings.Image = r.PortalImage
+	}
+	if settings.Image == "" {
+		settings.Image = DEFAULT_PORTAL_IMAGE
+	}
+	if settings.Title == "" {
+		settings.Title = PORTAL_TITLE
+	}
+	if settings.Duration == "" {
+		settings.Duration = PORTAL_DURATION
+	}
+	if settings.AdminSecretName == "" {
+		settings.AdminSecretName = portalName(workshop, PORTAL_ADMIN_SECRET_NAME)
+	}
+	if settings.TokenSecretName == "" {
+		settings.TokenSecretName = portalName(workshop, PORTAL_TOKEN_SECRET_NAME)
+	}
+	return settings
+}
 
-	if result, err := r.deleteUsernameDistribution(workshop, userID, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
-		return result, err
+// portalHost returns the host the portal is exposed on
+func portalHost(workshop *workshopv1.Workshop, appsHostnameSuffix string) string {
+	if workshop.Spec.Portal.Route.Host != "" {
+		return workshop.Spec.Portal.Route.Host
 	}
-	if result, err := r.deleteRedis(workshop); util.IsRequeued(result, err) {
-		return result, err
+	return routeHost(portalName(workshop, PORTAL_ROUTE_NAME), workshop.Namespace, appsHostnameSuffix)
+}
+
+// newPortalRoute exposes the portal on its host with TLS terminated at the router, with the certificate of the workshop if set
+func (r *WorkshopReconciler) newPortalRoute(workshop *workshopv1.Workshop, appsHostnameSuffix string) (runtime.Object, error) {
+	spec := workshop.Spec.Portal.Route
+	host := portalHost(workshop, appsHostnameSuffix)
+
+	if !r.Platform.IsOpenShift() {
+		ingress := kubernetes.NewSecuredIngress(workshop, r.Scheme, portalName(workshop, PORTAL_ROUTE_NAME), workshop.Namespace, portalLabels(workshop),
+			portalName(workshop, PORTAL_SERVICE_NAME), int32(PORTAL_ROUTE_PORT), host)
+		ingress.Spec.TLS[0].SecretName = spec.CertificateSecretName
+		return ingress, nil
 	}
 
-	return reconcile.Result{}, nil
+	route := kubernetes.NewSecuredRoute(workshop, r.Scheme, portalName(workshop, PORTAL_ROUTE_NAME), workshop.Namespace, portalLabels(workshop),
+		portalName(workshop, PORTAL_SERVICE_NAME), int32(PORTAL_ROUTE_PORT))
+	// OpenShift generates the same host unless one is set
+	route.Spec.Host = spec.Host
+	route.Spec.TLS.InsecureEdgeTerminationPolicy = routev1.InsecureEdgeTerminationPolicyRedirect
+	if spec.InsecureEdgeTerminationPolicy != "" {
+		route.Spec.TLS.InsecureEdgeTerminationPolicy = routev1.InsecureEdgeTerminationPolicyType(spec.InsecureEdgeTerminationPolicy)
+	}
+	if spec.CertificateSecretName != "" {
+		secret := &corev1.Secret{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: spec.CertificateSecretName, Namespace: workshop.Namespace}, secret); err != nil {
+			return nil, fmt.Errorf("failed to read the portal certificate: %s", err)
+		}
+		route.Spec.TLS.Certificate = string(secret.Data[corev1.TLSCertKey])
+		route.Spec.TLS.Key = string(secret.Data[corev1.TLSPrivateKeyKey])
+		route.Spec.TLS.CACertificate = string(secret.Data["ca.crt"])
+	}
+	return route, nil
 }
 
-// delete Redis
-func (r *WorkshopReconciler) deleteRedis(workshop *workshopv1.Workshop) (reconcile.Result, error) {
-	log.Info("Deleting Redis")
-	service := kubernetes.NewService(workshop, r.Scheme, REDIS_SERVICE_NAME, workshop.Namespace, RedisLabels, []string{"http"}, []int32{6379})
-	// Delete Service
-	if err := r.Delete(context.TODO(), service); err != nil {
-		return reconcile.Result{}, err
+// recordPortalURL records the URL of the portal in the status, so instructors can share it with the attendees
+func (r *WorkshopReconciler) recordPortalURL(workshop *workshopv1.Workshop) {
+	host := ""
+	var err error
+	if r.Platform.IsOpenShift() {
+		route := &routev1.Route{}
+		if err = r.Get(context.TODO(), types.NamespacedName{Name: portalName(workshop, PORTAL_ROUTE_NAME), Namespace: workshop.Namespace}, route); err == nil {
+			host = route.Spec.Host
+		}
+	} else {
+		ingress := &networkingv1beta1.Ingress{}
+		if err = r.Get(context.TODO(), types.NamespacedName{Name: portalName(workshop, PORTAL_ROUTE_NAME), Namespace: workshop.Namespace}, ingress); err == nil && len(ingress.Spec.Rules) > 0 {
+			host = ingress.Spec.Rules[0].Host
+		}
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		log.Errorf("Failed to read the portal route: %s", err)
+		return
 	}
-	log.Infof("Deleted %s Service", service.Name)
 
-	dep := redis.NewDeployment(workshop, r.Scheme, REDIS_DEPLOYMENT_NAME, workshop.Namespace, RedisLabels)
-	// Delete Deployment
-	if err := r.Delete(context.TODO(), dep); err != nil {
-		return reconcile.Result{}, err
+	workshop.Status.PortalURL = ""
+	if host != "" {
+		workshop.Status.PortalURL = "https://" + host
 	}
-	log.Infof("Deleted %s Deployment ", dep.Name)
+}
 
-	persistentVolumeClaim := kubernetes.NewPersistentVolumeClaim(workshop, r.Scheme, REDIS_PVC_NAME, workshop.Namespace, RedisLabels, REDIS_VOLUME_SIZE)
-	// Delete persistentVolume Claim
-	if err := r.Delete(context.TODO(), persistentVolumeClaim); err != nil {
-		return reconcile.Result{}, err
+// portalRules lets the portal record the assignments in the WorkshopAttendees
+func portalRules() []rbac.PolicyRule {
+	return []rbac.PolicyRule{
+		{
+			APIGroups: []string{
+				workshopv1.GroupVersion.Group,
+			},
+			Resources: []string{
+				"workshopattendees",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"update",
+			},
+		},
+		{
+			APIGroups: []string{
+				workshopv1.GroupVersion.Group,
+			},
+			Resources: []string{
+				"workshopattendees/status",
+			},
+			Verbs: []string{
+				"get",
+				"update",
+			},
+		},
 	}
-	log.Infof("Deleted %s Persistent Volume Claim", persistentVolumeClaim.Name)
+}
 
-	secret := kubernetes.NewStringDataSecret(workshop, r.Scheme, REDIS_SECRET_NAME, workshop.Namespace, RedisLabels, RedisCredentials)
-	// Delete secret
-	if err := r.Delete(context.TODO(), secret); err != nil {
-		return reconcile.Result{}, err
+// createPortalAdminSecret generates the password of the portal admin page, once
+func (r *WorkshopReconciler) createPortalAdminSecret(workshop *workshopv1.Workshop) error {
+	secretFound := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: portalName(workshop, PORTAL_ADMIN_SECRET_NAME), Namespace: workshop.Namespace}, secretFound); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
 	}
-	log.Infof("Deleted %s Secret", secret.Name)
-	log.Info("Deleted Redis successfully")
+
+	password, err := openshiftuser.NewPassword()
+	if err != nil {
+		return err
+	}
+	secret := openshiftuser.NewCredentialsSecret(workshop, r.Scheme, portalName(workshop, PORTAL_ADMIN_SECRET_NAME), workshop.Namespace, portalLabels(workshop), portal.ADMIN_USERNAME, password)
+	if err := controllerutil.SetControllerReference(workshop, secret, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(context.TODO(), secret); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	log.Infof("Created %s Secret", secret.Name)
+	return nil
+}
+
+// createPortalTokenSecret generates the code attendees enter to claim a user, once
+func (r *WorkshopReconciler) createPortalTokenSecret(workshop *workshopv1.Workshop) error {
+	secretFound := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: portalName(workshop, PORTAL_TOKEN_SECRET_NAME), Namespace: workshop.Namespace}, secretFound); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	token, err := openshiftuser.NewPassword()
+	if err != nil {
+		return err
+	}
+	secret := kubernetes.NewStringDataSecret(workshop, r.Scheme, portalName(workshop, PORTAL_TOKEN_SECRET_NAME), workshop.Namespace, portalLabels(workshop),
+		map[string]string{portal.ACCESS_TOKEN_KEY: token})
+	if err := controllerutil.SetControllerReference(workshop, secret, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(context.TODO(), secret); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	log.Infof("Created %s Secret", secret.Name)
+	return nil
+}
+
+// portalUsers returns the users handed out by the portal, with their credentials, projects and component URLs
+func (r *WorkshopReconciler) portalUsers(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee,
+	appsHostnameSuffix string, openshiftConsoleURL string) ([]portal.User, error) {
+
+	users := []portal.User{}
+	for _, attendee := range attendees {
+		password, err := r.userPassword(workshop, attendee.Name)
+		if err != nil {
+			return nil, err
+		}
+		projects, err := util.UserProjectNames(workshop, attendee.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !workshop.Spec.Infrastructure.Project.Enabled {
+			projects = nil
+		}
+		users = append(users, portal.User{
+			Name:     attendee.Name,
+			Password: password,
+			Projects: projects,
+			Links:    portalLinks(workshop, attendee.Name, password, appsHostnameSuffix, openshiftConsoleURL),
+		})
+	}
+	return users, nil
+}
+
+// portalLinks returns the URLs of the enabled components for a user
+func portalLinks(workshop *workshopv1.Workshop, username string, password string,
+	appsHostnameSuffix string, openshiftConsoleURL string) []portal.Link {
+
+	infrastructure := workshop.Spec.Infrastructure
+	links := []portal.Link{}
+	if openshiftConsoleURL != "" {
+		links = append(links, portal.Link{Name: "OpenShift Console", URL: openshiftConsoleURL})
+	}
+	if infrastructure.Guide.Bookbag.Enabled {
+		links = append(links, portal.Link{
+			Name: "Workshop Guide",
+			URL:  "http://" + routeHost(userBookbagName(username), util.ScopedName(workshop, BOOKBAG_NAMESPACE_NAME), appsHostnameSuffix),
+		})
+	}
+	if infrastructure.Guide.Scholars.Enabled {
+		guideURLParameters := url.Values{}
+		guideURLParameters.Set("APPS_HOSTNAME_SUFFIX", appsHostnameSuffix)
+		guideURLParameters.Set("USER_ID", util.UserID(workshop, username))
+		guideURLParameters.Set("OPENSHIFT_PASSWORD", password)
+		guideURLParameters.Set("WORKSHOP_GIT_REPO", workshop.Spec.Source.GitURL)
+		guideURLParameters.Set("WORKSHOP_GIT_REF", workshop.Spec.Source.GitBranch)
+
+		guideNames := []string{}
+		for guideName := range infrastructure.Guide.Scholars.GuideURL {
+			guideNames = append(guideNames, guideName)
+		}
+		sort.Strings(guideNames)
+		for _, guideName := range guideNames {
+			links = append(links, portal.Link{
+				Name: guideName,
+				URL:  fmt.Sprintf("%s?%s", infrastructure.Guide.Scholars.GuideURL[guideName], guideURLParameters.Encode()),
+			})
+		}
+	}
+	if infrastructure.CodeReadyWorkspace.Enabled {
+		links = append(links, portal.Link{Name: "CodeReady Workspaces", URL: "https://" + CHE_CODE_FLAVOR_NAME + "-" + util.ScopedName(workshop, CODEREADY_NAMESPACE_NAME) + "." + appsHostnameSuffix})
+	}
+	if infrastructure.Gitea.Enabled {
+		links = append(links, portal.Link{Name: "Gitea", URL: "https://" + GITEACRNAME + "-" + util.ScopedName(workshop, GITEANAMESPACENAME) + "." + appsHostnameSuffix})
+	}
+	if infrastructure.GitOps.Enabled {
+		links = append(links, portal.Link{Name: "Argo CD", URL: "https://" + ARGOCD_DEPLOYMENT_NAME + "-" + util.ScopedName(workshop, ARGOCD_NAMESPACE_NAME) + "." + appsHostnameSuffix})
+	}
+	return links
+}
+
+// deleteLegacyPortal deletes the Redis and username-distribution portal the built-in portal replaces, once per workshop
+func (r *WorkshopReconciler) deleteLegacyPortal(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	if workshop.Status.LegacyPortalRemoved {
+		return reconcile.Result{}, nil
+	}
+
+	// Objects of the same names the operator did not label are left alone
+	legacy := labels.SelectorFromSet(legacyPortalLabels)
+	for _, obj := range []struct {
+		name   string
+		object runtime.Object
+	}{
+		{name: PORTAL_DEPLOYMENT_NAME, object: &appsv1.Deployment{}},
+		{name: LEGACY_REDIS_NAME, object: &appsv1.Deployment{}},
+		{name: LEGACY_REDIS_NAME, object: &corev1.Service{}},
+		{name: LEGACY_REDIS_NAME, object: &corev1.PersistentVolumeClaim{}},
+		{name: LEGACY_REDIS_NAME, object: &corev1.Secret{}},
+	} {
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: obj.name, Namespace: workshop.Namespace}, obj.object); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return reconcile.Result{}, err
+		}
+		accessor, err := meta.Accessor(obj.object)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !legacy.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		if err := kubernetes.DeleteIfExists(r, obj.object); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted legacy portal %s", obj.name)
+	}
+
+	workshop.Status.LegacyPortalRemoved = true
 
 	//Success
 	return reconcile.Result{}, nil
 }
 
-// delete UsernameDistribution
-func (r *WorkshopReconciler) deleteUsernameDistribution(workshop *workshopv1.Workshop,
-	users int, appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
+// delete Portal
+func (r *WorkshopReconciler) deletePortal(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee,
+	appsHostnameSuffix string, openshiftConsoleURL string) (reconcile.Result, error) {
 
-	log.Info("Deleting  portal ")
-	route := kubernetes.NewSecuredRoute(workshop, r.Scheme, PORTAL_ROUTE_NAME, workshop.Namespace, RedisLabels, PORTAL_SERVICE_NAME, int32(PORTAL_ROUTE_PORT))
+	log.Info("Deleting portal")
+	route := r.newSecuredRoute(workshop, portalName(workshop, PORTAL_ROUTE_NAME), workshop.Namespace, portalLabels(workshop), portalName(workshop, PORTAL_SERVICE_NAME), int32(PORTAL_ROUTE_PORT), appsHostnameSuffix)
 	// Delete Route
-	if err := r.Delete(context.TODO(), route); err != nil {
+	if err := kubernetes.DeleteIfExists(r, route); err != nil {
 		return reconcile.Result{}, err
 	}
-	log.Infof("Deleted %s Route", route.Name)
+	log.Infof("Deleted %s Route", portalName(workshop, PORTAL_ROUTE_NAME))
 
-	service := kubernetes.NewService(workshop, r.Scheme, PORTAL_SERVICE_NAME, workshop.Namespace, RedisLabels, []string{"http"}, []int32{8080})
+	service := kubernetes.NewService(workshop, r.Scheme, portalName(workshop, PORTAL_SERVICE_NAME), workshop.Namespace, portalLabels(workshop), []string{"http"}, []int32{PORTAL_ROUTE_PORT})
 	// Delete Service
-	if err := r.Delete(context.TODO(), service); err != nil {
+	if err := kubernetes.DeleteIfExists(r, service); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Service", service.Name)
 
-	dep := usernamedistribution.NewDeployment(workshop, r.Scheme, PORTAL_DEPLOYMENT_NAME, RedisLabels, REDIS_SERVICE_NAME, users, appsHostnameSuffix, openshiftConsoleURL)
-	deploymentFound := &appsv1.Deployment{}
-	deploymentErr := r.Get(context.TODO(), types.NamespacedName{Name: dep.Name, Namespace: workshop.Namespace}, deploymentFound)
-	if deploymentErr == nil {
-		// Delete Deployment
-		if err := r.Delete(context.TODO(), dep); err != nil {
+	dep := portal.NewDeployment(workshop, r.Scheme, portalName(workshop, PORTAL_DEPLOYMENT_NAME), workshop.Namespace, portalLabels(workshop), r.portalSettings(workshop))
+	// Delete Deployment
+	if err := kubernetes.DeleteIfExists(r, dep); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Deployment", dep.Name)
+
+	roleBinding := kubernetes.NewRoleBindingSA(workshop, r.Scheme, portalName(workshop, PORTAL_SERVICEACCOUNT_NAME), workshop.Namespace, portalLabels(workshop),
+		portalName(workshop, PORTAL_SERVICEACCOUNT_NAME), portalName(workshop, PORTAL_SERVICEACCOUNT_NAME), "Role")
+	// Delete Role Binding
+	if err := kubernetes.DeleteIfExists(r, roleBinding); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Role Binding", roleBinding.Name)
+
+	role := kubernetes.NewRole(workshop, r.Scheme, portalName(workshop, PORTAL_SERVICEACCOUNT_NAME), workshop.Namespace, portalLabels(workshop), portalRules())
+	// Delete Role
+	if err := kubernetes.DeleteIfExists(r, role); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Role", role.Name)
+
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, portalName(workshop, PORTAL_SERVICEACCOUNT_NAME), workshop.Namespace, portalLabels(workshop))
+	// Delete Service Account
+	if err := kubernetes.DeleteIfExists(r, serviceAccount); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Service Account", serviceAccount.Name)
+
+	// Delete Secrets
+	for _, obj := range []runtime.Object{
+		kubernetes.NewStringDataSecret(workshop, r.Scheme, portalName(workshop, PORTAL_ADMIN_SECRET_NAME), workshop.Namespace, portalLabels(workshop), nil),
+		kubernetes.NewStringDataSecret(workshop, r.Scheme, portalName(workshop, PORTAL_TOKEN_SECRET_NAME), workshop.Namespace, portalLabels(workshop), nil),
+		kubernetes.NewStringDataSecret(workshop, r.Scheme, portalName(workshop, PORTAL_USERS_SECRET_NAME), workshop.Namespace, portalLabels(workshop), nil),
+	} {
+		if err := kubernetes.DeleteIfExists(r, obj); err != nil {
 			return reconcile.Result{}, err
 		}
-		log.Infof("Deleted %s Deployment", dep.Name)
 	}
-	log.Info(" Deleted portal  successfully")
+
+	if result, err := r.deleteLegacyPortal(workshop); util.IsRequeued(result, err) {
+		return result, err
+	}
+	log.Info("Deleted portal successfully")
+
 	//Success
 	return reconcile.Result{}, nil
 }
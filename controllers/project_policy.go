@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	PROJECT_QUOTA_NAME               = "workshop-quota"
+	PROJECT_LIMIT_RANGE_NAME         = "workshop-limits"
+	DENY_ALL_POLICY_NAME             = "deny-by-default"
+	ALLOW_SAME_NAMESPACE_POLICY_NAME = "allow-same-namespace"
+	ALLOW_INGRESS_POLICY_NAME        = "allow-from-openshift-ingress"
+	OPENSHIFT_POLICY_GROUP_LABEL     = "network.openshift.io/policy-group"
+	OPENSHIFT_INGRESS_POLICY_GROUP   = "ingress"
+)
+
+// reconcileProjectPolicy applies the quota, limit range and network policies of a user project,
+// and deletes those no longer set in the project policy
+func (r *WorkshopReconciler) reconcileProjectPolicy(workshop *workshopv1.Workshop, projectName string) (reconcile.Result, error) {
+	policy := workshop.Spec.Infrastructure.Project.Policy
+	if policy == nil {
+		policy = &workshopv1.ProjectPolicySpec{}
+	}
+
+	hard, err := projectQuota(policy.Quota)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	resourceQuota := kubernetes.NewResourceQuota(workshop, r.Scheme, PROJECT_QUOTA_NAME, projectName, projectLabels, hard)
+	if result, err := r.applyProjectPolicyObject(resourceQuota, len(hard) > 0, "Resource Quota"); err != nil {
+		return result, err
+	}
+
+	limits, err := projectLimits(policy.LimitRange)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	limitRange := kubernetes.NewLimitRange(workshop, r.Scheme, PROJECT_LIMIT_RANGE_NAME, projectName, projectLabels, limits)
+	if result, err := r.applyProjectPolicyObject(limitRange, len(limits) > 0, "Limit Range"); err != nil {
+		return result, err
+	}
+
+	// Deny all ingress traffic, then allow it from the pods of the project
+	denyAllPolicy := kubernetes.NewNetworkPolicy(workshop, r.Scheme, DENY_ALL_POLICY_NAME, projectName, projectLabels, nil)
+	if result, err := r.applyProjectPolicyObject(denyAllPolicy, policy.NetworkIsolation, "Network Policy"); err != nil {
+		return result, err
+	}
+
+	sameNamespacePolicy := kubernetes.NewNetworkPolicy(workshop, r.Scheme, ALLOW_SAME_NAMESPACE_POLICY_NAME, projectName, projectLabels,
+		[]networkingv1.NetworkPolicyIngressRule{
+			{From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}},
+		})
+	if result, err := r.applyProjectPolicyObject(sameNamespacePolicy, policy.NetworkIsolation, "Network Policy"); err != nil {
+		return result, err
+	}
+
+	// Routes are served by the OpenShift router, from outside the project
+	ingressPolicy := kubernetes.NewNetworkPolicy(workshop, r.Scheme, ALLOW_INGRESS_POLICY_NAME, projectName, projectLabels,
+		[]networkingv1.NetworkPolicyIngressRule{
+			{From: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{OPENSHIFT_POLICY_GROUP_LABEL: OPENSHIFT_INGRESS_POLICY_GROUP},
+			}}}},
+		})
+	if result, err := r.applyProjectPolicyObject(ingressPolicy, policy.NetworkIsolation && r.Platform.IsOpenShift(), "Network Policy"); err != nil {
+		return result, err
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// applyProjectPolicyObject applies obj if it is enabled, or deletes it
+func (r *WorkshopReconciler) applyProjectPolicyObject(obj runtime.Object, enabled bool, kind string) (reconcile.Result, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !enabled {
+		if err := kubernetes.DeleteIfExists(r, obj); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+	if op, err := kubernetes.Apply(r, r.Scheme, obj); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s %s in %s", accessor.GetName(), kind, accessor.GetNamespace())
+	}
+	return reconcile.Result{}, nil
+}
+
+// projectQuota returns the hard limits of the quota of a user project
+func projectQuota(quota *workshopv1.ProjectQuotaSpec) (corev1.ResourceList, error) {
+	hard := corev1.ResourceList{}
+	if quota == nil {
+		return hard, nil
+	}
+
+	if err := addQuantities(hard, map[corev1.ResourceName]string{
+		corev1.ResourceRequestsCPU:     quota.CPU,
+		corev1.ResourceRequestsMemory:  quota.Memory,
+		corev1.ResourceLimitsCPU:       quota.LimitsCPU,
+		corev1.ResourceLimitsMemory:    quota.LimitsMemory,
+		corev1.ResourceRequestsStorage: quota.Storage,
+	}); err != nil {
+		return nil, fmt.Errorf("invalid project quota: %s", err)
+	}
+	if quota.Pods > 0 {
+		hard[corev1.ResourcePods] = *resource.NewQuantity(int64(quota.Pods), resource.DecimalSI)
+	}
+	if quota.PersistentVolumeClaims > 0 {
+		hard[corev1.ResourcePersistentVolumeClaims] = *resource.NewQuantity(int64(quota.PersistentVolumeClaims), resource.DecimalSI)
+	}
+	return hard, nil
+}
+
+// projectLimits returns the container limits of the limit range of a user project
+func projectLimits(limitRange *workshopv1.ProjectLimitRangeSpec) ([]corev1.LimitRangeItem, error) {
+	if limitRange == nil {
+		return nil, nil
+	}
+
+	item := corev1.LimitRangeItem{
+		Type:           corev1.LimitTypeContainer,
+		Default:        corev1.ResourceList{},
+		DefaultRequest: corev1.ResourceList{},
+		Max:            corev1.ResourceList{},
+	}
+	if err := addQuantities(item.Default, map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    limitRange.DefaultCPU,
+		corev1.ResourceMemory: limitRange.DefaultMemory,
+	}); err != nil {
+		return nil, fmt.Errorf("invalid project limit range default: %s", err)
+	}
+	if err := addQuantities(item.DefaultRequest, map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    limitRange.DefaultRequestCPU,
+		corev1.ResourceMemory: limitRange.DefaultRequestMemory,
+	}); err != nil {
+		return nil, fmt.Errorf("invalid project limit range default request: %s", err)
+	}
+	if err := addQuantities(item.Max, map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    limitRange.MaxCPU,
+		corev1.ResourceMemory: limitRange.MaxMemory,
+	}); err != nil {
+		return nil, fmt.Errorf("invalid project limit range max: %s", err)
+	}
+	if len(item.Default) == 0 && len(item.DefaultRequest) == 0 && len(item.Max) == 0 {
+		return nil, nil
+	}
+	return []corev1.LimitRangeItem{item}, nil
+}
+
+// addQuantities parses the quantities that are set and adds them to resources
+func addQuantities(resources corev1.ResourceList, quantities map[corev1.ResourceName]string) error {
+	for name, value := range quantities {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("%s %q: %s", name, value, err)
+		}
+		resources[name] = quantity
+	}
+	return nil
+}
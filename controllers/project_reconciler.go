@@ -11,7 +11,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -24,34 +27,72 @@ const (
 	PROJECT_SERVICEACCOUNT_NAME   = "default"
 	DEFAULT_ROLE_BINDING_NAME     = "view"
 	ARGOCD_EDIT_ROLE_BINDING_NAME = "edit"
+	DISPLAY_NAME_ANNOTATION       = "openshift.io/display-name"
 )
 
 // Reconciling Project
-func (r *WorkshopReconciler) reconcileProject(workshop *workshopv1.Workshop, users int) (reconcile.Result, error) {
+func (r *WorkshopReconciler) reconcileProject(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, removed []string) (reconcile.Result, error) {
 	enabledProject := workshop.Spec.Infrastructure.Project.Enabled
 
-	id := 1
-	for {
-		username := fmt.Sprintf("user%d", id)
-		stagingProjectName := fmt.Sprintf("%s%d", workshop.Spec.Infrastructure.Project.StagingName, id)
-
-		if id <= users && enabledProject {
-			if workshop.Spec.Infrastructure.Project.StagingName != "" {
-				if result, err := r.addProject(workshop, stagingProjectName, username); util.IsRequeued(result, err) {
+	// Project names by user, a name generated for two users would give one of them the projects of the other
+	owners := make(map[string]string)
+	if enabledProject {
+		instructors := r.instructorSubjects(workshop, attendees)
+		for _, attendee := range attendees {
+			projects, err := util.UserProjects(workshop, attendee.Name)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			for _, project := range projects {
+				if owner, ok := owners[project.Name]; ok {
+					return reconcile.Result{}, fmt.Errorf("project %s is generated for both %s and %s, its name pattern must refer to .UserName or .UserID", project.Name, owner, attendee.Name)
+				}
+				owners[project.Name] = attendee.Name
+				if result, err := r.addProject(workshop, project, attendee.Name, instructors); util.IsRequeued(result, err) {
 					return result, err
 				}
 			}
+		}
+	}
 
-		} else {
-			stagingProjectNamespace := kubernetes.NewNamespace(workshop, r.Scheme, stagingProjectName)
-			stagingProjectNamespaceFound := &corev1.Namespace{}
-			stagingProjectNamespaceErr := r.Get(context.TODO(), types.NamespacedName{Name: stagingProjectNamespace.Name}, stagingProjectNamespaceFound)
-
-			if stagingProjectNamespaceErr != nil && errors.IsNotFound(stagingProjectNamespaceErr) {
-				break
+	// Delete the projects of the attendees removed from the roster
+	for _, username := range removed {
+		projectNames, err := util.UserProjectNames(workshop, username)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		for _, projectName := range projectNames {
+			if result, err := r.deleteProjectNamespace(workshop, projectName, username); util.IsRequeued(result, err) {
+				return result, err
 			}
 		}
-		id++
+	}
+
+	// Delete the projects of templates that were removed or renamed
+	if result, err := r.pruneProjects(workshop, owners); util.IsRequeued(result, err) {
+		return result, err
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// pruneProjects deletes the projects created for the workshop that are not in keep, namespaces that only carry
+// its labels were not created by the workshop and are left
+func (r *WorkshopReconciler) pruneProjects(workshop *workshopv1.Workshop, keep map[string]string) (reconcile.Result, error) {
+	labelSelector := labels.SelectorFromSet(kubernetes.WorkshopLabels(workshop, projectLabels))
+	listNamespaces := &corev1.NamespaceList{}
+	if err := r.List(context.TODO(), listNamespaces, &client.ListOptions{LabelSelector: labelSelector}); err != nil {
+		log.Errorf("Failed to get list of Projects, filtered by labelSelector {%s}, {%s}", labelSelector, err)
+		return reconcile.Result{}, err
+	}
+	for _, namespace := range listNamespaces.Items {
+		if _, ok := keep[namespace.Name]; ok || !kubernetes.IsCreatedBy(workshop, &namespace) {
+			continue
+		}
+		if result, err := r.deleteProjectNamespace(workshop, namespace.Name, namespace.Labels[kubernetes.WORKSHOP_USER_LABEL]); util.IsRequeued(result, err) {
+			return result, err
+		}
 	}
 
 	//Success
@@ -59,16 +100,50 @@ func (r *WorkshopReconciler) reconcileProject(workshop *workshopv1.Workshop, use
 }
 
 // Add Project
-func (r *WorkshopReconciler) addProject(workshop *workshopv1.Workshop, projectName string, username string) (reconcile.Result, error) {
+func (r *WorkshopReconciler) addProject(workshop *workshopv1.Workshop, project util.UserProject, username string, instructors []rbac.Subject) (reconcile.Result, error) {
 	log.Infoln("Creating Project ")
-	projectNamespace := kubernetes.NewNamespace(workshop, r.Scheme, projectName)
-	if err := r.Create(context.TODO(), projectNamespace); err != nil && !errors.IsAlreadyExists(err) {
-		return reconcile.Result{}, err
-	} else if err == nil {
+	namespaceLabels := map[string]string{}
+	for key, value := range project.Template.Labels {
+		namespaceLabels[key] = value
+	}
+	for key, value := range projectLabels {
+		namespaceLabels[key] = value
+	}
+	namespaceLabels[kubernetes.WORKSHOP_USER_LABEL] = username
+
+	projectNamespace := kubernetes.NewNamespace(workshop, r.Scheme, project.Name)
+	projectNamespace.Labels = kubernetes.WorkshopLabels(workshop, namespaceLabels)
+	if project.DisplayName != "" {
+		projectNamespace.Annotations = map[string]string{DISPLAY_NAME_ANNOTATION: project.DisplayName}
+	}
+
+	// The created-by annotation is only set on create, a namespace that existed before is left to its owner
+	namespaceFound := &corev1.Namespace{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: projectNamespace.Name}, namespaceFound); errors.IsNotFound(err) {
+		namespaceFound = projectNamespace.DeepCopy()
+		namespaceFound.Annotations = map[string]string{kubernetes.WORKSHOP_CREATED_ANNOTATION: kubernetes.CreatedBy(workshop)}
+		for key, value := range projectNamespace.Annotations {
+			namespaceFound.Annotations[key] = value
+		}
+		if err := r.Create(context.TODO(), namespaceFound); err != nil {
+			return reconcile.Result{}, err
+		}
 		log.Infof("Created %s Namespace", projectNamespace.Name)
+	} else if err != nil {
+		return reconcile.Result{}, err
+	} else if !kubernetes.IsCreatedBy(workshop, namespaceFound) {
+		return reconcile.Result{}, fmt.Errorf("namespace %s already exists and was not created by the workshop, choose another project name", projectNamespace.Name)
+	} else if op, err := kubernetes.Apply(r, r.Scheme, projectNamespace); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Namespace", projectNamespace.Name)
+	}
+
+	if result, err := r.manageRoles(workshop, projectNamespace.Name, username, project.Template.RoleBindings, instructors); util.IsRequeued(result, err) {
+		return result, err
 	}
 
-	if result, err := r.manageRoles(workshop, projectNamespace.Name, username); err != nil {
+	if result, err := r.reconcileProjectPolicy(workshop, projectNamespace.Name); util.IsRequeued(result, err) {
 		return result, err
 	}
 
@@ -77,81 +152,133 @@ func (r *WorkshopReconciler) addProject(workshop *workshopv1.Workshop, projectNa
 }
 
 // create Manage Roles
-func (r *WorkshopReconciler) manageRoles(workshop *workshopv1.Workshop, projectName string, username string) (reconcile.Result, error) {
+func (r *WorkshopReconciler) manageRoles(workshop *workshopv1.Workshop, projectName string, username string,
+	roleBindings []workshopv1.ProjectRoleBindingSpec, instructors []rbac.Subject) (reconcile.Result, error) {
 
 	users := []rbac.Subject{}
-	userSubject := rbac.Subject{
-		Kind: rbac.UserKind,
-		Name: username,
-	}
+	userSubject := r.userSubject(workshop, username)
 
 	users = append(users, userSubject)
 
-	// Create User Role Binding
-	userRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, username+"-project", projectName, projectLabels,
-		users, USER_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
-	if err := r.Create(context.TODO(), userRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
-		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Role Binding", userRoleBinding.Name)
+	// Role Bindings of the project, the others are left over from earlier templates
+	applied := make(map[string]bool)
+
+	// Create User Role Bindings
+	if len(roleBindings) == 0 {
+		roleBindings = []workshopv1.ProjectRoleBindingSpec{{Name: username + "-project", ClusterRole: USER_ROLE_BINDING_NAME}}
+	}
+	for _, binding := range roleBindings {
+		name := binding.Name
+		if name == "" {
+			name = username + "-" + binding.ClusterRole
+		}
+		userRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, name, projectName, projectLabels,
+			users, binding.ClusterRole, KIND_CLUSTER_ROLE)
+		if result, err := r.applyProjectRoleBinding(userRoleBinding); util.IsRequeued(result, err) {
+			return result, err
+		}
+		applied[userRoleBinding.Name] = true
+	}
+
+	// Create Instructors Role Binding
+	instructorsRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, username+"-instructors", projectName, projectLabels,
+		instructors, instructorProjectRole(workshop), KIND_CLUSTER_ROLE)
+	if len(instructors) > 0 {
+		if result, err := r.applyProjectRoleBinding(instructorsRoleBinding); util.IsRequeued(result, err) {
+			return result, err
+		}
+		applied[instructorsRoleBinding.Name] = true
 	}
 
 	// Create Default Role Binding
 	defaultRoleBinding := kubernetes.NewRoleBindingSA(workshop, r.Scheme, username+"-default", projectName, projectLabels,
 		PROJECT_SERVICEACCOUNT_NAME, DEFAULT_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
-	if err := r.Create(context.TODO(), defaultRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, defaultRoleBinding); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Role Binding", defaultRoleBinding.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role Binding", defaultRoleBinding.Name)
 	}
 
 	argocdUsers := []rbac.Subject{}
 	userSubject = rbac.Subject{
 		Kind: rbac.UserKind,
-		Name: "system:serviceaccount:argocd:argocd-argocd-application-controller",
+		Name: argocdControllerUser(workshop),
 	}
 	argocdUsers = append(argocdUsers, userSubject)
 
 	//Create Argo CD Role Binding
 	argocdEditRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme,
 		username+"-argocd", projectName, projectLabels, argocdUsers, ARGOCD_EDIT_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
-	if err := r.Create(context.TODO(), argocdEditRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, argocdEditRoleBinding); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Role Binding", argocdEditRoleBinding.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role Binding", argocdEditRoleBinding.Name)
+	}
+	applied[defaultRoleBinding.Name] = true
+	applied[argocdEditRoleBinding.Name] = true
+
+	// Delete the Role Bindings no longer in the project template
+	labelSelector := labels.SelectorFromSet(kubernetes.WorkshopLabels(workshop, projectLabels))
+	listRoleBindings := &rbac.RoleBindingList{}
+	listOps := &client.ListOptions{
+		Namespace:     projectName,
+		LabelSelector: labelSelector,
+	}
+	if err := r.List(context.TODO(), listRoleBindings, listOps); err != nil {
+		log.Errorf("Failed to get list of Role Bindings, filtered by labelSelector {%s}, {%s}", labelSelector, err)
+		return reconcile.Result{}, err
+	}
+	for i := range listRoleBindings.Items {
+		roleBinding := &listRoleBindings.Items[i]
+		if applied[roleBinding.Name] {
+			continue
+		}
+		if err := kubernetes.DeleteIfExists(r, roleBinding); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s Role Binding", roleBinding.Name)
 	}
 
 	//Success
 	return reconcile.Result{}, nil
 }
 
-// Delete Project
-func (r *WorkshopReconciler) deleteProject(workshop *workshopv1.Workshop, userId int) (reconcile.Result, error) {
-	enabledProject := workshop.Spec.Infrastructure.Project.Enabled
-	log.Infoln("Deleting Project ")
-	id := 1
-	for {
-		username := fmt.Sprintf("user%d", id)
-		stagingProjectName := fmt.Sprintf("%s%d", workshop.Spec.Infrastructure.Project.StagingName, id)
-
-		if id >= userId && enabledProject {
-			if workshop.Spec.Infrastructure.Project.StagingName != "" {
-				if result, err := r.deleteProjectNamespace(workshop, stagingProjectName, username); util.IsRequeued(result, err) {
-					return result, err
-				}
+// applyProjectRoleBinding applies a Role Binding, the role of a Role Binding cannot change so it is recreated when its role changed
+func (r *WorkshopReconciler) applyProjectRoleBinding(roleBinding *rbac.RoleBinding) (reconcile.Result, error) {
+	if op, err := kubernetes.Apply(r, r.Scheme, roleBinding); err != nil {
+		if errors.IsInvalid(err) {
+			if err := kubernetes.DeleteIfExists(r, roleBinding); err != nil {
+				return reconcile.Result{}, err
 			}
+			return reconcile.Result{Requeue: true}, nil
+		}
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role Binding", roleBinding.Name)
+	}
 
-		} else {
-			stagingProjectNamespace := kubernetes.NewNamespace(workshop, r.Scheme, stagingProjectName)
-			stagingProjectNamespaceFound := &corev1.Namespace{}
-			stagingProjectNamespaceErr := r.Get(context.TODO(), types.NamespacedName{Name: stagingProjectNamespace.Name}, stagingProjectNamespaceFound)
+	//Success
+	return reconcile.Result{}, nil
+}
 
-			if stagingProjectNamespaceErr != nil && errors.IsNotFound(stagingProjectNamespaceErr) {
-				break
+// Delete Project
+func (r *WorkshopReconciler) deleteProject(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
+	log.Infoln("Deleting Project ")
+	for _, attendee := range attendees {
+		projectNames, err := util.UserProjectNames(workshop, attendee.Name)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		for _, projectName := range projectNames {
+			if result, err := r.deleteProjectNamespace(workshop, projectName, attendee.Name); util.IsRequeued(result, err) {
+				return result, err
 			}
 		}
+	}
 
-		id++
+	// Delete the projects of templates that were removed or renamed
+	if result, err := r.pruneProjects(workshop, nil); util.IsRequeued(result, err) {
+		return result, err
 	}
 
 	//Success
@@ -167,8 +294,17 @@ func (r *WorkshopReconciler) deleteProjectNamespace(workshop *workshopv1.Worksho
 		return result, err
 	}
 
-	// Delete a Project
-	if err := r.Delete(context.TODO(), projectNamespace); err != nil {
+	// Delete a Project, unless it existed before the workshop
+	namespaceFound := &corev1.Namespace{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: projectName}, namespaceFound); errors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	} else if !kubernetes.IsCreatedBy(workshop, namespaceFound) {
+		log.Infof("Kept %s Namespace, it was not created by the workshop", projectName)
+		return reconcile.Result{}, nil
+	}
+	if err := kubernetes.DeleteIfExists(r, projectNamespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Namespace ", projectNamespace.Name)
@@ -182,24 +318,21 @@ func (r *WorkshopReconciler) deleteProjectNamespace(workshop *workshopv1.Worksho
 func (r *WorkshopReconciler) deleteManageRoles(workshop *workshopv1.Workshop, projectName string, username string) (reconcile.Result, error) {
 
 	users := []rbac.Subject{}
-	userSubject := rbac.Subject{
-		Kind: rbac.UserKind,
-		Name: username,
-	}
+	userSubject := r.userSubject(workshop, username)
 
 	users = append(users, userSubject)
 
 	argocdUsers := []rbac.Subject{}
 	userSubject = rbac.Subject{
 		Kind: rbac.UserKind,
-		Name: "system:serviceaccount:argocd:argocd-argocd-application-controller",
+		Name: argocdControllerUser(workshop),
 	}
 	argocdUsers = append(argocdUsers, userSubject)
 
 	argocdEditRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme,
 		username+"-argocd", projectName, projectLabels, argocdUsers, ARGOCD_EDIT_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
 	// Delete Argo CD Role Binding
-	if err := r.Delete(context.TODO(), argocdEditRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, argocdEditRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Role Binding", argocdEditRoleBinding.Name)
@@ -207,15 +340,23 @@ func (r *WorkshopReconciler) deleteManageRoles(workshop *workshopv1.Workshop, pr
 	defaultRoleBinding := kubernetes.NewRoleBindingSA(workshop, r.Scheme, username+"-default", projectName, projectLabels,
 		PROJECT_SERVICEACCOUNT_NAME, DEFAULT_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
 	// Delete default Role Binding
-	if err := r.Delete(context.TODO(), defaultRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, defaultRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Role Binding", defaultRoleBinding.Name)
 
+	instructorsRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, username+"-instructors", projectName, projectLabels,
+		nil, instructorProjectRole(workshop), KIND_CLUSTER_ROLE)
+	// Delete instructors Role Binding
+	if err := kubernetes.DeleteIfExists(r, instructorsRoleBinding); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Role Binding", instructorsRoleBinding.Name)
+
 	userRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, username+"-project", projectName, projectLabels,
 		users, USER_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
 	// Delete user Role Binding
-	if err := r.Delete(context.TODO(), userRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, userRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Role Binding", userRoleBinding.Name)
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	openshiftuser "github.com/stakater/workshop-operator/common/user"
+	"github.com/stakater/workshop-operator/common/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// resolveAttendees returns the attendees of the workshop sorted by name,
+// from the roster if one is set or generated from the user name prefix and the number of users
+func (r *WorkshopReconciler) resolveAttendees(workshop *workshopv1.Workshop) ([]workshopv1.Attendee, error) {
+	roster := workshop.Spec.UserDetails.Roster
+	if roster == nil {
+		identity := util.NewUserIdentity(workshop)
+		attendees := []workshopv1.Attendee{}
+		for id := 1; id <= workshop.Spec.UserDetails.NumberOfUsers; id++ {
+			attendee := workshopv1.Attendee{Name: identity.Name(id), Role: workshopv1.RoleAttendee}
+			if err := openshiftuser.ValidateAttendee(attendee); err != nil {
+				return nil, err
+			}
+			attendees = append(attendees, attendee)
+		}
+		return attendees, nil
+	}
+
+	byName := make(map[string]workshopv1.Attendee)
+	if roster.ConfigMapRef != nil {
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: roster.ConfigMapRef.Name, Namespace: workshop.Namespace}, configMap); err != nil {
+			return nil, fmt.Errorf("failed to read the roster ConfigMap %s: %s", roster.ConfigMapRef.Name, err)
+		}
+		data, ok := configMap.Data[roster.ConfigMapRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("the roster ConfigMap %s has no %s", configMap.Name, roster.ConfigMapRef.Key)
+		}
+		if err := addRosterAttendees(byName, []byte(data), roster.ConfigMapRef); err != nil {
+			return nil, fmt.Errorf("failed to parse the roster ConfigMap %s: %s", configMap.Name, err)
+		}
+	}
+	if roster.SecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: roster.SecretRef.Name, Namespace: workshop.Namespace}, secret); err != nil {
+			return nil, fmt.Errorf("failed to read the roster Secret %s: %s", roster.SecretRef.Name, err)
+		}
+		data, ok := secret.Data[roster.SecretRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("the roster Secret %s has no %s", secret.Name, roster.SecretRef.Key)
+		}
+		if err := addRosterAttendees(byName, data, roster.SecretRef); err != nil {
+			return nil, fmt.Errorf("failed to parse the roster Secret %s: %s", secret.Name, err)
+		}
+	}
+	for _, attendee := range roster.Attendees {
+		byName[attendee.Name] = attendee
+	}
+
+	attendees := make([]workshopv1.Attendee, 0, len(byName))
+	for _, attendee := range byName {
+		if err := openshiftuser.ValidateAttendee(attendee); err != nil {
+			return nil, err
+		}
+		if attendee.Role == "" {
+			attendee.Role = workshopv1.RoleAttendee
+		}
+		attendees = append(attendees, attendee)
+	}
+	sort.Slice(attendees, func(i, j int) bool {
+		return attendees[i].Name < attendees[j].Name
+	})
+	return attendees, nil
+}
+
+// addRosterAttendees parses a roster and adds its attendees by name
+func addRosterAttendees(byName map[string]workshopv1.Attendee, data []byte, source *workshopv1.RosterSourceSpec) error {
+	attendees, err := openshiftuser.ParseRoster(data, openshiftuser.RosterFormat(source))
+	if err != nil {
+		return err
+	}
+	for _, attendee := range attendees {
+		byName[attendee.Name] = attendee
+	}
+	return nil
+}
+
+// removedAttendees returns the user names provisioned earlier that are no longer attendees
+func removedAttendees(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) []string {
+	current := make(map[string]bool)
+	for _, attendee := range attendees {
+		current[attendee.Name] = true
+	}
+	removed := []string{}
+	for _, username := range workshop.Status.Attendees {
+		if !current[username] {
+			removed = append(removed, username)
+		}
+	}
+	return removed
+}
+
+// recordAttendees stores the provisioned attendees in the status. Removed attendees are kept
+// until a reconcile succeeds, so they are deprovisioned even if a component failed.
+func recordAttendees(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, succeeded bool) {
+	provisioned := []string{}
+	for _, attendee := range attendees {
+		provisioned = append(provisioned, attendee.Name)
+	}
+	if !succeeded {
+		for _, username := range removedAttendees(workshop, attendees) {
+			provisioned = append(provisioned, username)
+		}
+	}
+	sort.Strings(provisioned)
+	workshop.Status.Attendees = provisioned
+}
+
+// withRemovedAttendees returns the attendees followed by the removed ones, everything that may need to be deleted
+func withRemovedAttendees(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) []workshopv1.Attendee {
+	all := append([]workshopv1.Attendee{}, attendees...)
+	for _, username := range removedAttendees(workshop, attendees) {
+		all = append(all, workshopv1.Attendee{Name: username})
+	}
+	return all
+}
+
+// workshopsForRoster maps a ConfigMap or Secret to the Workshops reading their roster from it
+func (r *WorkshopReconciler) workshopsForRoster(obj handler.MapObject) []reconcile.Request {
+	if obj.Meta == nil {
+		return nil
+	}
+	workshops := &workshopv1.WorkshopList{}
+	if err := r.List(context.TODO(), workshops); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, workshop := range workshops.Items {
+		roster := workshop.Spec.UserDetails.Roster
+		if roster == nil || workshop.Namespace != obj.Meta.GetNamespace() {
+			continue
+		}
+		var source *workshopv1.RosterSourceSpec
+		switch obj.Object.(type) {
+		case *corev1.ConfigMap:
+			source = roster.ConfigMapRef
+		case *corev1.Secret:
+			source = roster.SecretRef
+		}
+		if source != nil && source.Name == obj.Meta.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: workshop.Name, Namespace: workshop.Namespace}})
+		}
+	}
+	return requests
+}
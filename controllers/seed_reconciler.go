@@ -0,0 +1,279 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	"github.com/stakater/workshop-operator/common/seed"
+	"github.com/stakater/workshop-operator/common/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var seedLabels = map[string]string{
+	"app.kubernetes.io/part-of": "seed",
+}
+
+// Reconciling Seed
+func (r *WorkshopReconciler) reconcileSeed(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, appsHostnameSuffix string) (reconcile.Result, error) {
+	files, err := r.seedFiles(workshop)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	manifestNames, err := seed.ManifestNames(files)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	previous, err := r.seedInventory(workshop)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	applied, applyErr := r.applySeedObjects(workshop, attendees, files, manifestNames, appsHostnameSuffix)
+	seen := make(map[seed.Entry]bool)
+	for _, entry := range applied {
+		seen[entry] = true
+	}
+
+	// Keep track of what was applied before, so it can still be pruned once seeding succeeds
+	if applyErr != nil {
+		for _, entry := range previous {
+			if !seen[entry] {
+				applied = append(applied, entry)
+			}
+		}
+		if err := r.applySeedInventory(workshop, applied); err != nil {
+			log.Errorf("Failed to update the seed inventory: %s", err)
+		}
+		return reconcile.Result{}, applyErr
+	}
+
+	// Delete what is no longer rendered, e.g. a manifest that was removed
+	for _, entry := range previous {
+		if seen[entry] {
+			continue
+		}
+		if err := kubernetes.DeleteIfExists(r, entry.Object()); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Infof("Deleted %s %s in %s", entry.Name, entry.Kind, entry.Namespace)
+	}
+
+	if err := r.applySeedInventory(workshop, applied); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// applySeedObjects renders the manifests for every seeded project of the attendees and applies them.
+// The objects applied so far are returned even if seeding fails.
+func (r *WorkshopReconciler) applySeedObjects(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee,
+	files map[string]string, manifestNames []string, appsHostnameSuffix string) ([]seed.Entry, error) {
+
+	seedSpec := workshop.Spec.Infrastructure.Project.Seed
+	applied := []seed.Entry{}
+	seen := make(map[seed.Entry]bool)
+	for _, attendee := range attendees {
+		projects, err := util.UserProjects(workshop, attendee.Name)
+		if err != nil {
+			return applied, err
+		}
+		projectNames := []string{}
+		for _, project := range projects {
+			projectNames = append(projectNames, project.Name)
+		}
+
+		for _, project := range projects {
+			if len(seedSpec.Projects) > 0 && !util.Contains(seedSpec.Projects, project.Template.Name) {
+				continue
+			}
+			data := seed.Data{
+				UserName:   attendee.Name,
+				UserID:     util.UserID(workshop, attendee.Name),
+				Project:    project.Name,
+				AppsDomain: appsHostnameSuffix,
+			}
+			for _, manifestName := range manifestNames {
+				objects, err := seed.Render(manifestName, files[manifestName], data)
+				if err != nil {
+					return applied, err
+				}
+				for _, obj := range objects {
+					if err := r.scopeSeedObject(workshop, obj, project.Name, projectNames, attendee.Name); err != nil {
+						return applied, fmt.Errorf("%s: %s", manifestName, err)
+					}
+					// The same object rendered for two projects of a user is applied once
+					entry := seed.EntryOf(obj)
+					if seen[entry] {
+						continue
+					}
+					seen[entry] = true
+
+					if op, err := kubernetes.Apply(r, r.Scheme, obj); err != nil {
+						return applied, err
+					} else if op != controllerutil.OperationResultNone {
+						log.Infof("Applied %s %s in %s", obj.GetName(), obj.GetKind(), obj.GetNamespace())
+					}
+					applied = append(applied, entry)
+				}
+			}
+		}
+	}
+	return applied, nil
+}
+
+// seedFiles returns the manifests of the seed, from its ConfigMap or from the directory of the workshop repository
+func (r *WorkshopReconciler) seedFiles(workshop *workshopv1.Workshop) (map[string]string, error) {
+	seedSpec := workshop.Spec.Infrastructure.Project.Seed
+
+	if seedSpec.ConfigMapName != "" {
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: seedSpec.ConfigMapName, Namespace: workshop.Namespace}, configMap); err != nil {
+			return nil, fmt.Errorf("failed to get seed ConfigMap %s: %s", seedSpec.ConfigMapName, err)
+		}
+		return configMap.Data, nil
+	}
+
+	if seedSpec.Path == "" {
+		return nil, fmt.Errorf("seed needs a path of the workshop repository or a ConfigMap")
+	}
+	if workshop.Spec.Source.GitURL == "" {
+		return nil, fmt.Errorf("seed path %s needs spec.source.gitURL", seedSpec.Path)
+	}
+	return seed.ReadGitFiles(workshop.Spec.Source.GitURL, workshop.Spec.Source.GitBranch, seedSpec.Path)
+}
+
+// scopeSeedObject places obj in the project it is seeded into, and labels it as seeded for the user.
+// Objects may name another project of the same user, but not a project of someone else nor a cluster-scoped kind.
+func (r *WorkshopReconciler) scopeSeedObject(workshop *workshopv1.Workshop, obj *unstructured.Unstructured, projectName string, projectNames []string, username string) error {
+	gvk := obj.GroupVersionKind()
+	if r.mapper == nil {
+		return fmt.Errorf("cannot tell the scope of %s %s before the controller is set up", obj.GetName(), gvk.Kind)
+	}
+	mapping, err := r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return fmt.Errorf("%s %s is cluster-scoped, only objects of the user projects can be seeded", obj.GetName(), gvk.Kind)
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(projectName)
+	}
+	if !util.Contains(projectNames, obj.GetNamespace()) {
+		return fmt.Errorf("%s %s is in %s, which is not a project of %s", obj.GetName(), gvk.Kind, obj.GetNamespace(), username)
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range seedLabels {
+		if _, ok := labels[key]; !ok {
+			labels[key] = value
+		}
+	}
+	labels[kubernetes.WORKSHOP_USER_LABEL] = username
+	obj.SetLabels(kubernetes.WorkshopLabels(workshop, labels))
+	return nil
+}
+
+// seedInventoryName returns the name of the ConfigMap listing the seeded objects of a workshop
+func seedInventoryName(workshop *workshopv1.Workshop) string {
+	return workshop.Name + "-seed-inventory"
+}
+
+// seedInventory returns the seeded objects of a workshop
+func (r *WorkshopReconciler) seedInventory(workshop *workshopv1.Workshop) ([]seed.Entry, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: seedInventoryName(workshop), Namespace: workshop.Namespace}, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return []seed.Entry{}, nil
+		}
+		return nil, err
+	}
+	entries, err := seed.ParseInventory(configMap.Data[seed.INVENTORY_KEY])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse seed inventory %s: %s", configMap.Name, err)
+	}
+	return entries, nil
+}
+
+// applySeedInventory records the seeded objects of a workshop
+func (r *WorkshopReconciler) applySeedInventory(workshop *workshopv1.Workshop, entries []seed.Entry) error {
+	inventory, err := seed.FormatInventory(entries)
+	if err != nil {
+		return err
+	}
+
+	inventoryConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, seedInventoryName(workshop), workshop.Namespace, seedLabels,
+		map[string]string{seed.INVENTORY_KEY: inventory})
+	if err := controllerutil.SetControllerReference(workshop, inventoryConfigMap, r.Scheme); err != nil {
+		return err
+	}
+	if op, err := kubernetes.Apply(r, r.Scheme, inventoryConfigMap); err != nil {
+		return err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Seed Inventory ConfigMap", inventoryConfigMap.Name)
+	}
+	return nil
+}
+
+// delete Seed
+func (r *WorkshopReconciler) deleteSeed(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	log.Infoln("Deleting Seed ")
+
+	entries, err := r.seedInventory(workshop)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, entry := range entries {
+		if err := kubernetes.DeleteIfExists(r, entry.Object()); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	inventoryConfigMap := kubernetes.NewConfigMap(workshop, r.Scheme, seedInventoryName(workshop), workshop.Namespace, seedLabels, nil)
+	if err := kubernetes.DeleteIfExists(r, inventoryConfigMap); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Seed Inventory ConfigMap", inventoryConfigMap.Name)
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// workshopsForSeed maps a ConfigMap to the Workshops seeding user projects from it
+func (r *WorkshopReconciler) workshopsForSeed(obj handler.MapObject) []reconcile.Request {
+	if obj.Meta == nil {
+		return nil
+	}
+	workshops := &workshopv1.WorkshopList{}
+	if err := r.List(context.TODO(), workshops); err != nil {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, workshop := range workshops.Items {
+		seedSpec := workshop.Spec.Infrastructure.Project.Seed
+		if seedSpec == nil || workshop.Namespace != obj.Meta.GetNamespace() {
+			continue
+		}
+		if seedSpec.ConfigMapName == obj.Meta.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: workshop.Name, Namespace: workshop.Namespace}})
+		}
+	}
+	return requests
+}
@@ -1,12 +1,11 @@
 package controllers
 
 import (
-	"context"
 	"github.com/prometheus/common/log"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
 	"github.com/stakater/workshop-operator/common/kubernetes"
 	"github.com/stakater/workshop-operator/common/util"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -42,32 +41,36 @@ func (r *WorkshopReconciler) addServerless(workshop *workshopv1.Workshop) (recon
 
 	// Create Serverless Namespace
 	namespace := kubernetes.NewNamespace(workshop, r.Scheme, SERVERLESS_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), namespace); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(namespace, "Serverless")
+	if op, err := kubernetes.Apply(r, r.Scheme, namespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Project", namespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Project", namespace.Name)
 	}
 
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, SERVERLESS_SUBSCRIPTION_NAME, SERVERLESS_SUBSCRIPTION_NAMESPACE_NAME, SERVERLESS_PACKAGE_NAME,
 		channel, clusterServiceVersion)
-	if err := r.Create(context.TODO(), subscription); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(subscription, "Serverless")
+	if op, err := kubernetes.Apply(r, r.Scheme, subscription); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Subscription", subscription.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Subscription", subscription.Name)
 	}
 
 	knativeServingNamespace := kubernetes.NewNamespace(workshop, r.Scheme, KNATIVE_SERVING_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), knativeServingNamespace); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(knativeServingNamespace, "Serverless")
+	if op, err := kubernetes.Apply(r, r.Scheme, knativeServingNamespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Namespace", knativeServingNamespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Namespace", knativeServingNamespace.Name)
 	}
 
 	knativeEventingNamespace := kubernetes.NewNamespace(workshop, r.Scheme, KNATIVE_EVENTING_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), knativeEventingNamespace); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(knativeEventingNamespace, "Serverless")
+	if op, err := kubernetes.Apply(r, r.Scheme, knativeEventingNamespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Namespace", knativeEventingNamespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Namespace", knativeEventingNamespace.Name)
 	}
 
 	// TODO
@@ -81,6 +84,15 @@ func (r *WorkshopReconciler) addServerless(workshop *workshopv1.Workshop) (recon
 // delete Serverless
 func (r *WorkshopReconciler) deleteServerless(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
+	// The serverless operator and the knative namespaces are shared with the other workshops on the cluster
+	if shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.Serverless.Enabled
+	}); err != nil {
+		return reconcile.Result{}, err
+	} else if shared {
+		return reconcile.Result{}, nil
+	}
+
 	channel := workshop.Spec.Infrastructure.Serverless.OperatorHub.Channel
 	clusterServiceVersion := workshop.Spec.Infrastructure.Serverless.OperatorHub.ClusterServiceVersion
 
@@ -89,13 +101,13 @@ func (r *WorkshopReconciler) deleteServerless(workshop *workshopv1.Workshop) (re
 	knativeEventingNamespace := kubernetes.NewNamespace(workshop, r.Scheme, KNATIVE_EVENTING_NAMESPACE_NAME)
 
 	//Delete knativeEventing Namespace
-	if err := r.Delete(context.TODO(), knativeEventingNamespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, knativeEventingNamespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Namespace", knativeEventingNamespace.Name)
 
 	//Delete knativeServing Namespace
-	if err := r.Delete(context.TODO(), knativeServingNamespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, knativeServingNamespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Namespace", knativeServingNamespace.Name)
@@ -104,13 +116,13 @@ func (r *WorkshopReconciler) deleteServerless(workshop *workshopv1.Workshop) (re
 		channel, clusterServiceVersion)
 
 	//Delete subscription
-	if err := r.Delete(context.TODO(), subscription); err != nil {
+	if err := kubernetes.DeleteIfExists(r, subscription); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Subscription", subscription.Name)
 
 	// Delete namespace
-	if err := r.Delete(context.TODO(), namespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, namespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s namespace", namespace.Name)
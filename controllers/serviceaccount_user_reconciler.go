@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	"github.com/stakater/workshop-operator/common/util"
+	corev1 "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ATTENDEE_NAMESPACE_NAME    = "workshop-attendees"
+	ATTENDEE_TOKEN_SUFFIX      = "-token"
+	ATTENDEE_ROLE_BINDING_NAME = "view"
+)
+
+// userSubject returns the RBAC subject of an attendee, an OpenShift User or the attendee Service Account on Kubernetes
+func (r *WorkshopReconciler) userSubject(workshop *workshopv1.Workshop, username string) rbac.Subject {
+	if r.Platform.IsOpenShift() {
+		return rbac.Subject{
+			Kind: rbac.UserKind,
+			Name: username,
+		}
+	}
+	return rbac.Subject{
+		Kind:      rbac.ServiceAccountKind,
+		Name:      username,
+		Namespace: util.ScopedName(workshop, ATTENDEE_NAMESPACE_NAME),
+	}
+}
+
+// reconcileServiceAccountUsers creates a Service Account per attendee on clusters without OpenShift users,
+// attendees log in with the token of their Service Account
+func (r *WorkshopReconciler) reconcileServiceAccountUsers(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
+	createUsers := make(map[string]bool)
+	for _, attendee := range attendees {
+		createUsers[attendee.Name] = true
+	}
+
+	// Create Namespace
+	namespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, ATTENDEE_NAMESPACE_NAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, namespace); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Namespace", namespace.Name)
+	}
+
+	listServiceAccounts, err := r.createdServiceAccountUserList(workshop)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, serviceAccount := range listServiceAccounts.Items {
+		if _, ok := createUsers[serviceAccount.Name]; !ok {
+			if result, err := r.deleteServiceAccountUser(workshop, serviceAccount.Name); util.IsRequeued(result, err) {
+				return result, err
+			}
+		}
+	}
+
+	for username := range createUsers {
+		if result, err := r.addServiceAccountUser(workshop, username); util.IsRequeued(result, err) {
+			return result, err
+		}
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// addServiceAccountUser creates the Service Account and token of an attendee
+func (r *WorkshopReconciler) addServiceAccountUser(workshop *workshopv1.Workshop, username string) (reconcile.Result, error) {
+	namespace := util.ScopedName(workshop, ATTENDEE_NAMESPACE_NAME)
+
+	// Create Service Account
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, username, namespace, userLabels)
+	if op, err := kubernetes.Apply(r, r.Scheme, serviceAccount); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Service Account", serviceAccount.Name)
+	}
+
+	// Create Token Secret
+	tokenSecret := kubernetes.NewServiceAccountTokenSecret(workshop, r.Scheme, username+ATTENDEE_TOKEN_SUFFIX, namespace, userLabels, serviceAccount.Name)
+	if op, err := kubernetes.Apply(r, r.Scheme, tokenSecret); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Token Secret", tokenSecret.Name)
+	}
+
+	// Create Role Binding, attendees can see the other attendees in the namespace holding their credentials
+	roleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, username, namespace, userLabels,
+		[]rbac.Subject{r.userSubject(workshop, username)}, ATTENDEE_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
+	if op, err := kubernetes.Apply(r, r.Scheme, roleBinding); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role Binding", roleBinding.Name)
+	}
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// deleteServiceAccountUser deletes the Service Account and token of an attendee
+func (r *WorkshopReconciler) deleteServiceAccountUser(workshop *workshopv1.Workshop, username string) (reconcile.Result, error) {
+	namespace := util.ScopedName(workshop, ATTENDEE_NAMESPACE_NAME)
+
+	// Delete Role Binding
+	roleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme, username, namespace, userLabels,
+		[]rbac.Subject{r.userSubject(workshop, username)}, ATTENDEE_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
+	if err := kubernetes.DeleteIfExists(r, roleBinding); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Role Binding", roleBinding.Name)
+
+	// Delete Token Secret
+	tokenSecret := kubernetes.NewServiceAccountTokenSecret(workshop, r.Scheme, username+ATTENDEE_TOKEN_SUFFIX, namespace, userLabels, username)
+	if err := kubernetes.DeleteIfExists(r, tokenSecret); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Token Secret", tokenSecret.Name)
+
+	// Delete Service Account
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, username, namespace, userLabels)
+	if err := kubernetes.DeleteIfExists(r, serviceAccount); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Service Account", serviceAccount.Name)
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// deleteServiceAccountUsers deletes the namespace holding the attendee Service Accounts
+func (r *WorkshopReconciler) deleteServiceAccountUsers(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	namespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, ATTENDEE_NAMESPACE_NAME))
+	if err := kubernetes.DeleteIfExists(r, namespace); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Infof("Deleted %s Namespace", namespace.Name)
+
+	//Success
+	return reconcile.Result{}, nil
+}
+
+// createdServiceAccountUserList returns the attendee Service Accounts of the workshop
+func (r *WorkshopReconciler) createdServiceAccountUserList(workshop *workshopv1.Workshop) (*corev1.ServiceAccountList, error) {
+	labelSelector := labels.SelectorFromSet(kubernetes.WorkshopLabels(workshop, userLabels))
+	listServiceAccounts := &corev1.ServiceAccountList{}
+	listOps := &client.ListOptions{
+		Namespace:     util.ScopedName(workshop, ATTENDEE_NAMESPACE_NAME),
+		LabelSelector: labelSelector,
+	}
+	if err := r.List(context.TODO(), listServiceAccounts, listOps); err != nil {
+		log.Errorf("Failed to get list of Service Accounts, filtered by labelSelector {%s}, {%s}", labelSelector, err)
+		return nil, err
+	}
+	return listServiceAccounts, nil
+}
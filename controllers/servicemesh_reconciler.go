@@ -17,8 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"reflect"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -57,7 +56,7 @@ var istioLabels = map[string]string{
 }
 
 // Reconciling ServiceMesh
-func (r *WorkshopReconciler) reconcileServiceMesh(workshop *workshopv1.Workshop, users int) (reconcile.Result, error) {
+func (r *WorkshopReconciler) reconcileServiceMesh(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
 	enabledServiceMesh := workshop.Spec.Infrastructure.ServiceMesh.Enabled
 	enabledServerless := workshop.Spec.Infrastructure.Serverless.Enabled
 
@@ -75,7 +74,7 @@ func (r *WorkshopReconciler) reconcileServiceMesh(workshop *workshopv1.Workshop,
 			return result, err
 		}
 
-		if result, err := r.addServiceMesh(workshop, users); util.IsRequeued(result, err) {
+		if result, err := r.addServiceMesh(workshop, attendees); util.IsRequeued(result, err) {
 			return result, err
 		}
 	}
@@ -85,17 +84,18 @@ func (r *WorkshopReconciler) reconcileServiceMesh(workshop *workshopv1.Workshop,
 }
 
 // Add ServiceMesh
-func (r *WorkshopReconciler) addServiceMesh(workshop *workshopv1.Workshop, users int) (reconcile.Result, error) {
+func (r *WorkshopReconciler) addServiceMesh(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
 
 	channel := workshop.Spec.Infrastructure.ServiceMesh.ServiceMeshOperatorHub.Channel
 	clusterserviceversion := workshop.Spec.Infrastructure.ServiceMesh.ServiceMeshOperatorHub.ClusterServiceVersion
 
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, SERVICE_MESH_SUBSCRIPTION_NAME, SERVICE_MESH_SUBSCRIPTION_NAMESPACE_NAME,
 		SERVICE_MESH_SUBSCRIPTION_PACKAGE_NAME, channel, clusterserviceversion)
-	if err := r.Create(context.TODO(), subscription); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(subscription, "ServiceMesh")
+	if op, err := kubernetes.Apply(r, r.Scheme, subscription); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Subscription", subscription.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Subscription", subscription.Name)
 	}
 
 	if err := r.ApproveInstallPlan(clusterserviceversion, SERVICE_MESH_SUBSCRIPTION_NAME, SERVICE_MESH_SUBSCRIPTION_NAMESPACE_NAME); err != nil {
@@ -104,15 +104,17 @@ func (r *WorkshopReconciler) addServiceMesh(workshop *workshopv1.Workshop, users
 	}
 
 	// Wait for Operator to be running
-	if !kubernetes.GetK8Client().GetDeploymentStatus(ISTIO_OPERATOR_NAME, ISTIO_OPERATOR_NAMESPACE_NAME) {
+	if ready, err := kubernetes.IsDeploymentReady(r, ISTIO_OPERATOR_NAME, ISTIO_OPERATOR_NAMESPACE_NAME); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	istioSystemNamespace := kubernetes.NewNamespace(workshop, r.Scheme, ISTIO_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), istioSystemNamespace); err != nil && !errors.IsAlreadyExists(err) {
+	istioSystemNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, ISTIO_NAMESPACE_NAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, istioSystemNamespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Namespace", istioSystemNamespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Namespace", istioSystemNamespace.Name)
 	}
 
 	istioMembers := []string{}
@@ -121,89 +123,66 @@ func (r *WorkshopReconciler) addServiceMesh(workshop *workshopv1.Workshop, users
 	if workshop.Spec.Infrastructure.GitOps.Enabled {
 		argocdSubject := rbac.Subject{
 			Kind:     rbac.UserKind,
-			Name:     "system:serviceaccount:argocd:argocd-argocd-application-controller",
+			Name:     argocdControllerUser(workshop),
 			APIGroup: "rbac.authorization.k8s.io",
 		}
 		istioUsers = append(istioUsers, argocdSubject)
 	}
 
-	for id := 1; id <= users; id++ {
-		username := fmt.Sprintf("user%d", id)
-		stagingProjectName := fmt.Sprintf("%s%d", workshop.Spec.Infrastructure.Project.StagingName, id)
+	for _, attendee := range attendees {
+		username := attendee.Name
+		projectNames, err := util.UserProjectNames(workshop, username)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 		userSubject := rbac.Subject{
 			Kind:     rbac.UserKind,
 			Name:     username,
 			APIGroup: "rbac.authorization.k8s.io",
 		}
 
-		istioMembers = append(istioMembers, stagingProjectName)
+		istioMembers = append(istioMembers, projectNames...)
 		istioUsers = append(istioUsers, userSubject)
 	}
 
 	jaegerRole := kubernetes.NewRole(workshop, r.Scheme,
-		JAEGER_ROLE_NAME, JAEGER_ROLE_NAMESPACE_NAME, istioLabels, kubernetes.JaegerUserRules())
-	if err := r.Create(context.TODO(), jaegerRole); err != nil && !errors.IsAlreadyExists(err) {
+		JAEGER_ROLE_NAME, util.ScopedName(workshop, JAEGER_ROLE_NAMESPACE_NAME), istioLabels, kubernetes.JaegerUserRules())
+	if op, err := kubernetes.Apply(r, r.Scheme, jaegerRole); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Role", jaegerRole.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role", jaegerRole.Name)
 	}
 
 	jaegerRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme,
-		JAEGER_ROLE_BINDING_NAME, JAEGER_ROLE_BINDING_NAMESPACE_NAME, istioLabels, istioUsers, jaegerRole.Name, JAEGER_ROLE_KIND_NAME)
-	if err := r.Create(context.TODO(), jaegerRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
-		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Role Binding", jaegerRoleBinding.Name)
-	} else if errors.IsAlreadyExists(err) {
-		found := &rbac.RoleBinding{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: jaegerRoleBinding.Name, Namespace: istioSystemNamespace.Name}, found); err != nil {
-			return reconcile.Result{}, err
-		} else if err == nil {
-			if !reflect.DeepEqual(istioUsers, found.Subjects) {
-				found.Subjects = istioUsers
-				if err := r.Update(context.TODO(), found); err != nil {
-					return reconcile.Result{}, err
-				}
-				log.Infof("Updated %s Role Binding", found.Name)
-			}
-		}
+		JAEGER_ROLE_BINDING_NAME, util.ScopedName(workshop, JAEGER_ROLE_BINDING_NAMESPACE_NAME), istioLabels, istioUsers, jaegerRole.Name, JAEGER_ROLE_KIND_NAME)
+	if op, err := kubernetes.Apply(r, r.Scheme, jaegerRoleBinding); err != nil {
+		return reconcile.Result{}, err
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role Binding", jaegerRoleBinding.Name)
 	}
 
 	meshUserRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme,
-		SERVICE_MESH_ROLE_BINDING_NAME, SERVICE_MESH_ROLE_BINDING_NAMESPACE_NAME, istioLabels, istioUsers, SERVICE_MESH_ROLE_NAME, SERVICE_MESH_ROLE_KIND_NAME)
+		SERVICE_MESH_ROLE_BINDING_NAME, util.ScopedName(workshop, SERVICE_MESH_ROLE_BINDING_NAMESPACE_NAME), istioLabels, istioUsers, SERVICE_MESH_ROLE_NAME, SERVICE_MESH_ROLE_KIND_NAME)
 
-	if err := r.Create(context.TODO(), meshUserRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, meshUserRoleBinding); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Role Binding", meshUserRoleBinding.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Role Binding", meshUserRoleBinding.Name)
 	}
 
 	serviceMeshControlPlaneCR := maistra.NewServiceMeshControlPlaneCR(workshop, r.Scheme, SERVICE_MESH_CONTROL_PLANE_NAME, istioSystemNamespace.Name)
-	if err := r.Create(context.TODO(), serviceMeshControlPlaneCR); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, serviceMeshControlPlaneCR); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Service Mesh Control Plane Custom Resource", serviceMeshControlPlaneCR.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Service Mesh Control Plane Custom Resource", serviceMeshControlPlaneCR.Name)
 	}
 
 	serviceMeshMemberRollCR := maistra.NewServiceMeshMemberRollCR(workshop, r.Scheme,
 		SERVICE_MESH_MEMBER_ROLL_NAME, istioSystemNamespace.Name, istioMembers)
-	if err := r.Create(context.TODO(), serviceMeshMemberRollCR); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, serviceMeshMemberRollCR); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Custom Resource", serviceMeshMemberRollCR.Name)
-	} else if errors.IsAlreadyExists(err) {
-		serviceMeshMemberRollCRFound := &maistrav1.ServiceMeshMemberRoll{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: serviceMeshMemberRollCR.Name, Namespace: istioSystemNamespace.Name}, serviceMeshMemberRollCRFound); err != nil {
-			return reconcile.Result{}, err
-		} else if err == nil {
-			if !reflect.DeepEqual(istioMembers, serviceMeshMemberRollCRFound.Spec.Members) {
-				serviceMeshMemberRollCRFound.Spec.Members = istioMembers
-				if err := r.Update(context.TODO(), serviceMeshMemberRollCRFound); err != nil {
-					return reconcile.Result{}, err
-				}
-				log.Infof("Updated %s Service Mesh Member Roll Custom Resource", serviceMeshMemberRollCRFound.Name)
-			}
-		}
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Service Mesh Member Roll Custom Resource", serviceMeshMemberRollCR.Name)
 	}
 	//Success
 	return reconcile.Result{}, nil
@@ -217,18 +196,20 @@ func (r *WorkshopReconciler) addElasticSearchOperator(workshop *workshopv1.Works
 	subcriptionName := fmt.Sprintf("elasticsearch-operator-%s", channel)
 
 	redhatOperatorsNamespace := kubernetes.NewNamespace(workshop, r.Scheme, OPERATOR_REDHAT_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), redhatOperatorsNamespace); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(redhatOperatorsNamespace, "ServiceMesh")
+	if op, err := kubernetes.Apply(r, r.Scheme, redhatOperatorsNamespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Namespace", redhatOperatorsNamespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Namespace", redhatOperatorsNamespace.Name)
 	}
 
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, subcriptionName, ELASTICSEARCH_SUBSCRIPTION_NAMESPACE_NAME,
 		ELASTICSEARCH_SUBSCRIPTION_PACKAGE_NAME, channel, clusterserviceversion)
-	if err := r.Create(context.TODO(), subscription); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(subscription, "ServiceMesh")
+	if op, err := kubernetes.Apply(r, r.Scheme, subscription); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Subscription", subscription.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Subscription", subscription.Name)
 	}
 
 	if err := r.ApproveInstallPlan(clusterserviceversion, subcriptionName, ELASTICSEARCH_SUBSCRIPTION_NAMESPACE_NAME); err != nil {
@@ -248,10 +229,11 @@ func (r *WorkshopReconciler) addJaegerOperator(workshop *workshopv1.Workshop) (r
 
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, JAEGER_SUBSCRIPTION_NAME, JAEGER_SUBSCRIPTION_NAMESPACE_NAME,
 		JAEGER_SUBSCRIPTION_PACKAGE_NAME, channel, clusterserviceversion)
-	if err := r.Create(context.TODO(), subscription); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(subscription, "ServiceMesh")
+	if op, err := kubernetes.Apply(r, r.Scheme, subscription); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Subscription", subscription.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Subscription", subscription.Name)
 	}
 
 	if err := r.ApproveInstallPlan(clusterserviceversion, JAEGER_SUBSCRIPTION_NAME, JAEGER_SUBSCRIPTION_NAMESPACE_NAME); err != nil {
@@ -271,10 +253,11 @@ func (r *WorkshopReconciler) addKialiOperator(workshop *workshopv1.Workshop) (re
 
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, KIALI_SUBSCRIPTION_NAME, KIALI_SUBSCRIPTION_NAMESPACE_NAME,
 		KIALI_SUBSCRIPTION_PACKAGE_NAME, channel, clusterserviceversion)
-	if err := r.Create(context.TODO(), subscription); err != nil && !errors.IsAlreadyExists(err) {
+	shareObject(subscription, "ServiceMesh")
+	if op, err := kubernetes.Apply(r, r.Scheme, subscription); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Subscription", subscription.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Subscription", subscription.Name)
 	}
 
 	if err := r.ApproveInstallPlan(clusterserviceversion, KIALI_SUBSCRIPTION_NAME, KIALI_SUBSCRIPTION_NAMESPACE_NAME); err != nil {
@@ -286,14 +269,15 @@ func (r *WorkshopReconciler) addKialiOperator(workshop *workshopv1.Workshop) (re
 	return reconcile.Result{}, nil
 }
 
-func (r *WorkshopReconciler) deleteServiceMeshService(workshop *workshopv1.Workshop, userID int) (reconcile.Result, error) {
+func (r *WorkshopReconciler) deleteServiceMeshService(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
 
 	servicemeshCSV, JaegerCSV, kialiCSV, err := r.getCSV(workshop)
 	if err != nil {
 		log.Error("Failed to get ClusterServiceVersion")
+		return reconcile.Result{}, err
 	}
 
-	if result, err := r.deleteServiceMesh(workshop, userID); util.IsRequeued(result, err) {
+	if result, err := r.deleteServiceMesh(workshop, attendees); util.IsRequeued(result, err) {
 		return result, err
 	}
 	if result, err := r.deleteKialiSubscription(workshop); util.IsRequeued(result, err) {
@@ -323,7 +307,7 @@ func (r *WorkshopReconciler) deleteServiceMeshService(workshop *workshopv1.Works
 }
 
 // Delete ServiceMesh
-func (r *WorkshopReconciler) deleteServiceMesh(workshop *workshopv1.Workshop, users int) (reconcile.Result, error) {
+func (r *WorkshopReconciler) deleteServiceMesh(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
 
 	channel := workshop.Spec.Infrastructure.ServiceMesh.ServiceMeshOperatorHub.Channel
 	clusterserviceversion := workshop.Spec.Infrastructure.ServiceMesh.ServiceMeshOperatorHub.ClusterServiceVersion
@@ -334,69 +318,81 @@ func (r *WorkshopReconciler) deleteServiceMesh(workshop *workshopv1.Workshop, us
 	if workshop.Spec.Infrastructure.GitOps.Enabled {
 		argocdSubject := rbac.Subject{
 			Kind:     rbac.UserKind,
-			Name:     "system:serviceaccount:argocd:argocd-argocd-application-controller",
+			Name:     argocdControllerUser(workshop),
 			APIGroup: "rbac.authorization.k8s.io",
 		}
 		istioUsers = append(istioUsers, argocdSubject)
 	}
 
-	for id := 1; id <= users; id++ {
-		username := fmt.Sprintf("user%d", id)
-		stagingProjectName := fmt.Sprintf("%s%d", workshop.Spec.Infrastructure.Project.StagingName, id)
+	for _, attendee := range attendees {
+		username := attendee.Name
+		projectNames, err := util.UserProjectNames(workshop, username)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 		userSubject := rbac.Subject{
 			Kind:     rbac.UserKind,
 			Name:     username,
 			APIGroup: "rbac.authorization.k8s.io",
 		}
 
-		istioMembers = append(istioMembers, stagingProjectName)
+		istioMembers = append(istioMembers, projectNames...)
 		istioUsers = append(istioUsers, userSubject)
 	}
 
 	jaegerRole := kubernetes.NewRole(workshop, r.Scheme,
-		JAEGER_ROLE_NAME, JAEGER_ROLE_NAMESPACE_NAME, istioLabels, kubernetes.JaegerUserRules())
+		JAEGER_ROLE_NAME, util.ScopedName(workshop, JAEGER_ROLE_NAMESPACE_NAME), istioLabels, kubernetes.JaegerUserRules())
 
 	serviceMeshMemberRollCR := maistra.NewServiceMeshMemberRollCR(workshop, r.Scheme,
-		SERVICE_MESH_MEMBER_ROLL_NAME, ISTIO_NAMESPACE_NAME, istioMembers)
+		SERVICE_MESH_MEMBER_ROLL_NAME, util.ScopedName(workshop, ISTIO_NAMESPACE_NAME), istioMembers)
 	// Delete Service MeshMember Roll Custom Resource
-	if err := r.Delete(context.TODO(), serviceMeshMemberRollCR); err != nil {
+	if err := kubernetes.DeleteIfExists(r, serviceMeshMemberRollCR); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Service MeshMember Roll Custom Resource", serviceMeshMemberRollCR.Name)
 
-	serviceMeshControlPlaneCR := maistra.NewServiceMeshControlPlaneCR(workshop, r.Scheme, SERVICE_MESH_CONTROL_PLANE_NAME, ISTIO_NAMESPACE_NAME)
+	serviceMeshControlPlaneCR := maistra.NewServiceMeshControlPlaneCR(workshop, r.Scheme, SERVICE_MESH_CONTROL_PLANE_NAME, util.ScopedName(workshop, ISTIO_NAMESPACE_NAME))
 	// Delete Service Mesh Control Plane Custom Resource
-	if err := r.Delete(context.TODO(), serviceMeshControlPlaneCR); err != nil {
+	if err := kubernetes.DeleteIfExists(r, serviceMeshControlPlaneCR); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Service Mesh Control Plane Custom Resource", serviceMeshControlPlaneCR.Name)
 
 	meshUserRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme,
-		SERVICE_MESH_ROLE_BINDING_NAME, SERVICE_MESH_ROLE_BINDING_NAMESPACE_NAME, istioLabels, istioUsers, SERVICE_MESH_ROLE_NAME, SERVICE_MESH_ROLE_KIND_NAME)
+		SERVICE_MESH_ROLE_BINDING_NAME, util.ScopedName(workshop, SERVICE_MESH_ROLE_BINDING_NAMESPACE_NAME), istioLabels, istioUsers, SERVICE_MESH_ROLE_NAME, SERVICE_MESH_ROLE_KIND_NAME)
 	// Delete RoleBinding
-	if err := r.Delete(context.TODO(), meshUserRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, meshUserRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Role Binding", meshUserRoleBinding.Name)
 
 	jaegerRoleBinding := kubernetes.NewRoleBindingUsers(workshop, r.Scheme,
-		JAEGER_ROLE_BINDING_NAME, JAEGER_ROLE_BINDING_NAMESPACE_NAME, istioLabels, istioUsers, jaegerRole.Name, JAEGER_ROLE_KIND_NAME)
+		JAEGER_ROLE_BINDING_NAME, util.ScopedName(workshop, JAEGER_ROLE_BINDING_NAMESPACE_NAME), istioLabels, istioUsers, jaegerRole.Name, JAEGER_ROLE_KIND_NAME)
 	// Delete RoleBinding
-	if err := r.Delete(context.TODO(), jaegerRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, jaegerRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Role Binding", jaegerRoleBinding.Name)
 
 	// Delete Role
-	if err := r.Delete(context.TODO(), jaegerRole); err != nil {
+	if err := kubernetes.DeleteIfExists(r, jaegerRole); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Role", jaegerRole.Name)
 
+	// The Service Mesh operator is shared with the other workshops on the cluster
+	if shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.ServiceMesh.Enabled
+	}); err != nil {
+		return reconcile.Result{}, err
+	} else if shared {
+		return reconcile.Result{}, nil
+	}
+
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, SERVICE_MESH_SUBSCRIPTION_NAME, SERVICE_MESH_SUBSCRIPTION_NAMESPACE_NAME,
 		SERVICE_MESH_SUBSCRIPTION_PACKAGE_NAME, channel, clusterserviceversion)
 	// Delete Subscription
-	if err := r.Delete(context.TODO(), subscription); err != nil {
+	if err := kubernetes.DeleteIfExists(r, subscription); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Subscription", subscription.Name)
@@ -409,7 +405,7 @@ func (r *WorkshopReconciler) deleteServiceMesh(workshop *workshopv1.Workshop, us
 	}
 
 	// Delete ValidatingWebhookConfiguration
-	if err := r.Delete(context.TODO(), vwc); err != nil {
+	if err := kubernetes.DeleteIfExists(r, vwc); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("deleted %s ValidatingWebhookConfiguration", vwc.Name)
@@ -421,7 +417,7 @@ func (r *WorkshopReconciler) deleteServiceMesh(workshop *workshopv1.Workshop, us
 		},
 	}
 	// Delete MutatingWebhookConfiguration
-	if err := r.Delete(context.TODO(), mwc); err != nil {
+	if err := kubernetes.DeleteIfExists(r, mwc); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("deleted %s MutatingWebhookConfiguration", mwc.Name)
@@ -433,13 +429,22 @@ func (r *WorkshopReconciler) deleteServiceMesh(workshop *workshopv1.Workshop, us
 // Delete KialiSubscription
 func (r *WorkshopReconciler) deleteKialiSubscription(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
+	// The Kiali operator and its subscription are shared with the other workshops on the cluster
+	if shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.ServiceMesh.Enabled
+	}); err != nil {
+		return reconcile.Result{}, err
+	} else if shared {
+		return reconcile.Result{}, nil
+	}
+
 	channel := workshop.Spec.Infrastructure.ServiceMesh.KialiOperatorHub.Channel
 	clusterserviceversion := workshop.Spec.Infrastructure.ServiceMesh.KialiOperatorHub.ClusterServiceVersion
 
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, KIALI_SUBSCRIPTION_NAME, KIALI_SUBSCRIPTION_NAMESPACE_NAME,
 		KIALI_SUBSCRIPTION_PACKAGE_NAME, channel, clusterserviceversion)
 	// Delete Subscription
-	if err := r.Delete(context.TODO(), subscription); err != nil {
+	if err := kubernetes.DeleteIfExists(r, subscription); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Subscription", subscription.Name)
@@ -450,13 +455,22 @@ func (r *WorkshopReconciler) deleteKialiSubscription(workshop *workshopv1.Worksh
 // Delete JaegerSubscription
 func (r *WorkshopReconciler) deleteJaegerSubscription(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
+	// The Jaeger operator and its subscription are shared with the other workshops on the cluster
+	if shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.ServiceMesh.Enabled
+	}); err != nil {
+		return reconcile.Result{}, err
+	} else if shared {
+		return reconcile.Result{}, nil
+	}
+
 	channel := workshop.Spec.Infrastructure.ServiceMesh.JaegerOperatorHub.Channel
 	clusterserviceversion := workshop.Spec.Infrastructure.ServiceMesh.JaegerOperatorHub.ClusterServiceVersion
 
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, JAEGER_SUBSCRIPTION_NAME, JAEGER_SUBSCRIPTION_NAMESPACE_NAME,
 		JAEGER_SUBSCRIPTION_PACKAGE_NAME, channel, clusterserviceversion)
 	// Delete Subscription
-	if err := r.Delete(context.TODO(), subscription); err != nil {
+	if err := kubernetes.DeleteIfExists(r, subscription); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Subscription", subscription.Name)
@@ -468,6 +482,15 @@ func (r *WorkshopReconciler) deleteJaegerSubscription(workshop *workshopv1.Works
 // Delete ElasticSearchOperator
 func (r *WorkshopReconciler) deleteElasticSearchOperator(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
+	// The Elasticsearch operator and its subscription are shared with the other workshops on the cluster
+	if shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.ServiceMesh.Enabled
+	}); err != nil {
+		return reconcile.Result{}, err
+	} else if shared {
+		return reconcile.Result{}, nil
+	}
+
 	channel := workshop.Spec.Infrastructure.ServiceMesh.ElasticSearchOperatorHub.Channel
 	clusterserviceversion := workshop.Spec.Infrastructure.ServiceMesh.ElasticSearchOperatorHub.ClusterServiceVersion
 	subcriptionName := fmt.Sprintf("elasticsearch-operator-%s", channel)
@@ -475,14 +498,14 @@ func (r *WorkshopReconciler) deleteElasticSearchOperator(workshop *workshopv1.Wo
 	subscription := kubernetes.NewRedHatSubscription(workshop, r.Scheme, subcriptionName, ELASTICSEARCH_SUBSCRIPTION_NAMESPACE_NAME,
 		ELASTICSEARCH_SUBSCRIPTION_PACKAGE_NAME, channel, clusterserviceversion)
 	// Delete Subscription
-	if err := r.Delete(context.TODO(), subscription); err != nil {
+	if err := kubernetes.DeleteIfExists(r, subscription); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Subscription", subscription.Name)
 
 	redhatOperatorsNamespace := kubernetes.NewNamespace(workshop, r.Scheme, OPERATOR_REDHAT_NAMESPACE_NAME)
 	// Delete Namespace
-	if err := r.Delete(context.TODO(), redhatOperatorsNamespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, redhatOperatorsNamespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Namespace", redhatOperatorsNamespace.Name)
@@ -494,9 +517,9 @@ func (r *WorkshopReconciler) deleteElasticSearchOperator(workshop *workshopv1.Wo
 // delete IstioSystem Namespace
 func (r *WorkshopReconciler) deleteIstioSystemNamespace(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
-	istioSystemNamespace := kubernetes.NewNamespace(workshop, r.Scheme, ISTIO_NAMESPACE_NAME)
+	istioSystemNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, ISTIO_NAMESPACE_NAME))
 	// Delete Namespace
-	if err := r.Delete(context.TODO(), istioSystemNamespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, istioSystemNamespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Namespace", istioSystemNamespace.Name)
@@ -508,21 +531,22 @@ func (r *WorkshopReconciler) deleteIstioSystemNamespace(workshop *workshopv1.Wor
 // get CSV of servicemesh, jaeger, kiali
 func (r *WorkshopReconciler) getCSV(workshop *workshopv1.Workshop) (string, string, string, error) {
 
+	// A subscription that is already deleted has no CSV left to delete
 	servicemeshSubFound := &olmv1alpha1.Subscription{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: SERVICE_MESH_SUBSCRIPTION_NAME, Namespace: SERVICE_MESH_SUBSCRIPTION_NAMESPACE_NAME}, servicemeshSubFound); err != nil {
-		return servicemeshSubFound.Status.InstalledCSV, "", "", err
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: SERVICE_MESH_SUBSCRIPTION_NAME, Namespace: SERVICE_MESH_SUBSCRIPTION_NAMESPACE_NAME}, servicemeshSubFound); err != nil && !errors.IsNotFound(err) {
+		return "", "", "", err
 	}
 	log.Info(servicemeshSubFound.Status.InstalledCSV)
 
 	jaegerSubFound := &olmv1alpha1.Subscription{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: JAEGER_SUBSCRIPTION_NAME, Namespace: JAEGER_SUBSCRIPTION_NAMESPACE_NAME}, jaegerSubFound); err != nil {
-		return "", jaegerSubFound.Status.InstalledCSV, "", err
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: JAEGER_SUBSCRIPTION_NAME, Namespace: JAEGER_SUBSCRIPTION_NAMESPACE_NAME}, jaegerSubFound); err != nil && !errors.IsNotFound(err) {
+		return "", "", "", err
 	}
 	log.Info(jaegerSubFound.Status.InstalledCSV)
 
 	kialiSubFound := &olmv1alpha1.Subscription{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: KIALI_SUBSCRIPTION_NAME, Namespace: KIALI_SUBSCRIPTION_NAMESPACE_NAME}, kialiSubFound); err != nil {
-		return "", "", kialiSubFound.Status.InstalledCSV, err
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: KIALI_SUBSCRIPTION_NAME, Namespace: KIALI_SUBSCRIPTION_NAMESPACE_NAME}, kialiSubFound); err != nil && !errors.IsNotFound(err) {
+		return "", "", "", err
 	}
 	log.Info(kialiSubFound.Status.InstalledCSV)
 
@@ -532,20 +556,29 @@ func (r *WorkshopReconciler) getCSV(workshop *workshopv1.Workshop) (string, stri
 // delete CSV of servicemesh, jaeger, kiali
 func (r *WorkshopReconciler) deleteCSV(workshop *workshopv1.Workshop, servicemeshCSV string, kialiCSV string, JaegerCSV string) (reconcile.Result, error) {
 
+	// The operator ClusterServiceVersions are shared with the other workshops on the cluster
+	if shared, err := r.isSharedWithOtherWorkshops(workshop, func(spec *workshopv1.WorkshopSpec) bool {
+		return spec.Infrastructure.ServiceMesh.Enabled
+	}); err != nil {
+		return reconcile.Result{}, err
+	} else if shared {
+		return reconcile.Result{}, nil
+	}
+
 	servicemeshOperatorCSV := kubernetes.NewRedHatClusterServiceVersion(workshop, r.Scheme, servicemeshCSV, SERVICE_MESH_SUBSCRIPTION_NAMESPACE_NAME)
-	if err := r.Delete(context.TODO(), servicemeshOperatorCSV); err != nil {
+	if err := kubernetes.DeleteIfExists(r, servicemeshOperatorCSV); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s ClusterServiceVersion", servicemeshOperatorCSV.Name)
 
 	kialiOperatorCSV := kubernetes.NewRedHatClusterServiceVersion(workshop, r.Scheme, kialiCSV, KIALI_SUBSCRIPTION_NAMESPACE_NAME)
-	if err := r.Delete(context.TODO(), kialiOperatorCSV); err != nil {
+	if err := kubernetes.DeleteIfExists(r, kialiOperatorCSV); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s ClusterServiceVersion", kialiOperatorCSV.Name)
 
 	JaegerOperatorCSV := kubernetes.NewRedHatClusterServiceVersion(workshop, r.Scheme, JaegerCSV, JAEGER_SUBSCRIPTION_NAMESPACE_NAME)
-	if err := r.Delete(context.TODO(), JaegerOperatorCSV); err != nil {
+	if err := kubernetes.DeleteIfExists(r, JaegerOperatorCSV); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s ClusterServiceVersion", JaegerOperatorCSV.Name)
@@ -556,52 +589,25 @@ func (r *WorkshopReconciler) deleteCSV(workshop *workshopv1.Workshop, servicemes
 // Patch istio-system Project
 func (r *WorkshopReconciler) PatchIstioProject(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
-	namespaceFound := kubernetes.NewNamespace(workshop, r.Scheme, ISTIO_NAMESPACE_NAME)
-
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: ISTIO_NAMESPACE_NAME}, namespaceFound); err != nil {
-		return reconcile.Result{}, err
-	}
-
-	if namespaceFound.Spec.Finalizers[0] == "kubernetes" {
-		servicemeshcontrolplanes := &maistrav2.ServiceMeshControlPlane{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: SERVICE_MESH_CONTROL_PLANE_NAME, Namespace: ISTIO_NAMESPACE_NAME}, servicemeshcontrolplanes); err != nil {
-			return reconcile.Result{}, err
-		}
-
-		patch := client.MergeFrom(servicemeshcontrolplanes.DeepCopy())
-		servicemeshcontrolplanes.Finalizers = nil
-		if err := r.Patch(context.TODO(), servicemeshcontrolplanes, patch); err != nil {
-			return reconcile.Result{}, err
+	namespaceFound := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, ISTIO_NAMESPACE_NAME))
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: util.ScopedName(workshop, ISTIO_NAMESPACE_NAME)}, namespaceFound); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
 		}
-		log.Infof("patched %s ServiceMeshControlPlane", servicemeshcontrolplanes.Name)
+		return reconcile.Result{}, err
 	}
 
-	if namespaceFound.Spec.Finalizers[0] == "kubernetes" {
-		kialiFound := &kiali.Kiali{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: KIALI_NAME, Namespace: ISTIO_NAMESPACE_NAME}, kialiFound); err != nil {
-			return reconcile.Result{}, err
-		}
-
-		patch := client.MergeFrom(kialiFound.DeepCopy())
-		kialiFound.Finalizers = nil
-		if err := r.Patch(context.TODO(), kialiFound, patch); err != nil {
+	if len(namespaceFound.Spec.Finalizers) > 0 && namespaceFound.Spec.Finalizers[0] == "kubernetes" {
+		if err := kubernetes.RemoveFinalizers(r, SERVICE_MESH_CONTROL_PLANE_NAME, util.ScopedName(workshop, ISTIO_NAMESPACE_NAME), &maistrav2.ServiceMeshControlPlane{}); err != nil {
 			return reconcile.Result{}, err
 		}
-		log.Infof("patched %s kiali", kialiFound.Name)
-	}
-
-	if namespaceFound.Spec.Finalizers[0] == "kubernetes" {
-		serviceMeshMemberRoll := &maistrav1.ServiceMeshMemberRoll{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: SERVICE_MESH_MEMBER_ROLL_NAME, Namespace: ISTIO_NAMESPACE_NAME}, serviceMeshMemberRoll); err != nil {
+		if err := kubernetes.RemoveFinalizers(r, KIALI_NAME, util.ScopedName(workshop, ISTIO_NAMESPACE_NAME), &kiali.Kiali{}); err != nil {
 			return reconcile.Result{}, err
 		}
-
-		patch := client.MergeFrom(serviceMeshMemberRoll.DeepCopy())
-		serviceMeshMemberRoll.Finalizers = nil
-		if err := r.Patch(context.TODO(), serviceMeshMemberRoll, patch); err != nil {
+		if err := kubernetes.RemoveFinalizers(r, SERVICE_MESH_MEMBER_ROLL_NAME, util.ScopedName(workshop, ISTIO_NAMESPACE_NAME), &maistrav1.ServiceMeshMemberRoll{}); err != nil {
 			return reconcile.Result{}, err
 		}
-		log.Infof("patched %s ServiceMeshMemberRoll", serviceMeshMemberRoll.Name)
+		log.Infof("Removed finalizers in %s Project", namespaceFound.Name)
 	}
 
 	//Success
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/component"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// isSharedWithOtherWorkshops returns true if another workshop still uses a cluster-wide resource, e.g. an OLM subscription.
+// Workshops reference shared resources through their spec, so the last workshop using a resource removes it.
+func (r *WorkshopReconciler) isSharedWithOtherWorkshops(workshop *workshopv1.Workshop, uses func(spec *workshopv1.WorkshopSpec) bool) (bool, error) {
+	workshops := &workshopv1.WorkshopList{}
+	if err := r.List(context.TODO(), workshops); err != nil {
+		return false, err
+	}
+
+	for _, other := range workshops.Items {
+		// Workshops being deleted release their references
+		if other.UID == workshop.UID || other.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if uses(&other.Spec) {
+			log.Infof("Keeping shared resources of %s Workshop, still used by %s/%s", workshop.Name, other.Namespace, other.Name)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// shareObject marks a cluster-wide object as shared by the workshops using component, in place of the labels of the
+// workshop applying it, so workshops sharing the object do not rewrite its labels and re-enqueue each other
+func shareObject(obj metav1.Object, component string) {
+	obj.SetLabels(kubernetes.SharedLabels(component, obj.GetLabels()))
+}
+
+// workshopsForSharedObject maps a shared object to every workshop using its component
+func (r *WorkshopReconciler) workshopsForSharedObject(obj handler.MapObject) []reconcile.Request {
+	if obj.Meta == nil {
+		return nil
+	}
+	name, ok := obj.Meta.GetLabels()[kubernetes.WORKSHOP_SHARED_LABEL]
+	if !ok {
+		return nil
+	}
+	var shared component.Component
+	if r.graph != nil {
+		for _, c := range r.graph.Sorted() {
+			if c.Name() == name {
+				shared = c
+			}
+		}
+	}
+
+	workshops := &workshopv1.WorkshopList{}
+	if err := r.List(context.TODO(), workshops); err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for i := range workshops.Items {
+		workshop := &workshops.Items[i]
+		// Objects of unknown components are mapped to every workshop
+		if shared == nil || shared.Enabled(&workshop.Spec) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: workshop.Name, Namespace: workshop.Namespace}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/component"
+	"github.com/stakater/workshop-operator/common/platform"
+	"github.com/stakater/workshop-operator/common/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const CONDITION_TYPE_SUFFIX = "Ready"
+
+// componentConditionType returns the condition type of a component, e.g. GiteaReady
+func componentConditionType(component string) string {
+	return component + CONDITION_TYPE_SUFFIX
+}
+
+// setComponentStatus records the phase and condition of a component from the outcome of its reconcile
+func (r *WorkshopReconciler) setComponentStatus(workshop *workshopv1.Workshop, c component.Component,
+	enabled bool, result reconcile.Result, err error) {
+
+	name := c.Name()
+	condition := workshopv1.Condition{
+		Type:               componentConditionType(name),
+		ObservedGeneration: workshop.Generation,
+	}
+
+	status := util.OperatorStatus.Installed
+	switch {
+	case !enabled:
+		status = util.OperatorStatus.NotScheduled
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = util.ConditionReason.NotScheduled
+		condition.Message = fmt.Sprintf("%s is not enabled", name)
+	case err != nil:
+		status = util.OperatorStatus.Failed
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = util.ConditionReason.Failed
+		condition.Message = err.Error()
+	case util.IsRequeued(result, err):
+		status = util.OperatorStatus.InProgress
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = util.ConditionReason.InProgress
+		condition.Message = fmt.Sprintf("Waiting for %s to become ready", name)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = util.ConditionReason.Installed
+		condition.Message = fmt.Sprintf("%s is installed", name)
+	}
+
+	if phase := c.Status(&workshop.Status); phase != nil {
+		*phase = status
+	}
+	util.SetCondition(&workshop.Status.Conditions, condition)
+}
+
+// setComponentRemoval records the progress of removing a component that was disabled in the spec
+func (r *WorkshopReconciler) setComponentRemoval(workshop *workshopv1.Workshop, c component.Component, result reconcile.Result, err error) {
+	name := c.Name()
+	condition := workshopv1.Condition{
+		Type:               componentConditionType(name),
+		ObservedGeneration: workshop.Generation,
+	}
+
+	status := util.OperatorStatus.NotScheduled
+	switch {
+	case err != nil:
+		status = util.OperatorStatus.Failed
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = util.ConditionReason.Failed
+		condition.Message = fmt.Sprintf("Failed to remove %s: %s", name, err)
+	case util.IsRequeued(result, err):
+		status = util.OperatorStatus.Removing
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = util.ConditionReason.Removing
+		condition.Message = fmt.Sprintf("Removing %s", name)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = util.ConditionReason.Removed
+		condition.Message = fmt.Sprintf("%s was removed", name)
+	}
+
+	if phase := c.Status(&workshop.Status); phase != nil {
+		*phase = status
+	}
+	util.SetCondition(&workshop.Status.Conditions, condition)
+}
+
+// needsRemoval returns true if a component has been provisioned before and not removed since
+func needsRemoval(workshop *workshopv1.Workshop, c component.Component) bool {
+	condition := util.FindCondition(workshop.Status.Conditions, componentConditionType(c.Name()))
+	return condition != nil && !isRemoved(workshop, c)
+}
+
+// isRemoved returns true if a component was never scheduled, is not supported or has been removed
+func isRemoved(workshop *workshopv1.Workshop, c component.Component) bool {
+	condition := util.FindCondition(workshop.Status.Conditions, componentConditionType(c.Name()))
+	if condition == nil {
+		return false
+	}
+	return condition.Reason == util.ConditionReason.NotScheduled || condition.Reason == util.ConditionReason.Removed ||
+		condition.Reason == util.ConditionReason.Unsupported
+}
+
+// setComponentUnsupported records that an enabled component cannot run on the platform
+func (r *WorkshopReconciler) setComponentUnsupported(workshop *workshopv1.Workshop, c component.Component, p platform.Platform) {
+	if phase := c.Status(&workshop.Status); phase != nil {
+		*phase = util.OperatorStatus.Unsupported
+	}
+	util.SetCondition(&workshop.Status.Conditions, workshopv1.Condition{
+		Type:               componentConditionType(c.Name()),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: workshop.Generation,
+		Reason:             util.ConditionReason.Unsupported,
+		Message:            fmt.Sprintf("%s is not supported on %s, disable it in the workshop spec", c.Name(), p),
+	})
+}
+
+// setComponentWaiting records that a component is waiting on dependencies that are not ready yet
+func (r *WorkshopReconciler) setComponentWaiting(workshop *workshopv1.Workshop, c component.Component, waitingOn []string) {
+	if phase := c.Status(&workshop.Status); phase != nil {
+		*phase = util.OperatorStatus.Waiting
+	}
+	util.SetCondition(&workshop.Status.Conditions, workshopv1.Condition{
+		Type:               componentConditionType(c.Name()),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: workshop.Generation,
+		Reason:             util.ConditionReason.Waiting,
+		Message:            fmt.Sprintf("Waiting on %s", strings.Join(waitingOn, ", ")),
+	})
+}
+
+// setLoginCondition reports whether attendees can log in, which takes their users and on OpenShift the rolled out identity provider
+func (r *WorkshopReconciler) setLoginCondition(workshop *workshopv1.Workshop) {
+	login := workshopv1.Condition{
+		Type:               workshopv1.ConditionUsersCanLogIn,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workshop.Generation,
+		Reason:             util.ConditionReason.Installed,
+		Message:            "Attendees can log in",
+	}
+	for _, name := range []string{"Users", "OAuth"} {
+		condition := util.FindCondition(workshop.Status.Conditions, componentConditionType(name))
+		// Without an OAuth server attendees log in with Service Account tokens
+		if condition == nil || condition.Reason == util.ConditionReason.Unsupported {
+			continue
+		}
+		if condition.Status != metav1.ConditionTrue {
+			login.Status = metav1.ConditionFalse
+			login.Reason = condition.Reason
+			login.Message = fmt.Sprintf("%s: %s", name, condition.Message)
+			break
+		}
+	}
+	util.SetCondition(&workshop.Status.Conditions, login)
+}
+
+// setReadyCondition aggregates the component conditions into the overall Ready condition
+func (r *WorkshopReconciler) setReadyCondition(workshop *workshopv1.Workshop, err error) {
+	ready := workshopv1.Condition{
+		Type:               workshopv1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: workshop.Generation,
+		Reason:             util.ConditionReason.Installed,
+		Message:            "All components are ready",
+	}
+
+	if workshop.GetDeletionTimestamp() != nil {
+		r.setDeletingCondition(workshop, err)
+		return
+	}
+	if isPlanMode(workshop) && err == nil {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = util.ConditionReason.Planned
+		ready.Message = "Plan mode is enabled, no changes are applied"
+		util.SetCondition(&workshop.Status.Conditions, ready)
+		return
+	}
+
+	var notReady *workshopv1.Condition
+	for _, condition := range workshop.Status.Conditions {
+		if condition.Type == workshopv1.ConditionReady || !strings.HasSuffix(condition.Type, CONDITION_TYPE_SUFFIX) {
+			continue
+		}
+		if condition.Status != metav1.ConditionTrue && (notReady == nil || notReady.Reason == util.ConditionReason.Waiting) {
+			notReady = condition.DeepCopy()
+		}
+	}
+	// Report the component that is actually stuck rather than one waiting on it
+	if notReady != nil {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = notReady.Reason
+		ready.Message = fmt.Sprintf("%s: %s", strings.TrimSuffix(notReady.Type, CONDITION_TYPE_SUFFIX), notReady.Message)
+	}
+
+	if err != nil && ready.Status == metav1.ConditionTrue {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = util.ConditionReason.Failed
+		ready.Message = err.Error()
+	}
+
+	util.SetCondition(&workshop.Status.Conditions, ready)
+}
+
+// setDeletingCondition reports the components still left to remove while the workshop is being deleted
+func (r *WorkshopReconciler) setDeletingCondition(workshop *workshopv1.Workshop, err error) {
+	remaining := []string{}
+	for _, condition := range workshop.Status.Conditions {
+		if condition.Type == workshopv1.ConditionReady || !strings.HasSuffix(condition.Type, CONDITION_TYPE_SUFFIX) {
+			continue
+		}
+		if condition.Reason != util.ConditionReason.Removed && condition.Reason != util.ConditionReason.NotScheduled &&
+			condition.Reason != util.ConditionReason.Unsupported {
+			remaining = append(remaining, strings.TrimSuffix(condition.Type, CONDITION_TYPE_SUFFIX))
+		}
+	}
+
+	deleting := workshopv1.Condition{
+		Type:               workshopv1.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: workshop.Generation,
+		Reason:             util.ConditionReason.Removing,
+		Message:            fmt.Sprintf("Deleting workshop, remaining: %s", strings.Join(remaining, ", ")),
+	}
+	if err != nil {
+		deleting.Reason = util.ConditionReason.Failed
+		deleting.Message = fmt.Sprintf("%s, last error: %s", deleting.Message, err)
+	}
+	util.SetCondition(&workshop.Status.Conditions, deleting)
+}
+
+// updateStatus writes the workshop status and passes the reconcile outcome through
+func (r *WorkshopReconciler) updateStatus(workshop *workshopv1.Workshop, result reconcile.Result, err error) (reconcile.Result, error) {
+	workshop.Status.ObservedGeneration = workshop.Generation
+	if err != nil {
+		workshop.Status.LastError = err.Error()
+	} else {
+		workshop.Status.LastError = ""
+	}
+	r.setReadyCondition(workshop, err)
+
+	if updateErr := r.Status().Update(context.TODO(), workshop); updateErr != nil {
+		log.Errorf("Failed to update %s Workshop status: %s", workshop.Name, updateErr)
+		if err == nil {
+			return reconcile.Result{}, updateErr
+		}
+	}
+	return result, err
+}
@@ -2,11 +2,10 @@ package controllers
 
 import (
 	"context"
-	"encoding/base64"
-	"fmt"
 	userv1 "github.com/openshift/api/user/v1"
 	"github.com/prometheus/common/log"
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/kubernetes"
 	openshiftuser "github.com/stakater/workshop-operator/common/user"
 	"github.com/stakater/workshop-operator/common/util"
 	corev1 "k8s.io/api/core/v1"
@@ -14,10 +13,9 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"os/exec"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
-	"strings"
 )
 
 const (
@@ -25,73 +23,79 @@ const (
 	HTPASSWD_SECRET_NAMESPACE_NAME   = "openshift-config"
 	USER_ROLE_BINDING_NAMESPACE_NAME = "workshop-infra"
 	IDENTITY_NAME                    = "htpass-workshop-users"
-	USER_IDENTITY_MAPPING_NAME       = "htpass-workshop-users"
-	USER_LABEL_SELECTOR              = "createdBy=WorkshopOperator"
 )
 
 var userLabels = map[string]string{
 	"createdBy": "WorkshopOperator",
 }
 
-func (r *WorkshopReconciler) reconcileUser(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+func (r *WorkshopReconciler) reconcileUser(workshop *workshopv1.Workshop, attendees []workshopv1.Attendee) (reconcile.Result, error) {
+	// Plain Kubernetes has no users, attendees get a Service Account instead
+	if !r.Platform.IsOpenShift() {
+		return r.reconcileServiceAccountUsers(workshop, attendees)
+	}
+	// Attendees of an external identity provider have their own users, the users created earlier cannot log in anymore
+	if isExternalIdentityProvider(workshop) {
+		if result, err := r.deleteHTPasswdUsers(workshop); util.IsRequeued(result, err) {
+			return result, err
+		}
+		return r.reconcileGroups(workshop, attendees)
+	}
+
 	createUsers := make(map[string]bool)
-	totalUsers := workshop.Spec.UserDetails.NumberOfUsers
-	userPrefix := workshop.Spec.UserDetails.UserNamePrefix
-	for userSuffix := 1; userSuffix <= totalUsers; userSuffix++ {
-		userName := fmt.Sprint(userPrefix, userSuffix)
-		createUsers[userName] = true
+	for _, attendee := range attendees {
+		createUsers[attendee.Name] = true
 	}
 
 	listUsers, err := r.createdUserList(workshop)
 	if err != nil {
 		log.Errorf("Failed to get Created User List {%s} ", err)
+		return reconcile.Result{}, err
 	}
 
 	for _, user := range listUsers.Items {
 		username := user.Name
-		_, ok := createUsers[username]
-		if ok {
-			createUsers[username] = false
-		} else {
+		if _, ok := createUsers[username]; !ok {
 			if result, err := r.deleteOpenshiftUser(workshop, r.Scheme, username); util.IsRequeued(result, err) {
 				return result, err
 			}
 		}
 	}
 
-	for username, value := range createUsers {
-		if value {
-			if result, err := r.addUser(workshop, r.Scheme, username); util.IsRequeued(result, err) {
-				return result, err
-			}
+	// Apply every attendee, so changes to their email or display name are picked up
+	for _, attendee := range attendees {
+		if result, err := r.addUser(workshop, r.Scheme, attendee); util.IsRequeued(result, err) {
+			return result, err
 		}
 	}
 	if result, err := r.createUserHtpasswd(workshop, createUsers); util.IsRequeued(result, err) {
 		return result, err
 	}
+	if result, err := r.reconcileGroups(workshop, attendees); util.IsRequeued(result, err) {
+		return result, err
+	}
 
 	//Success
 	return reconcile.Result{}, nil
 }
 
 // Add user in openshift cluster
-func (r *WorkshopReconciler) addUser(workshop *workshopv1.Workshop, scheme *runtime.Scheme, username string) (reconcile.Result, error) {
+func (r *WorkshopReconciler) addUser(workshop *workshopv1.Workshop, scheme *runtime.Scheme, attendee workshopv1.Attendee) (reconcile.Result, error) {
+	username := attendee.Name
 
 	//Create User
-	user := openshiftuser.NewUser(workshop, r.Scheme, username, userLabels)
-	if err := r.Create(context.TODO(), user); err != nil && !errors.IsAlreadyExists(err) {
+	user := openshiftuser.NewUser(workshop, r.Scheme, attendee, userLabels)
+	if op, err := kubernetes.Apply(r, r.Scheme, user); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s User", user.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s User", user.Name)
 	}
 
-	// Create User Role Binding
+	// Roles are granted to the groups, delete the User Role Binding of earlier versions
 	userRoleBinding := openshiftuser.NewUserRoleBinding(workshop, r.Scheme, username, USER_ROLE_BINDING_NAMESPACE_NAME,
 		USER_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
-	if err := r.Create(context.TODO(), userRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
+	if err := kubernetes.DeleteIfExists(r, userRoleBinding); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Role Binding", userRoleBinding.Name)
 	}
 
 	// Get User
@@ -102,15 +106,15 @@ func (r *WorkshopReconciler) addUser(workshop *workshopv1.Workshop, scheme *runt
 	}
 
 	// Create Identity
-	identity := openshiftuser.NewIdentity(workshop, r.Scheme, username, IDENTITY_NAME, userFound)
-	if err := r.Create(context.TODO(), identity); err != nil && !errors.IsAlreadyExists(err) {
+	identity := openshiftuser.NewIdentity(workshop, r.Scheme, attendee, identityProviderName(workshop), userFound)
+	if op, err := kubernetes.Apply(r, r.Scheme, identity); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Identity ", identity.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Identity ", identity.Name)
 	}
 
-	// Create User Identity Mapping
-	userIdentity := openshiftuser.NewUserIdentityMapping(workshop, r.Scheme, USER_IDENTITY_MAPPING_NAME, username)
+	// Create User Identity Mapping, a virtual resource that can neither be watched nor applied
+	userIdentity := openshiftuser.NewUserIdentityMapping(workshop, r.Scheme, identityProviderName(workshop), username)
 	if err := r.Create(context.TODO(), userIdentity); err != nil && !errors.IsAlreadyExists(err) {
 		return reconcile.Result{}, err
 	} else if err == nil {
@@ -123,57 +127,42 @@ func (r *WorkshopReconciler) addUser(workshop *workshopv1.Workshop, scheme *runt
 
 func (r *WorkshopReconciler) createUserHtpasswd(workshop *workshopv1.Workshop, users map[string]bool) (reconcile.Result, error) {
 
-	var htpasswds []byte
-	var countUsers int
-	createUsers := []string{}
-
-	password := workshop.Spec.UserDetails.DefaultPassword
-	totalUsers := workshop.Spec.UserDetails.NumberOfUsers
-	userPrefix := workshop.Spec.UserDetails.UserNamePrefix
-
-	for usersname := range users {
-		createUsers = append(createUsers, usersname)
+	passwords := make(map[string]string)
+	for username := range users {
+		password, err := r.userPassword(workshop, username)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		passwords[username] = password
 	}
 
-	for _, username := range createUsers {
-		command := "echo \"password\" | htpasswd -b -B -i -n " + username
-		updateCommad := fmt.Sprint(strings.Replace(command, "password", password, -1))
-		out, err := exec.Command("/bin/bash", "-c", updateCommad).Output()
+	secretFound := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: util.ScopedName(workshop, HTPASSWD_SECRET_NAME), Namespace: HTPASSWD_SECRET_NAMESPACE_NAME}, secretFound); err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	htpasswds := secretFound.Data[openshiftuser.HTPASSWD_KEY]
+	fingerprint := secretFound.Annotations[openshiftuser.HTPASSWD_FINGERPRINT_ANNOTATION]
+
+	// The hashes are only checked when the users or their passwords changed since the secret was written
+	if !openshiftuser.HTPasswdMatches(fingerprint, passwords, htpasswds) {
+		// Reuse the hashes of unchanged passwords, hashes are salted so regenerating them would change the secret
+		var err error
+		htpasswds, err = openshiftuser.NewHTPasswd(passwords, openshiftuser.ParseHTPasswd(htpasswds))
 		if err != nil {
-			log.Errorf("Failed to Execute Bash Command %s", err)
+			return reconcile.Result{}, err
+		}
+		fingerprint, err = openshiftuser.HTPasswdFingerprint(passwords, htpasswds)
+		if err != nil {
+			return reconcile.Result{}, err
 		}
-		userpwd := fmt.Sprint(strings.TrimSpace(string(out)), "\n")
-		htpasswds = append(htpasswds, []byte(userpwd)...)
 	}
 
-	htpasswdSecret := openshiftuser.NewHTPasswdSecret(workshop, r.Scheme, HTPASSWD_SECRET_NAME, HTPASSWD_SECRET_NAMESPACE_NAME, htpasswds)
-	if err := r.Create(context.TODO(), htpasswdSecret); err != nil && !errors.IsAlreadyExists(err) {
+	htpasswdSecret := openshiftuser.NewHTPasswdSecret(workshop, r.Scheme, util.ScopedName(workshop, HTPASSWD_SECRET_NAME), HTPASSWD_SECRET_NAMESPACE_NAME, htpasswds)
+	htpasswdSecret.Annotations = map[string]string{openshiftuser.HTPASSWD_FINGERPRINT_ANNOTATION: fingerprint}
+	if op, err := kubernetes.Apply(r, r.Scheme, htpasswdSecret); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created  %s Secret", htpasswdSecret.Name)
-	} else if errors.IsAlreadyExists(err) {
-		// Get secret
-		secretFound := &corev1.Secret{}
-		if err := r.Get(context.TODO(), types.NamespacedName{Name: HTPASSWD_SECRET_NAME, Namespace: HTPASSWD_SECRET_NAMESPACE_NAME}, secretFound); err == nil {
-			for _, secretData := range secretFound.Data {
-				encodedSecret := base64.StdEncoding.EncodeToString(secretData)
-				decodeSecret, err := base64.StdEncoding.DecodeString(encodedSecret)
-				if err != nil {
-					log.Errorf("Failed to Decode Secret %s", err)
-				}
-				countUsers = strings.Count(string(decodeSecret), userPrefix)
-			}
-			if totalUsers > countUsers || totalUsers < countUsers {
-				if err := r.Delete(context.TODO(), htpasswdSecret); err != nil {
-					return reconcile.Result{}, err
-				}
-				if err := r.Create(context.TODO(), htpasswdSecret); err != nil && !errors.IsAlreadyExists(err) {
-					return reconcile.Result{}, err
-				} else if err == nil {
-					log.Infof("Created  %s secret", htpasswdSecret.Name)
-				}
-			}
-		}
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Secret", htpasswdSecret.Name)
 	}
 
 	return reconcile.Result{}, nil
@@ -181,10 +170,22 @@ func (r *WorkshopReconciler) createUserHtpasswd(workshop *workshopv1.Workshop, u
 
 // deleteUsers delete users in openshift cluster
 func (r *WorkshopReconciler) deleteUsers(workshop *workshopv1.Workshop) (reconcile.Result, error) {
+	if !r.Platform.IsOpenShift() {
+		return r.deleteServiceAccountUsers(workshop)
+	}
 
+	if result, err := r.deleteGroups(workshop); util.IsRequeued(result, err) {
+		return result, err
+	}
+	return r.deleteHTPasswdUsers(workshop)
+}
+
+// deleteHTPasswdUsers delete the users created for the htpasswd identity provider and their htpasswd secret
+func (r *WorkshopReconciler) deleteHTPasswdUsers(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 	listUsers, err := r.createdUserList(workshop)
 	if err != nil {
 		log.Errorf("Failed to get Created User List {%s} ", err)
+		return reconcile.Result{}, err
 	}
 
 	for _, user := range listUsers.Items {
@@ -212,15 +213,15 @@ func (r *WorkshopReconciler) deleteOpenshiftUser(workshop *workshopv1.Workshop,
 	}
 	//
 	// Delete User Identity Mapping
-	userIdentity := openshiftuser.NewUserIdentityMapping(workshop, r.Scheme, USER_IDENTITY_MAPPING_NAME, username)
-	if err := r.Delete(context.TODO(), userIdentity); err != nil {
+	userIdentity := openshiftuser.NewUserIdentityMapping(workshop, r.Scheme, identityProviderName(workshop), username)
+	if err := kubernetes.DeleteIfExists(r, userIdentity); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s User Identity Mapping ", userIdentity.Name)
 
 	// Delete Identity
-	identity := openshiftuser.NewIdentity(workshop, r.Scheme, username, IDENTITY_NAME, userFound)
-	if err := r.Delete(context.TODO(), identity); err != nil {
+	identity := openshiftuser.NewIdentity(workshop, r.Scheme, workshopv1.Attendee{Name: username}, identityProviderName(workshop), userFound)
+	if err := kubernetes.DeleteIfExists(r, identity); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Identity  ", identity.Name)
@@ -228,14 +229,14 @@ func (r *WorkshopReconciler) deleteOpenshiftUser(workshop *workshopv1.Workshop,
 	// Delete User Role Binding
 	userRoleBinding := openshiftuser.NewUserRoleBinding(workshop, r.Scheme, username, USER_ROLE_BINDING_NAMESPACE_NAME,
 		USER_ROLE_BINDING_NAME, KIND_CLUSTER_ROLE)
-	if err := r.Delete(context.TODO(), userRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, userRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Role Binding", userRoleBinding.Name)
 
 	// Delete User
-	user := openshiftuser.NewUser(workshop, r.Scheme, username, userLabels)
-	if err := r.Delete(context.TODO(), user); err != nil {
+	user := openshiftuser.NewUser(workshop, r.Scheme, workshopv1.Attendee{Name: username}, userLabels)
+	if err := kubernetes.DeleteIfExists(r, user); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s user", user.Name)
@@ -247,8 +248,8 @@ func (r *WorkshopReconciler) deleteOpenshiftUser(workshop *workshopv1.Workshop,
 //deleteUserHtpasswd delete Htpasswd secret for users
 func (r *WorkshopReconciler) deleteUserHtpasswd(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
-	htpasswdSecret := openshiftuser.NewHTPasswdSecret(workshop, r.Scheme, HTPASSWD_SECRET_NAME, HTPASSWD_SECRET_NAMESPACE_NAME, []byte(""))
-	if err := r.Delete(context.TODO(), htpasswdSecret); err != nil {
+	htpasswdSecret := openshiftuser.NewHTPasswdSecret(workshop, r.Scheme, util.ScopedName(workshop, HTPASSWD_SECRET_NAME), HTPASSWD_SECRET_NAMESPACE_NAME, []byte(""))
+	if err := kubernetes.DeleteIfExists(r, htpasswdSecret); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s HTPasswd Secret", htpasswdSecret.Name)
@@ -256,18 +257,17 @@ func (r *WorkshopReconciler) deleteUserHtpasswd(workshop *workshopv1.Workshop) (
 	return reconcile.Result{}, nil
 }
 
-//createdUserList return list of users
+//createdUserList return list of users created for the workshop
 func (r *WorkshopReconciler) createdUserList(workshop *workshopv1.Workshop) (*userv1.UserList, error) {
-	labelSelector, err := labels.Parse(USER_LABEL_SELECTOR)
-	if err != nil {
-		log.Errorf("Failed to get list of users %s", err)
-	}
+	// Only select users of this workshop, other workshops on the cluster create users with the same label
+	labelSelector := labels.SelectorFromSet(kubernetes.WorkshopLabels(workshop, userLabels))
 	listUsers := &userv1.UserList{}
 	listOps := &client.ListOptions{
 		LabelSelector: labelSelector,
 	}
 	// list User
-	if err := r.List(context.TODO(), listUsers, listOps); err != nil {
+	err := r.List(context.TODO(), listUsers, listOps)
+	if err != nil {
 		log.Errorf("Failed to get  list of  users, filtered by labelSelector {%s} ,{%s}", labelSelector, err)
 	}
 	return listUsers, err
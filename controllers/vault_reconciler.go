@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -53,6 +54,7 @@ const (
 	VAULT_ROLEBINDING_ROLE_NAME    = "system:auth-delegator"
 	KIND_CLUSTER_ROLE              = "ClusterRole"
 	VAULT_SERVICEACCOUNT_NAME      = "vault"
+	VAULT_SCC_NAME                 = "vault"
 	VAULT_CONFIGMAP_NAME           = "vault-config"
 	VAULTAGENT_WEBHOOK_NAME        = "vault-agent-injector-cfg"
 	VAULTAGENT_DEPLOYMENT_NAME     = "vault-agent-injector"
@@ -87,38 +89,39 @@ func (r *WorkshopReconciler) addVaultServer(workshop *workshopv1.Workshop) (reco
 	log.Infoln("Creating VaultServer ")
 
 	// Create Namespace
-	vaultNamespace := kubernetes.NewNamespace(workshop, r.Scheme, VAULT_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), vaultNamespace); err != nil && !errors.IsAlreadyExists(err) {
+	vaultNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, VAULT_NAMESPACE_NAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, vaultNamespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Vault Project", vaultNamespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Vault Project", vaultNamespace.Name)
 	}
 
-	configMap := kubernetes.NewConfigMap(workshop, r.Scheme, VAULT_CONFIGMAP_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels, ExtraConfigFromValues)
-	if err := r.Create(context.TODO(), configMap); err != nil && !errors.IsAlreadyExists(err) {
+	configMap := kubernetes.NewConfigMap(workshop, r.Scheme, VAULT_CONFIGMAP_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels, ExtraConfigFromValues)
+	if op, err := kubernetes.Apply(r, r.Scheme, configMap); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Vault ConfigMap", configMap.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Vault ConfigMap", configMap.Name)
 	}
 
 	// Create Service Account
-	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, VAULT_SERVICEACCOUNT_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels)
-	if err := r.Create(context.TODO(), serviceAccount); err != nil && !errors.IsAlreadyExists(err) {
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, VAULT_SERVICEACCOUNT_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels)
+	if op, err := kubernetes.Apply(r, r.Scheme, serviceAccount); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Vault Service Account", serviceAccount.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Vault Service Account", serviceAccount.Name)
 	}
 
 	// Create ServiceAccountUser
 	serviceAccountUser := "system:serviceaccount:" + vaultNamespace.Name + ":" + serviceAccount.Name
 
 	vaultSCC := &securityv1.SecurityContextConstraints{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: "vault"}, vaultSCC); err != nil {
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: util.ScopedName(workshop, VAULT_SCC_NAME)}, vaultSCC); err != nil {
 		privilegedSCCFound := &securityv1.SecurityContextConstraints{}
 		if err := r.Get(context.TODO(), types.NamespacedName{Name: "privileged"}, privilegedSCCFound); err == nil {
 			newVaultSCC := privilegedSCCFound.DeepCopy()
 			newVaultSCC.ObjectMeta = metav1.ObjectMeta{}
-			newVaultSCC.Name = "vault"
+			newVaultSCC.Name = util.ScopedName(workshop, VAULT_SCC_NAME)
+			newVaultSCC.Labels = kubernetes.WorkshopLabels(workshop, VaultServerLabels)
 
 			if !util.StringInSlice(serviceAccountUser, newVaultSCC.Users) {
 				newVaultSCC.Users = append(newVaultSCC.Users, serviceAccountUser)
@@ -141,36 +144,43 @@ func (r *WorkshopReconciler) addVaultServer(workshop *workshopv1.Workshop) (reco
 	}
 
 	// Create ClusterRole Binding
-	clusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, VAULT_ROLEBINDING_NAME, VAULT_NAMESPACE_NAME,
+	clusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, VAULT_ROLEBINDING_NAME), util.ScopedName(workshop, VAULT_NAMESPACE_NAME),
 		VaultServerLabels, serviceAccount.Name, VAULT_ROLEBINDING_ROLE_NAME, KIND_CLUSTER_ROLE)
-	if err := r.Create(context.TODO(), clusterRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, clusterRoleBinding); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Vault Cluster Role Binding", clusterRoleBinding.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Vault Cluster Role Binding", clusterRoleBinding.Name)
 	}
 
 	// Create Service
-	internalService := kubernetes.NewService(workshop, r.Scheme, VAULT_INTERNAL_SERVICE_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels, []string{"http", "internal"}, []int32{8200, 8201})
-	if err := r.Create(context.TODO(), internalService); err != nil && !errors.IsAlreadyExists(err) {
+	internalService := kubernetes.NewService(workshop, r.Scheme, VAULT_INTERNAL_SERVICE_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels, []string{"http", "internal"}, []int32{8200, 8201})
+	if op, err := kubernetes.Apply(r, r.Scheme, internalService); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Vault Service", internalService.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Vault Service", internalService.Name)
 	}
 
 	// Create Service
-	service := kubernetes.NewService(workshop, r.Scheme, VAULT_SERVICE_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels, []string{"http", "internal"}, []int32{8200, 8201})
-	if err := r.Create(context.TODO(), service); err != nil && !errors.IsAlreadyExists(err) {
+	service := kubernetes.NewService(workshop, r.Scheme, VAULT_SERVICE_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels, []string{"http", "internal"}, []int32{8200, 8201})
+	if op, err := kubernetes.Apply(r, r.Scheme, service); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Vault Service", service.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Vault Service", service.Name)
 	}
 
 	// Create StatefulSet
-	stateful := vault.NewStatefulSet(workshop, r.Scheme, VAULT_STATEFULSET_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels)
-	if err := r.Create(context.TODO(), stateful); err != nil && !errors.IsAlreadyExists(err) {
+	stateful := vault.NewStatefulSet(workshop, r.Scheme, VAULT_STATEFULSET_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels)
+	if op, err := kubernetes.Apply(r, r.Scheme, stateful); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s Vault Stateful", stateful.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s Vault Stateful", stateful.Name)
+	}
+
+	// Wait for Vault Server to be running
+	if ready, err := kubernetes.IsStatefulSetReady(r, VAULT_STATEFULSET_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME)); err != nil {
+		return reconcile.Result{}, err
+	} else if !ready {
+		return reconcile.Result{Requeue: true}, nil
 	}
 
 	//Success
@@ -182,31 +192,32 @@ func (r *WorkshopReconciler) addVaultAgentInjector(workshop *workshopv1.Workshop
 	log.Infoln("Creating VaultAgent")
 
 	// Create Namespace
-	vaultNamespace := kubernetes.NewNamespace(workshop, r.Scheme, VAULT_NAMESPACE_NAME)
-	if err := r.Create(context.TODO(), vaultNamespace); err != nil && !errors.IsAlreadyExists(err) {
+	vaultNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, VAULT_NAMESPACE_NAME))
+	if op, err := kubernetes.Apply(r, r.Scheme, vaultNamespace); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s VaultAgent Project", vaultNamespace.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s VaultAgent Project", vaultNamespace.Name)
 	}
 
 	// Create Service Account
-	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, VAULTAGENT_SERVICEACCOUNT_NAME, VAULT_NAMESPACE_NAME, VaultAgentLabels)
-	if err := r.Create(context.TODO(), serviceAccount); err != nil && !errors.IsAlreadyExists(err) {
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, VAULTAGENT_SERVICEACCOUNT_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultAgentLabels)
+	if op, err := kubernetes.Apply(r, r.Scheme, serviceAccount); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s VaultAgent Service Account", serviceAccount.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s VaultAgent Service Account", serviceAccount.Name)
 	}
 
 	// Create ServiceAccountUser
 	serviceAccountUser := "system:serviceaccount:" + vaultNamespace.Name + ":" + serviceAccount.Name
 
 	vaultAgentSCC := &securityv1.SecurityContextConstraints{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: "vault"}, vaultAgentSCC); err != nil {
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: util.ScopedName(workshop, VAULT_SCC_NAME)}, vaultAgentSCC); err != nil {
 		privilegedSCCFound := &securityv1.SecurityContextConstraints{}
 		if err := r.Get(context.TODO(), types.NamespacedName{Name: "privileged"}, privilegedSCCFound); err == nil {
 			newVaultAgentSCC := privilegedSCCFound.DeepCopy()
 			newVaultAgentSCC.ObjectMeta = metav1.ObjectMeta{}
-			newVaultAgentSCC.Name = "vault"
+			newVaultAgentSCC.Name = util.ScopedName(workshop, VAULT_SCC_NAME)
+			newVaultAgentSCC.Labels = kubernetes.WorkshopLabels(workshop, VaultAgentLabels)
 
 			if !util.StringInSlice(serviceAccountUser, newVaultAgentSCC.Users) {
 				newVaultAgentSCC.Users = append(newVaultAgentSCC.Users, serviceAccountUser)
@@ -230,47 +241,47 @@ func (r *WorkshopReconciler) addVaultAgentInjector(workshop *workshopv1.Workshop
 
 	// Create Cluster Role
 	clusterRole := kubernetes.NewClusterRole(workshop, r.Scheme,
-		VAULTAGENT_CLUSTERROLE_NAME, VAULT_NAMESPACE_NAME, VaultAgentLabels, kubernetes.VaultAgentInjectorRules())
-	if err := r.Create(context.TODO(), clusterRole); err != nil && !errors.IsAlreadyExists(err) {
+		util.ScopedName(workshop, VAULTAGENT_CLUSTERROLE_NAME), util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultAgentLabels, kubernetes.VaultAgentInjectorRules())
+	if op, err := kubernetes.Apply(r, r.Scheme, clusterRole); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s VaultAgent Cluster Role", clusterRole.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s VaultAgent Cluster Role", clusterRole.Name)
 	}
 
 	// Create Cluster Role Binding
-	clusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, VAULTAGENT_ROLEBINDING_NAME, VAULT_NAMESPACE_NAME,
+	clusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, VAULTAGENT_ROLEBINDING_NAME), util.ScopedName(workshop, VAULT_NAMESPACE_NAME),
 		VaultAgentLabels, VAULTAGENT_SERVICEACCOUNT_NAME, clusterRole.Name, KIND_CLUSTER_ROLE)
-	if err := r.Create(context.TODO(), clusterRoleBinding); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, clusterRoleBinding); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s VaultAgent Cluster Role Binding", clusterRoleBinding.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s VaultAgent Cluster Role Binding", clusterRoleBinding.Name)
 	}
 
 	// Create Service
-	service := kubernetes.NewServiceWithTarget(workshop, r.Scheme, VAULTAGENT_SERVICE_NAME, VAULT_NAMESPACE_NAME, VaultAgentLabels,
+	service := kubernetes.NewServiceWithTarget(workshop, r.Scheme, VAULTAGENT_SERVICE_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultAgentLabels,
 		[]string{"http"}, []int32{443}, []int32{8080})
-	if err := r.Create(context.TODO(), service); err != nil && !errors.IsAlreadyExists(err) {
+	if op, err := kubernetes.Apply(r, r.Scheme, service); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s VaultAgent Service", service.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s VaultAgent Service", service.Name)
 	}
 
 	// Create Deployment
-	ocpDeployment := vault.NewAgentInjectorDeployment(workshop, r.Scheme, VAULTAGENT_DEPLOYMENT_NAME, VAULT_NAMESPACE_NAME, VaultAgentLabels)
-	if err := r.Create(context.TODO(), ocpDeployment); err != nil && !errors.IsAlreadyExists(err) {
+	ocpDeployment := vault.NewAgentInjectorDeployment(workshop, r.Scheme, VAULTAGENT_DEPLOYMENT_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultAgentLabels)
+	if op, err := kubernetes.Apply(r, r.Scheme, ocpDeployment); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s VaultAgent Deployment", ocpDeployment.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s VaultAgent Deployment", ocpDeployment.Name)
 	}
 
 	// Create AgentInjectorWebHook
 	webhooks := vault.NewAgentInjectorWebHook(vaultNamespace.Name)
 	mutatingWebhookConfiguration := kubernetes.NewMutatingWebhookConfiguration(workshop, r.Scheme,
-		VAULTAGENT_WEBHOOK_NAME, VaultAgentLabels, webhooks)
-	if err := r.Create(context.TODO(), mutatingWebhookConfiguration); err != nil && !errors.IsAlreadyExists(err) {
+		util.ScopedName(workshop, VAULTAGENT_WEBHOOK_NAME), VaultAgentLabels, webhooks)
+	if op, err := kubernetes.Apply(r, r.Scheme, mutatingWebhookConfiguration); err != nil {
 		return reconcile.Result{}, err
-	} else if err == nil {
-		log.Infof("Created %s VaultAgent Mutating Webhook Configuration", mutatingWebhookConfiguration.Name)
+	} else if op != controllerutil.OperationResultNone {
+		log.Infof("Applied %s VaultAgent Mutating Webhook Configuration", mutatingWebhookConfiguration.Name)
 	}
 
 	//Success
@@ -298,54 +309,54 @@ func (r *WorkshopReconciler) deleteVaultServer(workshop *workshopv1.Workshop) (r
 	log.Infoln("Deleting VaultServer")
 
 	// last method last line
-	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, VAULT_SERVICEACCOUNT_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels)
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, VAULT_SERVICEACCOUNT_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels)
 
-	stateful := vault.NewStatefulSet(workshop, r.Scheme, VAULT_STATEFULSET_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels)
+	stateful := vault.NewStatefulSet(workshop, r.Scheme, VAULT_STATEFULSET_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels)
 	// Delete stateful
-	if err := r.Delete(context.TODO(), stateful); err != nil {
+	if err := kubernetes.DeleteIfExists(r, stateful); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultServer stateful", stateful.Name)
 
-	service := kubernetes.NewService(workshop, r.Scheme, VAULT_SERVICE_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels, []string{"http", "internal"}, []int32{8200, 8201})
+	service := kubernetes.NewService(workshop, r.Scheme, VAULT_SERVICE_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels, []string{"http", "internal"}, []int32{8200, 8201})
 	// Delete Service
-	if err := r.Delete(context.TODO(), service); err != nil {
+	if err := kubernetes.DeleteIfExists(r, service); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultServer Service", service.Name)
 
-	internalService := kubernetes.NewService(workshop, r.Scheme, VAULT_INTERNAL_SERVICE_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels, []string{"http", "internal"}, []int32{8200, 8201})
+	internalService := kubernetes.NewService(workshop, r.Scheme, VAULT_INTERNAL_SERVICE_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels, []string{"http", "internal"}, []int32{8200, 8201})
 	// Delete internal Service
-	if err := r.Delete(context.TODO(), internalService); err != nil {
+	if err := kubernetes.DeleteIfExists(r, internalService); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultServer internal Service", internalService.Name)
 
-	clusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, VAULT_ROLEBINDING_NAME, VAULT_NAMESPACE_NAME,
+	clusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, VAULT_ROLEBINDING_NAME), util.ScopedName(workshop, VAULT_NAMESPACE_NAME),
 		VaultServerLabels, serviceAccount.Name, VAULT_ROLEBINDING_ROLE_NAME, KIND_CLUSTER_ROLE)
 	// Delete ClusterRole Binding
-	if err := r.Delete(context.TODO(), clusterRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, clusterRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s  VaultServer ClusterRole Binding", clusterRoleBinding.Name)
 
 	vaultSCC := &securityv1.SecurityContextConstraints{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: "vault"}, vaultSCC); err == nil {
-		if err := r.Delete(context.TODO(), vaultSCC); err != nil {
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: util.ScopedName(workshop, VAULT_SCC_NAME)}, vaultSCC); err == nil {
+		if err := kubernetes.DeleteIfExists(r, vaultSCC); err != nil {
 			return reconcile.Result{}, err
 		}
 		log.Infof("Deleted %s vaultSCC ", vaultSCC.Name)
 	}
 
 	// Delete Service Account
-	if err := r.Delete(context.TODO(), serviceAccount); err != nil {
+	if err := kubernetes.DeleteIfExists(r, serviceAccount); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultServer Service Account", serviceAccount.Name)
 
-	configMap := kubernetes.NewConfigMap(workshop, r.Scheme, VAULT_CONFIGMAP_NAME, VAULT_NAMESPACE_NAME, VaultServerLabels, ExtraConfigFromValues)
+	configMap := kubernetes.NewConfigMap(workshop, r.Scheme, VAULT_CONFIGMAP_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultServerLabels, ExtraConfigFromValues)
 	// Delete configMap
-	if err := r.Delete(context.TODO(), configMap); err != nil {
+	if err := kubernetes.DeleteIfExists(r, configMap); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultServer configMap", configMap.Name)
@@ -359,59 +370,59 @@ func (r *WorkshopReconciler) deleteVaultServer(workshop *workshopv1.Workshop) (r
 func (r *WorkshopReconciler) deleteVaultAgentInjector(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 	log.Infoln("Deleting VaultAgent ")
 
-	vaultNamespace := kubernetes.NewNamespace(workshop, r.Scheme, VAULT_NAMESPACE_NAME)
+	vaultNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, VAULT_NAMESPACE_NAME))
 	clusterRole := kubernetes.NewClusterRole(workshop, r.Scheme,
-		VAULTAGENT_CLUSTERROLE_NAME, VAULT_NAMESPACE_NAME, VaultAgentLabels, kubernetes.VaultAgentInjectorRules())
+		util.ScopedName(workshop, VAULTAGENT_CLUSTERROLE_NAME), util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultAgentLabels, kubernetes.VaultAgentInjectorRules())
 
 	webhooks := vault.NewAgentInjectorWebHook(vaultNamespace.Name)
 	mutatingWebhookConfiguration := kubernetes.NewMutatingWebhookConfiguration(workshop, r.Scheme,
-		VAULTAGENT_WEBHOOK_NAME, VaultAgentLabels, webhooks)
+		util.ScopedName(workshop, VAULTAGENT_WEBHOOK_NAME), VaultAgentLabels, webhooks)
 	// Delete AgentInjectorWebHook
-	if err := r.Delete(context.TODO(), mutatingWebhookConfiguration); err != nil {
+	if err := kubernetes.DeleteIfExists(r, mutatingWebhookConfiguration); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultAgent Mutating Webhook Configuration ", mutatingWebhookConfiguration.Name)
 
-	ocpDeployment := vault.NewAgentInjectorDeployment(workshop, r.Scheme, VAULTAGENT_DEPLOYMENT_NAME, VAULT_NAMESPACE_NAME, VaultAgentLabels)
+	ocpDeployment := vault.NewAgentInjectorDeployment(workshop, r.Scheme, VAULTAGENT_DEPLOYMENT_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultAgentLabels)
 	// Delete Deployment
-	if err := r.Delete(context.TODO(), ocpDeployment); err != nil {
+	if err := kubernetes.DeleteIfExists(r, ocpDeployment); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultAgent Deployment ", ocpDeployment.Name)
 
-	service := kubernetes.NewServiceWithTarget(workshop, r.Scheme, VAULTAGENT_SERVICE_NAME, VAULT_NAMESPACE_NAME, VaultAgentLabels,
+	service := kubernetes.NewServiceWithTarget(workshop, r.Scheme, VAULTAGENT_SERVICE_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultAgentLabels,
 		[]string{"http"}, []int32{443}, []int32{8080})
 	// Delete Service
-	if err := r.Delete(context.TODO(), service); err != nil {
+	if err := kubernetes.DeleteIfExists(r, service); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultAgent Service ", service.Name)
 
-	clusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, VAULTAGENT_ROLEBINDING_NAME, VAULT_NAMESPACE_NAME,
+	clusterRoleBinding := kubernetes.NewClusterRoleBindingSA(workshop, r.Scheme, util.ScopedName(workshop, VAULTAGENT_ROLEBINDING_NAME), util.ScopedName(workshop, VAULT_NAMESPACE_NAME),
 		VaultAgentLabels, VAULTAGENT_SERVICEACCOUNT_NAME, clusterRole.Name, KIND_CLUSTER_ROLE)
 	// Delete Cluster Role Binding
-	if err := r.Delete(context.TODO(), clusterRoleBinding); err != nil {
+	if err := kubernetes.DeleteIfExists(r, clusterRoleBinding); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultAgent Cluster Role Binding", clusterRoleBinding.Name)
 
 	// Delete Cluster Role
-	if err := r.Delete(context.TODO(), clusterRole); err != nil {
+	if err := kubernetes.DeleteIfExists(r, clusterRole); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s VaultAgent Cluster Role", clusterRole.Name)
 
 	vaultAgentSCC := &securityv1.SecurityContextConstraints{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Name: "vault"}, vaultAgentSCC); err == nil {
-		if err := r.Delete(context.TODO(), vaultAgentSCC); err != nil {
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: util.ScopedName(workshop, VAULT_SCC_NAME)}, vaultAgentSCC); err == nil {
+		if err := kubernetes.DeleteIfExists(r, vaultAgentSCC); err != nil {
 			return reconcile.Result{}, err
 		}
 		log.Infof("Deleted %s vaultAgentSCC ", vaultAgentSCC.Name)
 	}
 
-	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, VAULTAGENT_SERVICEACCOUNT_NAME, VAULT_NAMESPACE_NAME, VaultAgentLabels)
+	serviceAccount := kubernetes.NewServiceAccount(workshop, r.Scheme, VAULTAGENT_SERVICEACCOUNT_NAME, util.ScopedName(workshop, VAULT_NAMESPACE_NAME), VaultAgentLabels)
 	// Delete  Service Account
-	if err := r.Delete(context.TODO(), serviceAccount); err != nil {
+	if err := kubernetes.DeleteIfExists(r, serviceAccount); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s  VaultAgent Service Account", serviceAccount.Name)
@@ -424,9 +435,9 @@ func (r *WorkshopReconciler) deleteVaultAgentInjector(workshop *workshopv1.Works
 func (r *WorkshopReconciler) deleteVaultNamespace(workshop *workshopv1.Workshop) (reconcile.Result, error) {
 
 	log.Infoln("Deleting Namespace")
-	vaultNamespace := kubernetes.NewNamespace(workshop, r.Scheme, VAULT_NAMESPACE_NAME)
+	vaultNamespace := kubernetes.NewNamespace(workshop, r.Scheme, util.ScopedName(workshop, VAULT_NAMESPACE_NAME))
 	// Delete Namespace
-	if err := r.Delete(context.TODO(), vaultNamespace); err != nil {
+	if err := kubernetes.DeleteIfExists(r, vaultNamespace); err != nil {
 		return reconcile.Result{}, err
 	}
 	log.Infof("Deleted %s Namespace", vaultNamespace.Name)
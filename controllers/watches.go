@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"sync"
+
+	argocdoperatorv1 "github.com/argoproj-labs/argocd-operator/pkg/apis/argoproj/v1alpha1"
+	argocdv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	che "github.com/eclipse/che-operator/pkg/apis/org/v1"
+	maistrav1 "github.com/maistra/istio-operator/pkg/apis/maistra/v1"
+	maistrav2 "github.com/maistra/istio-operator/pkg/apis/maistra/v2"
+	routev1 "github.com/openshift/api/route/v1"
+	securityv1 "github.com/openshift/api/security/v1"
+	userv1 "github.com/openshift/api/user/v1"
+	olmv1 "github.com/operator-framework/api/pkg/operators/v1"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/prometheus/common/log"
+	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/certmanager"
+	"github.com/stakater/workshop-operator/common/gitea"
+	"github.com/stakater/workshop-operator/common/kubernetes"
+	"github.com/stakater/workshop-operator/common/nexus"
+	admissionregistration "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	rbac "k8s.io/api/rbac/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// managedKinds returns every kind the operator creates, these are watched to undo out-of-band changes
+func managedKinds() []runtime.Object {
+	return []runtime.Object{
+		// Core
+		&corev1.Namespace{},
+		&corev1.Secret{},
+		&corev1.ConfigMap{},
+		&corev1.Service{},
+		&corev1.ServiceAccount{},
+		&corev1.PersistentVolumeClaim{},
+		&corev1.ResourceQuota{},
+		&corev1.LimitRange{},
+		&appsv1.Deployment{},
+		&appsv1.StatefulSet{},
+		&networkingv1beta1.Ingress{},
+		&networkingv1.NetworkPolicy{},
+		&rbac.Role{},
+		&rbac.RoleBinding{},
+		&rbac.ClusterRole{},
+		&rbac.ClusterRoleBinding{},
+		&apiextensionsv1beta1.CustomResourceDefinition{},
+		&admissionregistration.MutatingWebhookConfiguration{},
+		// Workshop, instructors release and reassign users in the WorkshopAttendees
+		&workshopv1.WorkshopAttendee{},
+		// OpenShift
+		&routev1.Route{},
+		&userv1.User{},
+		&userv1.Identity{},
+		&userv1.Group{},
+		&securityv1.SecurityContextConstraints{},
+		// OLM
+		&olmv1alpha1.Subscription{},
+		&olmv1.OperatorGroup{},
+		// Components, only served once their operator is installed
+		&gitea.Gitea{},
+		&nexus.Nexus{},
+		&certmanager.CertManager{},
+		&argocdoperatorv1.ArgoCD{},
+		&argocdv1.AppProject{},
+		&che.CheCluster{},
+		&maistrav2.ServiceMeshControlPlane{},
+		&maistrav1.ServiceMeshMemberRoll{},
+	}
+}
+
+// workshopForObject maps a managed object back to its Workshop using the workshop labels
+func workshopForObject(obj handler.MapObject) []reconcile.Request {
+	if obj.Meta == nil {
+		return nil
+	}
+	if workshop, ok := kubernetes.WorkshopFromLabels(obj.Meta.GetLabels()); ok {
+		return []reconcile.Request{{NamespacedName: workshop}}
+	}
+	return nil
+}
+
+// workshopsForObject maps a watched object to the Workshop managing it, to the Workshops sharing it, to the Workshops
+// reading their roster or seed from it and to the Workshops waiting for it to log in
+func (r *WorkshopReconciler) workshopsForObject(obj handler.MapObject) []reconcile.Request {
+	requests := workshopForObject(obj)
+	requests = append(requests, r.workshopsForSharedObject(obj)...)
+	switch obj.Object.(type) {
+	case *corev1.ConfigMap:
+		requests = append(requests, r.workshopsForRoster(obj)...)
+		requests = append(requests, r.workshopsForSeed(obj)...)
+	case *corev1.Secret:
+		requests = append(requests, r.workshopsForRoster(obj)...)
+	case *userv1.User:
+		requests = append(requests, r.workshopsForUser(obj)...)
+	}
+	return requests
+}
+
+// watchSet records the kinds the controller watches
+type watchSet struct {
+	mu      sync.Mutex
+	watched map[string]bool
+}
+
+// ensureWatches starts a watch for every managed kind the cluster serves.
+// Kinds backed by CRDs installed later, e.g. Gitea, are picked up on a later reconcile.
+func (r *WorkshopReconciler) ensureWatches() {
+	if r.controller == nil || r.watches == nil {
+		return
+	}
+	r.watches.mu.Lock()
+	defer r.watches.mu.Unlock()
+
+	for _, obj := range managedKinds() {
+		gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+		if err != nil {
+			log.Errorf("Failed to get kind of %T: %s", obj, err)
+			continue
+		}
+		if r.watches.watched[gvk.String()] {
+			continue
+		}
+		if _, err := r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			log.Debugf("Not watching %s yet: %s", gvk.Kind, err)
+			continue
+		}
+		if err := r.controller.Watch(&source.Kind{Type: obj}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.workshopsForObject),
+		}); err != nil {
+			log.Errorf("Failed to watch %s: %s", gvk.Kind, err)
+			continue
+		}
+		r.watches.watched[gvk.String()] = true
+		log.Infof("Watching %s", gvk.Kind)
+	}
+}
@@ -14,21 +14,24 @@ package controllers
 
 import (
 	"context"
-	"regexp"
+	"fmt"
 
 	"github.com/go-logr/logr"
-	routev1 "github.com/openshift/api/route/v1"
 	"github.com/prometheus/common/log"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	workshopv1 "github.com/stakater/workshop-operator/api/v1"
+	"github.com/stakater/workshop-operator/common/component"
+	"github.com/stakater/workshop-operator/common/platform"
 	"github.com/stakater/workshop-operator/common/util"
 )
 
@@ -37,6 +40,17 @@ type WorkshopReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+	// Platform selects Routes or Ingresses, OpenShift users or ServiceAccounts
+	Platform platform.Platform
+	// PortalImage is the image of the portal server, the image of the operator which ships it
+	PortalImage string
+
+	graph *component.Graph
+
+	controller controller.Controller
+	mapper     meta.RESTMapper
+	// watches is a pointer so copies of the reconciler, e.g. the planner, share it
+	watches *watchSet
 }
 
 // Finalizer
@@ -45,15 +59,21 @@ const workshopFinalizer = "finalizer.workshop.stakater.com"
 // +kubebuilder:rbac:groups=workshop.stakater.com,resources=workshops;workshops/finalizers,verbs=*
 // +kubebuilder:rbac:groups=workshop.stakater.com,resources=workshops/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=workshop.stakater.com,resources=workshops,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=workshop.stakater.com,resources=workshopattendees,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=workshop.stakater.com,resources=workshopattendees/status,verbs=get;update;patch
 
-// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=create;delete
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
-// +kubebuilder:rbac:groups=core,resources=pods;services;endpoints;persistentvolumeclaims;events;configmaps;secrets;namespaces;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods;services;endpoints;persistentvolumeclaims;events;configmaps;secrets;namespaces;serviceaccounts;resourcequotas;limitranges,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes/custom-host,verbs=create;update;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,verbs=create;list;watch;update;patch;get;delete
 // +kubebuilder:rbac:groups=project.openshift.io,resources=projectrequests,verbs=create
-// +kubebuilder:rbac:groups=user.openshift.io,resources=users;identities;useridentitymappings,verbs=create;list;watch;update;get;delete
+// +kubebuilder:rbac:groups=user.openshift.io,resources=users;groups;identities;useridentitymappings,verbs=create;list;watch;update;patch;get;delete
+// +kubebuilder:rbac:groups=config.openshift.io,resources=clusteroperators;ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=oauths,verbs=get;list;watch;update;patch
 
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings;clusterroles;clusterrolebindings,verbs=*
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch;delete
@@ -70,6 +90,9 @@ func (r *WorkshopReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	ctx := context.Background()
 	reqLogger := r.Log.WithValues("workshop", req.NamespacedName)
 
+	// Watch kinds whose CRDs were installed since the last reconcile
+	r.ensureWatches()
+
 	// Fetch the Workshop workshop
 	workshop := &workshopv1.Workshop{}
 	err := r.Get(ctx, req.NamespacedName, workshop)
@@ -89,27 +112,25 @@ func (r *WorkshopReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	//////////////////////////
 	// Variables
 	//////////////////////////
-	var (
-		openshiftConsoleURL string
-		appsHostnameSuffix  string
-	)
-	// extract app route suffix from openshift-console
-	route := &routev1.Route{}
-	if err := r.Get(ctx, types.NamespacedName{Name: "console", Namespace: "openshift-console"}, route); err != nil {
-		log.Errorf("Failed to get OpenShift Console: %s", err)
-		return reconcile.Result{}, err
+	appsHostnameSuffix, openshiftConsoleURL, err := r.resolveAppsDomain(workshop)
+	if err != nil {
+		log.Errorf("Failed to resolve the apps domain: %s", err)
+		// Teardown does not need the apps domain, so it must not block deletion
+		if workshop.GetDeletionTimestamp() == nil {
+			return r.updateStatus(workshop, reconcile.Result{}, err)
+		}
 	}
-	openshiftConsoleURL = "https://" + route.Spec.Host
 	log.Infof("OpenShift Console URL %s", openshiftConsoleURL)
-
-	re := regexp.MustCompile(`^console-openshift-console.(.*?)$`)
-	match := re.FindStringSubmatch(route.Spec.Host)
-	appsHostnameSuffix = match[1]
 	log.Infof("Apps Hostname Suffix %s", appsHostnameSuffix)
 
-	users := workshop.Spec.UserDetails.NumberOfUsers
-	if users < 0 {
-		users = 0
+	attendees, err := r.resolveAttendees(workshop)
+	if err != nil {
+		log.Errorf("Failed to resolve the attendees: %s", err)
+		// Teardown falls back to the attendees provisioned so far
+		if workshop.GetDeletionTimestamp() == nil {
+			return r.updateStatus(workshop, reconcile.Result{}, err)
+		}
+		attendees = []workshopv1.Attendee{}
 	}
 	// Handle Cleanup on Deletion
 
@@ -124,12 +145,22 @@ func (r *WorkshopReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			if err := r.finalizeWorkshop(reqLogger, workshop); err != nil {
 				return ctrl.Result{}, err
 			}
-			_, _ = r.handleDelete(ctx, req, workshop, users, appsHostnameSuffix, openshiftConsoleURL)
+			// A workshop refused for its naming scope must not delete the objects of the workshop keeping it
+			owner, err := r.namingScopeOwner(workshop)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			// Keep the finalizer until every component is removed
+			if owner == nil {
+				if result, err := r.handleDelete(ctx, req, workshop, withRemovedAttendees(workshop, attendees), appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
+					return r.updateStatus(workshop, result, err)
+				}
+			}
 			// Remove workshopFinalizer. Once all finalizers have been
 			// removed, the object will be deleted.
 			controllerutil.RemoveFinalizer(workshop, workshopFinalizer)
 			log.Info("Finalizer removed for workshop" + workshop.ObjectMeta.Name)
-			err := r.Update(ctx, workshop)
+			err = r.Update(ctx, workshop)
 			if err != nil {
 				return ctrl.Result{}, err
 			}
@@ -138,168 +169,130 @@ func (r *WorkshopReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
-	// Add finalizer for this CR
-	if !util.Contains(workshop.GetFinalizers(), workshopFinalizer) {
-		if err := r.addFinalizer(reqLogger, workshop); err != nil {
-			return ctrl.Result{}, err
-		}
-	}
-	//////////////////////////
-	// Users
-	//////////////////////////
-	if result, err := r.reconcileUser(workshop); util.IsRequeued(result, err) {
-		return ctrl.Result{}, err
-	}
-
-	//////////////////////////
-	// Portal
-	//////////////////////////
-	if result, err := r.reconcilePortal(workshop, users, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	//////////////////////////
-	// Projects
-	//////////////////////////
-	if result, err := r.reconcileProject(workshop, users); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	//////////////////////////
-	// Bookbag
-	//////////////////////////
-	if result, err := r.reconcileBookbag(workshop, users, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	//////////////////////////
-	// Nexus
-	//////////////////////////
-	if result, err := r.reconcileNexus(workshop); util.IsRequeued(result, err) {
-		return result, err
+	// Workshops sharing a naming scope would provision and delete the same objects
+	if err := r.checkNamingScope(workshop); err != nil {
+		return r.updateStatus(workshop, reconcile.Result{}, err)
 	}
 
-	//////////////////////////
-	// Gitea
-	//////////////////////////
-	if result, err := r.reconcileGitea(workshop, users); util.IsRequeued(result, err) {
-		return result, err
+	// Attendees of an external identity provider are provisioned once they logged in
+	attendees, err = r.loggedInAttendees(workshop, attendees)
+	if err != nil {
+		return r.updateStatus(workshop, reconcile.Result{}, err)
 	}
 
-	//////////////////////////
-	// Pipeline
-	//////////////////////////
-	if result, err := r.reconcilePipelines(workshop); util.IsRequeued(result, err) {
-		return result, err
+	env := &component.Environment{
+		Client:              r.Client,
+		Scheme:              r.Scheme,
+		Workshop:            workshop,
+		Users:               len(attendees),
+		AppsHostnameSuffix:  appsHostnameSuffix,
+		OpenshiftConsoleURL: openshiftConsoleURL,
+		Platform:            r.Platform,
+		Attendees:           attendees,
+		RemovedAttendees:    removedAttendees(workshop, attendees),
 	}
 
-	//////////////////////////
-	// GitOps
-	//////////////////////////
-	if result, err := r.reconcileGitOps(workshop, users, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
-		return result, err
+	// Report the changes without applying them, nothing is created so no finalizer is needed
+	if isPlanMode(workshop) {
+		result, err := r.planComponents(env)
+		return r.updateStatus(workshop, result, err)
 	}
-
-	//////////////////////////
-	// CodeReadyWorkspace
-	//////////////////////////
-	if result, err := r.reconcileCodeReadyWorkspace(workshop, users, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
-		return result, err
+	if err := r.clearPlan(workshop); err != nil {
+		return r.updateStatus(workshop, reconcile.Result{}, err)
 	}
 
-	//////////////////////////
-	// Service Mesh
-	//////////////////////////
-	if result, err := r.reconcileServiceMesh(workshop, users); util.IsRequeued(result, err) {
-		return result, err
+	// Add finalizer for this CR
+	if !util.Contains(workshop.GetFinalizers(), workshopFinalizer) {
+		if err := r.addFinalizer(reqLogger, workshop); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	//////////////////////////
-	// Serverless
-	//////////////////////////
-	if result, err := r.reconcileServerless(workshop); util.IsRequeued(result, err) {
-		return result, err
-	}
+	result, err := r.reconcileComponents(env)
+	recordAttendees(workshop, attendees, !util.IsRequeued(result, err))
+	r.setLoginCondition(workshop)
+	r.updateAttendeeStatus(workshop)
+	r.recordPortalURL(workshop)
+	return r.updateStatus(workshop, result, err)
+}
 
-	//////////////////////////
-	// Vault
-	//////////////////////////
-	if result, err := r.reconcileVault(workshop, users); util.IsRequeued(result, err) {
-		return result, err
+func (r *WorkshopReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Built-in components first, followed by any registered from other packages
+	graph, err := component.NewGraph(append(r.builtinComponents(), component.Registered()...))
+	if err != nil {
+		return err
 	}
+	r.graph = graph
 
-	//////////////////////////
-	// Cert Manager
-	//////////////////////////
-	if result, err := r.reconcileCertManager(workshop, users); util.IsRequeued(result, err) {
-		return result, err
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		For(&workshopv1.Workshop{}).
+		Build(r)
+	if err != nil {
+		return err
 	}
+	r.controller = c
+	r.mapper = mgr.GetRESTMapper()
+	r.watches = &watchSet{watched: make(map[string]bool)}
+	r.ensureWatches()
 
-	return ctrl.Result{}, nil
+	return nil
 }
 
-func (r *WorkshopReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&workshopv1.Workshop{}).
-		Complete(r)
-}
-
-func (r *WorkshopReconciler) handleDelete(ctx context.Context, req ctrl.Request, workshop *workshopv1.Workshop, userID int, appsHostnameSuffix string, openshiftConsoleURL string) (ctrl.Result, error) {
+func (r *WorkshopReconciler) handleDelete(ctx context.Context, req ctrl.Request, workshop *workshopv1.Workshop, attendees []workshopv1.Attendee, appsHostnameSuffix string, openshiftConsoleURL string) (ctrl.Result, error) {
 	log := r.Log.WithValues("workshop", req.NamespacedName)
 	log.Info("Deleting workshop   " + workshop.ObjectMeta.Name)
 
-	if result, err := r.deleteUsers(workshop); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	if result, err := r.deletePortal(workshop, userID, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
-		return result, err
+	env := &component.Environment{
+		Client:              r.Client,
+		Scheme:              r.Scheme,
+		Workshop:            workshop,
+		Users:               len(attendees),
+		AppsHostnameSuffix:  appsHostnameSuffix,
+		OpenshiftConsoleURL: openshiftConsoleURL,
+		Platform:            r.Platform,
+		Attendees:           attendees,
 	}
-
-	if result, err := r.deleteProject(workshop, userID); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	if result, err := r.deleteBookbag(workshop, userID, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	if result, err := r.deleteNexus(workshop); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	if result, err := r.deleteGitea(workshop); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	if result, err := r.deletePipelines(workshop); util.IsRequeued(result, err) {
-
-		return result, err
-	}
-
-	if result, err := r.deleteGitOps(workshop, userID, appsHostnameSuffix, openshiftConsoleURL); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	if result, err := r.deleteCodeReadyWorkspace(workshop, userID, appsHostnameSuffix); util.IsRequeued(result, err) {
-		return result, err
-	}
-
-	if result, err := r.deleteServiceMeshService(workshop, userID); util.IsRequeued(result, err) {
-		return result, err
+	// Delete dependents before their dependencies, a component is only deleted once everything depending on it is gone
+	dependents := make(map[string][]string)
+	components := r.graph.Sorted()
+	for _, c := range components {
+		for _, dependency := range r.graph.Dependencies(c.Name()) {
+			dependents[dependency.Name()] = append(dependents[dependency.Name()], c.Name())
+		}
 	}
 
-	if result, err := r.deleteServerless(workshop); util.IsRequeued(result, err) {
-		return result, err
-	}
+	result := ctrl.Result{}
+	errs := []error{}
+	removed := make(map[string]bool)
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+		if isRemoved(workshop, c) {
+			removed[c.Name()] = true
+			continue
+		}
 
-	if result, err := r.deleteVault(workshop); util.IsRequeued(result, err) {
-		return result, err
-	}
+		waitingOn := []string{}
+		for _, dependent := range dependents[c.Name()] {
+			if !removed[dependent] {
+				waitingOn = append(waitingOn, dependent)
+			}
+		}
+		if len(waitingOn) > 0 {
+			r.setComponentWaiting(workshop, c, waitingOn)
+			result.Requeue = true
+			continue
+		}
 
-	if result, err := r.deleteCertManager(workshop); util.IsRequeued(result, err) {
-		return result, err
+		componentResult, err := c.Delete(env)
+		r.setComponentRemoval(workshop, c, componentResult, err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", c.Name(), err))
+		} else if util.IsRequeued(componentResult, err) {
+			result.Requeue = true
+		} else {
+			removed[c.Name()] = true
+		}
 	}
 
-	return ctrl.Result{}, nil
+	return result, utilerrors.NewAggregate(errs)
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/stakater/workshop-operator/common/certmanager"
 	"github.com/stakater/workshop-operator/common/gitea"
 	"github.com/stakater/workshop-operator/common/nexus"
+	"github.com/stakater/workshop-operator/common/platform"
 	"github.com/stakater/workshop-operator/controllers"
 
 	kiali "github.com/maistra/istio-operator/pkg/apis/external/kiali/v1alpha1"
@@ -82,10 +83,13 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var portalImage string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&portalImage, "portal-image", os.Getenv("PORTAL_IMAGE"),
+		"The image of the attendee portal, the image of the operator as it ships the portal binary.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -102,10 +106,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	clusterPlatform, err := platform.Detect(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to detect the cluster platform")
+		os.Exit(1)
+	}
+	setupLog.Info("detected cluster platform", "platform", clusterPlatform)
+
 	if err = (&controllers.WorkshopReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Workshop"),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("Workshop"),
+		Scheme:   mgr.GetScheme(),
+		Platform: clusterPlatform,
+
+		PortalImage: portalImage,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Workshop")
 		os.Exit(1)